@@ -201,6 +201,16 @@ var (
 		utils.InsecureUnlockAllowedFlag,
 		utils.RPCGlobalGasCapFlag,
 		utils.RPCGlobalTxFeeCapFlag,
+		utils.TraceWorkersFlag,
+		utils.TraceQueueTimeoutFlag,
+		utils.TraceRedactFlag,
+		utils.TraceRedactHashFlag,
+		utils.TraceFilterSizeLimitFlag,
+		utils.TraceFilterBufferFlag,
+		utils.TraceMaxSubscriptionsFlag,
+		utils.TraceCallManyLimitFlag,
+		utils.TraceIndexFlag,
+		utils.TraceIndexRetentionFlag,
 	}
 
 	whisperFlags = []cli.Flag{
@@ -154,6 +154,16 @@ var AppHelpFlagGroups = []flags.FlagGroup{
 			utils.GraphQLVirtualHostsFlag,
 			utils.RPCGlobalGasCapFlag,
 			utils.RPCGlobalTxFeeCapFlag,
+			utils.TraceWorkersFlag,
+			utils.TraceQueueTimeoutFlag,
+			utils.TraceRedactFlag,
+			utils.TraceRedactHashFlag,
+			utils.TraceFilterSizeLimitFlag,
+			utils.TraceFilterBufferFlag,
+			utils.TraceMaxSubscriptionsFlag,
+			utils.TraceCallManyLimitFlag,
+			utils.TraceIndexFlag,
+			utils.TraceIndexRetentionFlag,
 			utils.JSpathFlag,
 			utils.ExecFlag,
 			utils.PreloadJSFlag,
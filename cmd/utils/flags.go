@@ -504,6 +504,54 @@ var (
 		Usage: "Sets a cap on transaction fee (in ether) that can be sent via the RPC APIs (0 = no cap)",
 		Value: eth.DefaultConfig.RPCTxFeeCap,
 	}
+	TraceWorkersFlag = cli.IntFlag{
+		Name:  "trace.workers",
+		Usage: "Maximum number of traces executed concurrently across all trace API requests (0 = no limit)",
+		Value: eth.DefaultConfig.Trace.Workers,
+	}
+	TraceQueueTimeoutFlag = cli.DurationFlag{
+		Name:  "trace.queuetimeout",
+		Usage: "Maximum time a trace waits for a free trace worker before failing (0 = no timeout)",
+		Value: eth.DefaultConfig.Trace.QueueTimeout,
+	}
+	TraceRedactFlag = cli.StringFlag{
+		Name:  "trace.redact",
+		Usage: "Comma separated list of trace fields (e.g. action.input) stripped from all trace API outputs",
+		Value: "",
+	}
+	TraceFilterSizeLimitFlag = cli.IntFlag{
+		Name:  "trace.filtersizelimit",
+		Usage: "Maximum size in bytes of the block traces streamed by a single trace_filter request (0 = no limit)",
+		Value: eth.DefaultConfig.Trace.FilterSizeLimit,
+	}
+	TraceFilterBufferFlag = cli.IntFlag{
+		Name:  "trace.filterbuffer",
+		Usage: "Number of blocks a trace_filter subscription traces ahead of its client (0 = number of CPUs)",
+		Value: eth.DefaultConfig.Trace.FilterBuffer,
+	}
+	TraceMaxSubscriptionsFlag = cli.IntFlag{
+		Name:  "trace.maxsubscriptions",
+		Usage: "Maximum number of trace subscriptions active at once on a single RPC connection (0 = no limit)",
+		Value: eth.DefaultConfig.Trace.MaxSubscriptions,
+	}
+	TraceCallManyLimitFlag = cli.IntFlag{
+		Name:  "trace.callmanylimit",
+		Usage: "Maximum number of calls traced by a single trace_callMany request (0 = no limit)",
+		Value: eth.DefaultConfig.Trace.CallManyLimit,
+	}
+	TraceIndexFlag = cli.BoolFlag{
+		Name:  "trace.index",
+		Usage: "Trace newly imported blocks to index their trace addresses, speeding up address filtered trace_filter calls",
+	}
+	TraceIndexRetentionFlag = cli.Uint64Flag{
+		Name:  "trace.indexretention",
+		Usage: "Number of recent blocks kept in the trace index, older ones being pruned (0 = unlimited)",
+		Value: eth.DefaultConfig.Trace.IndexRetention,
+	}
+	TraceRedactHashFlag = cli.BoolFlag{
+		Name:  "trace.redacthash",
+		Usage: "Replace the redacted trace fields with their keccak256 hash instead of stripping them",
+	}
 	// Logging and debug settings
 	EthStatsURLFlag = cli.StringFlag{
 		Name:  "ethstats",
@@ -1699,6 +1747,36 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *eth.Config) {
 	if ctx.GlobalIsSet(RPCGlobalTxFeeCapFlag.Name) {
 		cfg.RPCTxFeeCap = ctx.GlobalFloat64(RPCGlobalTxFeeCapFlag.Name)
 	}
+	if ctx.GlobalIsSet(TraceWorkersFlag.Name) {
+		cfg.Trace.Workers = ctx.GlobalInt(TraceWorkersFlag.Name)
+	}
+	if ctx.GlobalIsSet(TraceQueueTimeoutFlag.Name) {
+		cfg.Trace.QueueTimeout = ctx.GlobalDuration(TraceQueueTimeoutFlag.Name)
+	}
+	if ctx.GlobalIsSet(TraceRedactFlag.Name) {
+		cfg.Trace.Redact = SplitAndTrim(ctx.GlobalString(TraceRedactFlag.Name))
+	}
+	if ctx.GlobalIsSet(TraceRedactHashFlag.Name) {
+		cfg.Trace.RedactHash = ctx.GlobalBool(TraceRedactHashFlag.Name)
+	}
+	if ctx.GlobalIsSet(TraceFilterSizeLimitFlag.Name) {
+		cfg.Trace.FilterSizeLimit = ctx.GlobalInt(TraceFilterSizeLimitFlag.Name)
+	}
+	if ctx.GlobalIsSet(TraceFilterBufferFlag.Name) {
+		cfg.Trace.FilterBuffer = ctx.GlobalInt(TraceFilterBufferFlag.Name)
+	}
+	if ctx.GlobalIsSet(TraceMaxSubscriptionsFlag.Name) {
+		cfg.Trace.MaxSubscriptions = ctx.GlobalInt(TraceMaxSubscriptionsFlag.Name)
+	}
+	if ctx.GlobalIsSet(TraceCallManyLimitFlag.Name) {
+		cfg.Trace.CallManyLimit = ctx.GlobalInt(TraceCallManyLimitFlag.Name)
+	}
+	if ctx.GlobalIsSet(TraceIndexFlag.Name) {
+		cfg.Trace.Index = ctx.GlobalBool(TraceIndexFlag.Name)
+	}
+	if ctx.GlobalIsSet(TraceIndexRetentionFlag.Name) {
+		cfg.Trace.IndexRetention = ctx.GlobalUint64(TraceIndexRetentionFlag.Name)
+	}
 	if ctx.GlobalIsSet(DNSDiscoveryFlag.Name) {
 		urls := ctx.GlobalString(DNSDiscoveryFlag.Name)
 		if urls == "" {
@@ -18,6 +18,7 @@ package rawdb
 
 import (
 	"bytes"
+	"encoding/binary"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -175,3 +176,153 @@ func DeleteBloombits(db ethdb.Database, bit uint, from uint64, to uint64) {
 		log.Crit("Failed to delete bloom bits", "err", it.Error())
 	}
 }
+
+// ReadTraceIndexHead retrieves the hash of the latest block whose trace addresses
+// have been indexed.
+func ReadTraceIndexHead(db ethdb.KeyValueReader) common.Hash {
+	data, _ := db.Get(traceIndexHeadKey)
+	if len(data) == 0 {
+		return common.Hash{}
+	}
+	return common.BytesToHash(data)
+}
+
+// WriteTraceIndexHead stores the hash of the latest block whose trace addresses
+// have been indexed.
+func WriteTraceIndexHead(db ethdb.KeyValueWriter, hash common.Hash) {
+	if err := db.Put(traceIndexHeadKey, hash.Bytes()); err != nil {
+		log.Crit("Failed to store the trace index head", "err", err)
+	}
+}
+
+// ReadTraceIndexTail retrieves the number of the oldest block whose trace
+// addresses have been indexed.
+func ReadTraceIndexTail(db ethdb.KeyValueReader) *uint64 {
+	data, _ := db.Get(traceIndexTailKey)
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// WriteTraceIndexTail stores the number of the oldest block whose trace addresses
+// have been indexed.
+func WriteTraceIndexTail(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Put(traceIndexTailKey, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store the trace index tail", "err", err)
+	}
+}
+
+// ReadTraceIndexAddresses retrieves the addresses indexed for the traces of the
+// given block, reporting whether the block was indexed at all.
+func ReadTraceIndexAddresses(db ethdb.KeyValueReader, number uint64, hash common.Hash) ([]common.Address, bool) {
+	data, _ := db.Get(traceIndexBlockKey(number, hash))
+	if len(data) == 0 {
+		return nil, false
+	}
+	var addresses []common.Address
+	if err := rlp.DecodeBytes(data, &addresses); err != nil {
+		log.Error("Invalid trace index RLP", "number", number, "hash", hash, "err", err)
+		return nil, false
+	}
+	return addresses, true
+}
+
+// WriteTraceIndexEntries stores the addresses of the traces of the given block,
+// both as the block's own entry and as a lookup entry for each address.
+func WriteTraceIndexEntries(db ethdb.KeyValueWriter, number uint64, hash common.Hash, addresses []common.Address) {
+	data, err := rlp.EncodeToBytes(addresses)
+	if err != nil {
+		log.Crit("Failed to RLP encode trace addresses", "err", err)
+	}
+	if err := db.Put(traceIndexBlockKey(number, hash), data); err != nil {
+		log.Crit("Failed to store trace index entry", "err", err)
+	}
+	for _, address := range addresses {
+		if err := db.Put(traceIndexAddressKey(address, number, hash), nil); err != nil {
+			log.Crit("Failed to store trace address lookup entry", "err", err)
+		}
+	}
+}
+
+// DeleteTraceIndexEntries removes the trace index entries of the given block,
+// its indexed addresses being those returned by ReadTraceIndexAddresses.
+func DeleteTraceIndexEntries(db ethdb.KeyValueWriter, number uint64, hash common.Hash, addresses []common.Address) {
+	for _, address := range addresses {
+		if err := db.Delete(traceIndexAddressKey(address, number, hash)); err != nil {
+			log.Crit("Failed to delete trace address lookup entry", "err", err)
+		}
+	}
+	if err := db.Delete(traceIndexBlockKey(number, hash)); err != nil {
+		log.Crit("Failed to delete trace index entry", "err", err)
+	}
+}
+
+// ReadTraceIndexBlocks retrieves the blocks in the given inclusive number range
+// whose traces involve the given address, along with the hashes of the blocks
+// indexed at each number.
+func ReadTraceIndexBlocks(db ethdb.Iteratee, address common.Address, from uint64, to uint64) map[uint64][]common.Hash {
+	prefix := append(append([]byte{}, traceIndexAddressPrefix...), address.Bytes()...)
+	it := db.NewIterator(prefix, encodeBlockNumber(from))
+	defer it.Release()
+
+	blocks := make(map[uint64][]common.Hash)
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+common.HashLength {
+			continue
+		}
+		number := binary.BigEndian.Uint64(key[len(prefix):])
+		if number > to {
+			break
+		}
+		blocks[number] = append(blocks[number], common.BytesToHash(key[len(prefix)+8:]))
+	}
+	if it.Error() != nil {
+		log.Error("Failed to iterate the trace index", "address", address, "err", it.Error())
+	}
+	return blocks
+}
+
+// PruneTraceIndex removes the trace index entries of all the blocks numbered
+// below the given limit, returning the number of block entries removed.
+func PruneTraceIndex(db ethdb.Database, limit uint64) int {
+	it := db.NewIterator(traceIndexBlockPrefix, nil)
+	defer it.Release()
+
+	var (
+		batch  = db.NewBatch()
+		pruned int
+	)
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(traceIndexBlockPrefix)+8+common.HashLength {
+			continue
+		}
+		number := binary.BigEndian.Uint64(key[len(traceIndexBlockPrefix):])
+		if number >= limit {
+			break
+		}
+		var addresses []common.Address
+		if err := rlp.DecodeBytes(it.Value(), &addresses); err != nil {
+			log.Error("Invalid trace index RLP", "number", number, "err", err)
+		}
+		DeleteTraceIndexEntries(batch, number, common.BytesToHash(key[len(traceIndexBlockPrefix)+8:]), addresses)
+		pruned++
+
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				log.Crit("Failed to prune the trace index", "err", err)
+			}
+			batch.Reset()
+		}
+	}
+	if it.Error() != nil {
+		log.Error("Failed to iterate the trace index", "err", it.Error())
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to prune the trace index", "err", err)
+	}
+	return pruned
+}
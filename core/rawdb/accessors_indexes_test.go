@@ -177,3 +177,83 @@ func TestDeleteBloomBits(t *testing.T) {
 	check(1, 1, params.MainnetGenesisHash, true)
 	check(1, 1, params.RinkebyGenesisHash, true)
 }
+
+func TestTraceIndexStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	var (
+		alice, bob = common.Address{0x01}, common.Address{0x02}
+		hash1      = common.Hash{0x11}
+		hash2      = common.Hash{0x22}
+		side2      = common.Hash{0x23}
+	)
+	WriteTraceIndexEntries(db, 1, hash1, []common.Address{alice})
+	WriteTraceIndexEntries(db, 2, hash2, []common.Address{alice, bob})
+	WriteTraceIndexEntries(db, 2, side2, []common.Address{bob})
+
+	if addresses, ok := ReadTraceIndexAddresses(db, 2, hash2); !ok || len(addresses) != 2 || addresses[0] != alice || addresses[1] != bob {
+		t.Fatalf("block addresses mismatch: have %v, %v", addresses, ok)
+	}
+	if _, ok := ReadTraceIndexAddresses(db, 3, hash2); ok {
+		t.Fatalf("unindexed block reported as indexed")
+	}
+	if blocks := ReadTraceIndexBlocks(db, alice, 0, 10); len(blocks) != 2 || blocks[1][0] != hash1 || blocks[2][0] != hash2 {
+		t.Fatalf("alice blocks mismatch: have %v", blocks)
+	}
+	if blocks := ReadTraceIndexBlocks(db, alice, 2, 2); len(blocks) != 1 || blocks[2][0] != hash2 {
+		t.Fatalf("alice ranged blocks mismatch: have %v", blocks)
+	}
+	if blocks := ReadTraceIndexBlocks(db, bob, 0, 10); len(blocks) != 1 || len(blocks[2]) != 2 {
+		t.Fatalf("bob blocks mismatch: have %v", blocks)
+	}
+	// Roll back the side block, leaving the canonical one in place
+	addresses, _ := ReadTraceIndexAddresses(db, 2, side2)
+	DeleteTraceIndexEntries(db, 2, side2, addresses)
+
+	if _, ok := ReadTraceIndexAddresses(db, 2, side2); ok {
+		t.Fatalf("deleted block still indexed")
+	}
+	if blocks := ReadTraceIndexBlocks(db, bob, 0, 10); len(blocks) != 1 || len(blocks[2]) != 1 || blocks[2][0] != hash2 {
+		t.Fatalf("bob blocks mismatch after rollback: have %v", blocks)
+	}
+	// Check the markers
+	if head := ReadTraceIndexHead(db); head != (common.Hash{}) {
+		t.Fatalf("unexpected trace index head: %x", head)
+	}
+	WriteTraceIndexHead(db, hash2)
+	WriteTraceIndexTail(db, 1)
+	if head := ReadTraceIndexHead(db); head != hash2 {
+		t.Fatalf("trace index head mismatch: have %x, want %x", head, hash2)
+	}
+	if tail := ReadTraceIndexTail(db); tail == nil || *tail != 1 {
+		t.Fatalf("trace index tail mismatch: have %v, want 1", tail)
+	}
+}
+
+func TestTraceIndexPruning(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	alice, bob := common.Address{0x01}, common.Address{0x02}
+	for number := uint64(1); number <= 4; number++ {
+		WriteTraceIndexEntries(db, number, common.Hash{byte(number)}, []common.Address{alice, bob})
+	}
+	WriteTraceIndexEntries(db, 2, common.Hash{0x22}, []common.Address{bob})
+
+	if pruned := PruneTraceIndex(db, 3); pruned != 3 {
+		t.Fatalf("pruned entry count mismatch: have %d, want 3", pruned)
+	}
+	for _, number := range []uint64{1, 2} {
+		if _, ok := ReadTraceIndexAddresses(db, number, common.Hash{byte(number)}); ok {
+			t.Errorf("block #%d still indexed", number)
+		}
+	}
+	if _, ok := ReadTraceIndexAddresses(db, 2, common.Hash{0x22}); ok {
+		t.Errorf("side block #2 still indexed")
+	}
+	if blocks := ReadTraceIndexBlocks(db, bob, 0, 10); len(blocks) != 2 || len(blocks[3]) != 1 || len(blocks[4]) != 1 {
+		t.Fatalf("bob blocks mismatch after pruning: have %v", blocks)
+	}
+	if pruned := PruneTraceIndex(db, 3); pruned != 0 {
+		t.Fatalf("pruned entry count mismatch on repeat: have %d, want 0", pruned)
+	}
+}
@@ -388,6 +388,7 @@ func InspectDatabase(db ethdb.Database) error {
 		storageSnaps    stat
 		preimages       stat
 		bloomBits       stat
+		traceIndex      stat
 		cliqueSnaps     stat
 
 		// Ancient store statistics
@@ -442,6 +443,10 @@ func InspectDatabase(db ethdb.Database) error {
 			preimages.Add(size)
 		case bytes.HasPrefix(key, bloomBitsPrefix) && len(key) == (len(bloomBitsPrefix)+10+common.HashLength):
 			bloomBits.Add(size)
+		case bytes.HasPrefix(key, traceIndexBlockPrefix) && len(key) == (len(traceIndexBlockPrefix)+8+common.HashLength):
+			traceIndex.Add(size)
+		case bytes.HasPrefix(key, traceIndexAddressPrefix) && len(key) == (len(traceIndexAddressPrefix)+common.AddressLength+8+common.HashLength):
+			traceIndex.Add(size)
 		case bytes.HasPrefix(key, []byte("clique-")) && len(key) == 7+common.HashLength:
 			cliqueSnaps.Add(size)
 		case bytes.HasPrefix(key, []byte("cht-")) && len(key) == 4+common.HashLength:
@@ -450,7 +455,7 @@ func InspectDatabase(db ethdb.Database) error {
 			bloomTrieNodes.Add(size)
 		default:
 			var accounted bool
-			for _, meta := range [][]byte{databaseVerisionKey, headHeaderKey, headBlockKey, headFastBlockKey, fastTrieProgressKey} {
+			for _, meta := range [][]byte{databaseVerisionKey, headHeaderKey, headBlockKey, headFastBlockKey, fastTrieProgressKey, traceIndexHeadKey, traceIndexTailKey} {
 				if bytes.Equal(key, meta) {
 					metadata.Add(size)
 					accounted = true
@@ -490,6 +495,7 @@ func InspectDatabase(db ethdb.Database) error {
 		{"Key-Value store", "Block hash->number", hashNumPairings.Size(), hashNumPairings.Count()},
 		{"Key-Value store", "Transaction index", txLookups.Size(), txLookups.Count()},
 		{"Key-Value store", "Bloombit index", bloomBits.Size(), bloomBits.Count()},
+		{"Key-Value store", "Trace address index", traceIndex.Size(), traceIndex.Count()},
 		{"Key-Value store", "Contract codes", codes.Size(), codes.Count()},
 		{"Key-Value store", "Trie nodes", tries.Size(), tries.Count()},
 		{"Key-Value store", "Trie preimages", preimages.Size(), preimages.Count()},
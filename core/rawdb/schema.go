@@ -63,6 +63,12 @@ var (
 	// fastTxLookupLimitKey tracks the transaction lookup limit during fast sync.
 	fastTxLookupLimitKey = []byte("FastTransactionLookupLimit")
 
+	// traceIndexHeadKey tracks the latest block whose trace addresses have been indexed.
+	traceIndexHeadKey = []byte("TraceIndexHead")
+
+	// traceIndexTailKey tracks the oldest block whose trace addresses have been indexed.
+	traceIndexTailKey = []byte("TraceIndexTail")
+
 	// Data item prefixes (use single byte to avoid mixing data types, avoid `i`, used for indexes).
 	headerPrefix       = []byte("h") // headerPrefix + num (uint64 big endian) + hash -> header
 	headerTDSuffix     = []byte("t") // headerPrefix + num (uint64 big endian) + hash + headerTDSuffix -> td
@@ -84,6 +90,9 @@ var (
 	// Chain index prefixes (use `i` + single byte to avoid mixing data types).
 	BloomBitsIndexPrefix = []byte("iB") // BloomBitsIndexPrefix is the data table of a chain indexer to track its progress
 
+	traceIndexBlockPrefix   = []byte("iT") // traceIndexBlockPrefix + num (uint64 big endian) + hash -> trace addresses of the block
+	traceIndexAddressPrefix = []byte("it") // traceIndexAddressPrefix + address + num (uint64 big endian) + hash -> empty
+
 	preimageCounter    = metrics.NewRegisteredCounter("db/preimage/total", nil)
 	preimageHitCounter = metrics.NewRegisteredCounter("db/preimage/hits", nil)
 )
@@ -201,6 +210,16 @@ func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
 }
 
+// traceIndexBlockKey = traceIndexBlockPrefix + num (uint64 big endian) + hash
+func traceIndexBlockKey(number uint64, hash common.Hash) []byte {
+	return append(append(traceIndexBlockPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// traceIndexAddressKey = traceIndexAddressPrefix + address + num (uint64 big endian) + hash
+func traceIndexAddressKey(address common.Address, number uint64, hash common.Hash) []byte {
+	return append(append(append(traceIndexAddressPrefix, address.Bytes()...), encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
 // accountSnapshotKey = SnapshotAccountPrefix + hash
 func accountSnapshotKey(hash common.Hash) []byte {
 	return append(SnapshotAccountPrefix, hash.Bytes()...)
@@ -133,11 +133,14 @@ func (s *stateObject) EncodeRLP(w io.Writer) error {
 	return rlp.Encode(w, s.data)
 }
 
-// setError remembers the first non-nil error it is called with.
+// setError remembers the first non-nil error it is called with, reporting it to
+// the state database too, as code and storage read failures would otherwise go
+// unnoticed by anyone not committing the object.
 func (s *stateObject) setError(err error) {
 	if s.dbErr == nil {
 		s.dbErr = err
 	}
+	s.db.setError(err)
 }
 
 func (s *stateObject) markSuicided() {
@@ -17,6 +17,8 @@
 package core
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/misc"
@@ -98,17 +100,7 @@ func ApplyTransaction(config ctypes.ChainConfigurator, bc ChainContext, author *
 	// Create a new environment which holds all relevant information
 	// about the transaction and calling mechanisms.
 	vmenv := vm.NewEVM(context, statedb, config, cfg)
-
-	if config.IsEnabled(config.GetEIP2929Transition, header.Number) {
-		statedb.AddAddressToAccessList(msg.From())
-		if dst := msg.To(); dst != nil {
-			statedb.AddAddressToAccessList(*dst)
-			// If it's a create-tx, the destination will be added inside evm.create
-		}
-		for addr := range vm.PrecompiledContractsForConfig(config, header.Number) {
-			statedb.AddAddressToAccessList(addr)
-		}
-	}
+	PrepareAccessList(config, statedb, header.Number, msg)
 
 	// Apply the transaction to the current state (included in the env)
 	result, err := ApplyMessage(vmenv, msg, gp)
@@ -143,3 +135,22 @@ func ApplyTransaction(config ctypes.ChainConfigurator, bc ChainContext, author *
 
 	return receipt, err
 }
+
+// PrepareAccessList warms up the addresses accessed by any message ahead of its
+// execution, i.e. its sender, its destination and the precompiled contracts, if
+// EIP-2929 is enabled at the given block number. Anything re-executing messages
+// outside of block processing (e.g. tracing) must do so too for the gas costs to
+// match.
+func PrepareAccessList(config ctypes.ChainConfigurator, statedb *state.StateDB, number *big.Int, msg Message) {
+	if !config.IsEnabled(config.GetEIP2929Transition, number) {
+		return
+	}
+	statedb.AddAddressToAccessList(msg.From())
+	if dst := msg.To(); dst != nil {
+		statedb.AddAddressToAccessList(*dst)
+		// If it's a create-tx, the destination will be added inside evm.create
+	}
+	for addr := range vm.PrecompiledContractsForConfig(config, number) {
+		statedb.AddAddressToAccessList(addr)
+	}
+}
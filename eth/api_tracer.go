@@ -20,6 +20,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -27,11 +28,13 @@ import (
 	"os"
 	"reflect"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -57,13 +60,88 @@ const (
 	defaultTraceReexec = uint64(128)
 )
 
+// errHistoricalStateUnavailable is returned if the state a trace needs is neither
+// available nor regenerable within the reexec limit.
+var errHistoricalStateUnavailable = errors.New("required historical state unavailable")
+
 // TraceConfig holds extra parameters to trace functions.
 type TraceConfig struct {
 	*vm.LogConfig
-	Tracer            *string
-	Timeout           *string
-	Reexec            *uint64
-	NestedTraceOutput bool // Returns the trace output JSON nested under the trace name key. This allows full Parity compatibility to be achieved.
+	Tracer                   *string
+	Timeout                  *string
+	Reexec                   *uint64
+	NestedTraceOutput        bool                  // Returns the trace output JSON nested under the trace name key. This allows full Parity compatibility to be achieved (trace_call and trace_callMany only).
+	IncludeForkName          bool                  // Annotates the traces with the name of the hardfork rules in effect for the traced block.
+	IncludeChainID           bool                  // Annotates the traces with the chain id of the node's chain, for indexers ingesting the traces of several chains.
+	ContinueOnFailure        bool                  // Keeps tracing a chain segment past blocks that fail to process, marking them with an error.
+	Fields                   []string              // Restricts the returned Parity traces to the given (dot separated) fields, e.g. "action.from".
+	IncludeInputHash         bool                  // Adds the keccak256 hash of each call's input data to the Parity traces as action.inputHash.
+	OmitInput                bool                  // Drops the input data of each call from the Parity traces, typically along with IncludeInputHash.
+	MaxDataBytes             uint64                // Truncates the input and output data of each Parity trace to this many bytes, marking the truncated traces (0 = unlimited).
+	Sender                   *common.Address       // Restricts block tracing to the transactions sent by the given address, leaving the others untraced (null).
+	Format                   string                // Output format of the Parity traces, either nested JSON (default) or "rows" for one flat row per trace.
+	IncludeUncleDetails      bool                  // Adds the uncle block number and depth used in the reward calculation to uncle reward traces.
+	IncludeStatus            bool                  // Adds the transaction's overall success status (0x1 or 0x0) to the root trace of each transaction.
+	DecimalValues            bool                  // Encodes the value, balance and gas fields of the Parity traces as decimal instead of hex strings.
+	Transactions             []uint64              // Restricts block tracing to the transactions at the given indices, leaving the others untraced (null).
+	IncludeBlockSummary      bool                  // Appends a summary of the block (number, hash, gas used and transaction count) to the trace_block result.
+	IncludeIntrinsicGas      bool                  // Adds the intrinsic gas to the gas and gasUsed of the root Parity traces, which by default only cover the EVM execution like OpenEthereum's.
+	IncludePrecompiles       bool                  // Reports the calls to precompiled contracts, with the gas they charged, in the Parity traces (skipped by default like OpenEthereum).
+	IncludeEffectiveGasPrice bool                  // Adds the gas price paid per unit of gas, as in the receipt, to the root trace of each transaction of the block tracing methods.
+	IncludeGasRemaining      bool                  // Adds the gas each call had left when returning, and the gas its caller retained meanwhile (EIP-150), to the Parity traces.
+	IncludeSelector          bool                  // Adds the function selector (first 4 bytes of the input data) of each call to the Parity traces as action.selector.
+	FailedTransactionsOnly   bool                  // Restricts the Parity traces to the ones of the transactions which reverted or errored, dropping successful transactions and rewards.
+	IncludeInternalFailures  bool                  // Also retains the successful transactions with reverted or errored internal calls, along with FailedTransactionsOnly.
+	ValueTransfersOnly       bool                  // Restricts the Parity traces to the ones moving ether (value bearing calls and creations, suicides and rewards), flagging pruned transactions.
+	IncludeAccessList        bool                  // Returns the access list (accounts and storage slots accessed) generated by the call along with its trace (trace_call only).
+	IncludeLogs              bool                  // Adds the logs (address, topics and data) emitted directly by each call frame, unless reverted, to the Parity traces.
+	IncludeFailedCreateGas   bool                  // Keeps the gasUsed of failed contract creations in the Parity traces, whose result OpenEthereum drops.
+	CancelToken              string                // Registers the trace under the given client chosen token, so that trace_cancel can abort it (trace_filter and trace_block only).
+	BlockOverrides           *TraceBlockOverrides  // Overrides the block context (e.g. the timestamp) the calls execute in, for deterministic replays (trace_call and trace_callMany only).
+	StateOverrides           *ethapi.StateOverride // Overrides the accounts (balance, nonce, code, storage) of the state the calls execute on, like eth_call (trace_call and trace_callMany only).
+	FreeGas                  bool                  // Executes the calls with a zero gas price, so senders without ether for the gas can be simulated (trace_call and trace_callMany only).
+	StrictCalls              bool                  // Fails the whole batch on its first call failing to execute or reverting, instead of reporting the failure in place (trace_callMany only).
+
+	addresses  *TraceFilterArgs  // Address and value filter of trace_filter, restricting the Parity traces to the matching ones
+	redaction  *TraceAPIConfig   // Node-wide trace settings carrying the redaction policy of the trace outputs
+	sizeLimit  int               // Maximum size in bytes of the block results streamed by a chain trace, 0 = unlimited
+	bufferLen  int               // Number of blocks a chain trace queues up ahead of its client, 0 = number of tracing threads
+	internal   bool              // Trace requested by the node itself (e.g. trace indexing), exempt from the redaction policy
+	accessList *accessListTracer // Collector of the access list of a traced call, nil if not requested
+	excluded   *uint64           // Index of the block transaction left out of the block's execution, nil if none
+}
+
+// TraceBlockOverrides holds the block context fields pinned for traced calls,
+// which otherwise execute in the context of the block they're traced on. The
+// block number also selects the fork rules in effect.
+type TraceBlockOverrides struct {
+	Number     *hexutil.Big    `json:"number"`
+	Time       *hexutil.Uint64 `json:"time"`
+	GasLimit   *hexutil.Uint64 `json:"gasLimit"`
+	Coinbase   *common.Address `json:"coinbase"`
+	Difficulty *hexutil.Big    `json:"difficulty"`
+}
+
+// apply overrides the block context of the EVM with the pinned fields.
+func (o *TraceBlockOverrides) apply(vmctx *vm.Context) {
+	if o == nil {
+		return
+	}
+	if o.Number != nil {
+		vmctx.BlockNumber = o.Number.ToInt()
+	}
+	if o.Time != nil {
+		vmctx.Time = new(big.Int).SetUint64(uint64(*o.Time))
+	}
+	if o.GasLimit != nil {
+		vmctx.GasLimit = uint64(*o.GasLimit)
+	}
+	if o.Coinbase != nil {
+		vmctx.Coinbase = *o.Coinbase
+	}
+	if o.Difficulty != nil {
+		vmctx.Difficulty = o.Difficulty.ToInt()
+	}
 }
 
 // StdTraceConfig holds extra parameters to standard-json trace functions.
@@ -86,14 +164,19 @@ type blockTraceTask struct {
 	block   *types.Block     // Block to trace the transactions from
 	rootref common.Hash      // Trie root reference held for this task
 	results []*txTraceResult // Trace results procudes by the task
+	failure error            // Block processing failure encountered after tracing
 }
 
 // blockTraceResult represets the results of tracing a single block when an entire
 // chain is being traced.
 type blockTraceResult struct {
-	Block  hexutil.Uint64   `json:"block"`  // Block number corresponding to this trace
-	Hash   common.Hash      `json:"hash"`   // Block hash corresponding to this trace
-	Traces []*txTraceResult `json:"traces"` // Trace results produced by the task
+	Block     hexutil.Uint64   `json:"block"`               // Block number corresponding to this trace
+	Hash      common.Hash      `json:"hash"`                // Block hash corresponding to this trace
+	Fork      string           `json:"fork,omitempty"`      // Hardfork rules in effect for this block, if requested
+	ChainID   *hexutil.Big     `json:"chainId,omitempty"`   // Chain id of the node's chain, if requested
+	Traces    []*txTraceResult `json:"traces"`              // Trace results produced by the task
+	Error     string           `json:"error,omitempty"`     // Block processing failure, if any
+	Truncated bool             `json:"truncated,omitempty"` // Streaming stopped at the size limit, resume from this block
 }
 
 // txTraceTask represents a single transaction trace task when an entire block
@@ -163,15 +246,17 @@ func traceChain(ctx context.Context, eth *Ethereum, start, end *types.Block, con
 	statedb, err := state.New(start.Root(), database, nil)
 	if err != nil {
 		// If the starting state is missing, allow some number of blocks to be reexecuted
+		// to regenerate it, fast-forwarding through them before tracing
 		reexec := defaultTraceReexec
 		if config != nil && config.Reexec != nil {
 			reexec = *config.Reexec
 		}
 		// Find the most recent block that has the state available
-		for i := uint64(0); i < reexec; i++ {
+		parent := start.NumberU64()
+		for i := uint64(0); i < reexec && start.NumberU64() > 0; i++ {
 			start = eth.blockchain.GetBlock(start.ParentHash(), start.NumberU64()-1)
 			if start == nil {
-				break
+				return nil, fmt.Errorf("block #%d not found regenerating the state of block #%d", parent-i-1, parent)
 			}
 			if statedb, err = state.New(start.Root(), database, nil); err == nil {
 				break
@@ -181,11 +266,12 @@ func traceChain(ctx context.Context, eth *Ethereum, start, end *types.Block, con
 		if err != nil {
 			switch err.(type) {
 			case *trie.MissingNodeError:
-				return nil, errors.New("required historical state unavailable")
+				return nil, fmt.Errorf("%w: state of block #%d not regenerable within reexec=%d blocks", errHistoricalStateUnavailable, parent, reexec)
 			default:
 				return nil, err
 			}
 		}
+		log.Info("Regenerating state for chain trace", "block", parent, "base", start.NumberU64())
 	}
 	// Execute all the transaction contained within the chain concurrently for each block
 	blocks := int(end.NumberU64() - origin)
@@ -194,10 +280,18 @@ func traceChain(ctx context.Context, eth *Ethereum, start, end *types.Block, con
 	if threads > blocks {
 		threads = blocks
 	}
+	// Blocks are traced at most buffer blocks ahead of the client: once both the
+	// task and result queues fill up, a slow client blocks the tracers instead
+	// of the traces piling up in memory
+	buffer := threads
+	if config != nil && config.bufferLen > 0 {
+		buffer = config.bufferLen
+	}
 	var (
 		pend    = new(sync.WaitGroup)
-		tasks   = make(chan *blockTraceTask, threads)
-		results = make(chan *blockTraceTask, threads)
+		tasks   = make(chan *blockTraceTask, buffer)
+		results = make(chan *blockTraceTask, buffer)
+		stop    = make(chan struct{}) // Closed if streaming stops at the size limit
 	)
 	for th := 0; th < threads; th++ {
 		pend.Add(1)
@@ -213,10 +307,23 @@ func traceChain(ctx context.Context, eth *Ethereum, start, end *types.Block, con
 					msg, _ := tx.AsMessage(signer)
 					vmctx := core.NewEVMContext(msg, task.block.Header(), eth.blockchain, nil)
 
+					task.statedb.Prepare(tx.Hash(), task.block.Hash(), i)
 					res, err := traceTx(ctx, eth, msg, vmctx, task.statedb, nil, config)
+					if err == nil {
+						res, err = formatParityTraceResult(res, config)
+					}
 					if err != nil {
 						task.results[i] = &txTraceResult{Error: err.Error()}
 						log.Warn("Tracing failed", "hash", tx.Hash(), "block", task.block.NumberU64(), "err", err)
+
+						// The rest of the block would run on a state lacking the failed
+						// transaction's effects, so flag it as untraced rather than
+						// tracing it wrong
+						if config != nil && config.ContinueOnFailure {
+							for j := i + 1; j < len(task.results); j++ {
+								task.results[j] = &txTraceResult{Error: fmt.Sprintf("not traced: transaction %#x failed", tx.Hash())}
+							}
+						}
 						break
 					}
 					// Only delete empty objects if EIP158/161 (a.k.a Spurious Dragon) is in effect
@@ -247,6 +354,10 @@ func traceChain(ctx context.Context, eth *Ethereum, start, end *types.Block, con
 		defer func() {
 			close(tasks)
 			pend.Wait()
+			traceChainTimer.UpdateSince(begin)
+			if failed != nil {
+				traceChainFailMeter.Mark(1)
+			}
 
 			switch {
 			case failed != nil:
@@ -264,6 +375,8 @@ func traceChain(ctx context.Context, eth *Ethereum, start, end *types.Block, con
 			select {
 			case <-notifier.Closed():
 				return
+			case <-stop:
+				return
 			default:
 			}
 			// Print progress logs if long enough time elapsed
@@ -279,34 +392,45 @@ func traceChain(ctx context.Context, eth *Ethereum, start, end *types.Block, con
 			// Retrieve the next block to trace
 			block := eth.blockchain.GetBlockByNumber(number)
 			if block == nil {
-				failed = fmt.Errorf("block #%d not found", number)
+				failed = missingBlockError(eth, rpc.BlockNumber(number))
 				break
 			}
-			// Send the block over to the concurrent tracers (if not in the fast-forward phase)
+			// Prepare the block for the concurrent tracers (if not in the fast-forward phase)
+			var task *blockTraceTask
 			if number > origin {
-				txs := block.Transactions()
-
-				select {
-				case tasks <- &blockTraceTask{statedb: statedb.Copy(), block: block, rootref: proot, results: make([]*txTraceResult, len(txs))}:
-				case <-notifier.Closed():
-					return
-				}
-				traced += uint64(len(txs))
+				task = &blockTraceTask{statedb: statedb.Copy(), block: block, rootref: proot, results: make([]*txTraceResult, len(block.Transactions()))}
+				applyBlockPreamble(eth.blockchain.Config(), block, task.statedb)
 			}
 			// Generate the next state snapshot fast without tracing
-			_, _, _, err := eth.blockchain.Processor().Process(block, statedb, vm.Config{})
-			if err != nil {
+			root, err := processChainBlock(eth, block, statedb)
+			if err != nil && config != nil && config.ContinueOnFailure && number > origin {
+				// Resume from the block's own state if it's available, flagging the failure.
+				// Pruned nodes usually don't have it, ending the stream at the failed block.
+				log.Warn("Chain tracing block failed, continuing", "block", number, "err", err)
+				var serr error
+				if statedb, serr = state.New(block.Root(), database, nil); serr == nil {
+					root = block.Root()
+				} else {
+					err = fmt.Errorf("%v, state of block #%d to continue from unavailable: %v", err, number, serr)
+					failed = err
+				}
+			} else {
 				failed = err
-				break
 			}
-			// Finalize the state so any modifications are written to the trie
-			root, err := statedb.Commit(eth.blockchain.Config().IsEnabled(eth.blockchain.Config().GetEIP161dTransition, block.Number()))
-			if err != nil {
-				failed = err
-				break
+			// Send the block over to the concurrent tracers
+			if task != nil {
+				task.failure = err
+				select {
+				case tasks <- task:
+				case <-notifier.Closed():
+					return
+				case <-stop:
+					return
+				}
+				traced += uint64(len(task.results))
+				traceBlocksMeter.Mark(1)
 			}
-			if err := statedb.Reset(root); err != nil {
-				failed = err
+			if failed != nil {
 				break
 			}
 			// Reference the trie twice, once for us, once for the tracer
@@ -327,16 +451,32 @@ func traceChain(ctx context.Context, eth *Ethereum, start, end *types.Block, con
 	// Keep reading the trace results and stream them to the user
 	go func() {
 		var (
-			done = make(map[uint64]*blockTraceResult)
-			next = origin + 1
+			done      = make(map[uint64]*blockTraceResult)
+			next      = origin + 1
+			streamed  int
+			truncated bool
 		)
 		for res := range results {
+			// Once truncated, only drain the results of the blocks in flight
+			if truncated {
+				database.TrieDB().Dereference(res.rootref)
+				continue
+			}
 			// Queue up next received result
 			result := &blockTraceResult{
 				Block:  hexutil.Uint64(res.block.NumberU64()),
 				Hash:   res.block.Hash(),
 				Traces: res.results,
 			}
+			if res.failure != nil {
+				result.Error = res.failure.Error()
+			}
+			if config != nil && config.IncludeForkName {
+				result.Fork = forkNameAt(eth.blockchain.Config(), res.block.Number())
+			}
+			if config != nil && config.IncludeChainID {
+				result.ChainID = (*hexutil.Big)(eth.blockchain.Config().GetChainID())
+			}
 			done[uint64(result.Block)] = result
 
 			// Dereference any paret tries held in memory by this task
@@ -344,7 +484,20 @@ func traceChain(ctx context.Context, eth *Ethereum, start, end *types.Block, con
 
 			// Stream completed traces to the user, aborting on the first error
 			for result, ok := done[next]; ok; result, ok = done[next] {
-				if len(result.Traces) > 0 || next == end.NumberU64() {
+				if len(result.Traces) > 0 || result.Error != "" || next == end.NumberU64() {
+					// Stop streaming if the size limit would be exceeded, telling
+					// the user where to resume from. The first block is always sent
+					// to guarantee progress.
+					if config != nil && config.sizeLimit > 0 {
+						blob, _ := json.Marshal(result)
+						if streamed > 0 && streamed+len(blob) > config.sizeLimit {
+							notifier.Notify(sub.ID, &blockTraceResult{Block: result.Block, Hash: result.Hash, Truncated: true})
+							truncated = true
+							close(stop)
+							break
+						}
+						streamed += len(blob)
+					}
 					notifier.Notify(sub.ID, result)
 				}
 				delete(done, next)
@@ -355,6 +508,36 @@ func traceChain(ctx context.Context, eth *Ethereum, start, end *types.Block, con
 	return sub, nil
 }
 
+// processChainBlock applies the given block on top of statedb without tracing,
+// commits the resulting state and resets statedb onto the new root.
+func processChainBlock(eth *Ethereum, block *types.Block, statedb *state.StateDB) (common.Hash, error) {
+	if _, _, _, err := eth.blockchain.Processor().Process(block, statedb, vm.Config{}); err != nil {
+		return common.Hash{}, err
+	}
+	// Finalize the state so any modifications are written to the trie
+	root, err := statedb.Commit(eth.blockchain.Config().IsEnabled(eth.blockchain.Config().GetEIP161dTransition, block.Number()))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := statedb.Reset(root); err != nil {
+		return common.Hash{}, err
+	}
+	return root, nil
+}
+
+// applyBlockPreamble mutates the state according to the system changes taking
+// place at the start of the block, before its first transaction, like block
+// processing does, so that the transactions are traced on the state they ran
+// on. The only such change of this client is the DAO hard fork's refund, the
+// system calls of later forks (e.g. the EIP-4788 beacon root) aren't supported.
+func applyBlockPreamble(config ctypes.ChainConfigurator, block *types.Block, statedb *state.StateDB) {
+	if config.IsEnabled(config.GetEthashEIP779Transition, block.Number()) {
+		if dao := config.GetEthashEIP779Transition(); dao != nil && *dao == block.NumberU64() {
+			misc.ApplyDAOHardFork(statedb)
+		}
+	}
+}
+
 // traceChain configures a new tracer according to the provided configuration, and
 // executes all the transactions contained within. The return value will be one item
 // per transaction, dependent on the requested tracer.
@@ -378,7 +561,7 @@ func traceBlockByNumber(ctx context.Context, eth *Ethereum, number rpc.BlockNumb
 	}
 	// Trace the block if it was found
 	if block == nil {
-		return nil, fmt.Errorf("block #%d not found", number)
+		return nil, missingBlockError(eth, number)
 	}
 	return traceBlock(ctx, eth, block, config)
 }
@@ -482,6 +665,8 @@ func traceBlock(ctx context.Context, eth *Ethereum, block *types.Block, config *
 	if err != nil {
 		return nil, err
 	}
+	applyBlockPreamble(eth.blockchain.Config(), block, statedb)
+
 	// Execute all the transaction contained within the block concurrently
 	var (
 		signer = types.MakeSigner(eth.blockchain.Config(), block.Number())
@@ -492,6 +677,34 @@ func traceBlock(ctx context.Context, eth *Ethereum, block *types.Block, config *
 		pend = new(sync.WaitGroup)
 		jobs = make(chan *txTraceTask, len(txs))
 	)
+	// If only some transactions were requested, there's no need to execute past
+	// the last one of them
+	var (
+		selected map[int]bool
+		last     = len(txs) - 1
+		excluded = -1
+	)
+	if config != nil && config.excluded != nil {
+		if *config.excluded >= uint64(len(txs)) {
+			return nil, fmt.Errorf("transaction index %d out of range, block #%d has %d transactions", *config.excluded, block.NumberU64(), len(txs))
+		}
+		excluded = int(*config.excluded)
+	}
+	if config != nil && config.Transactions != nil {
+		selected, last = make(map[int]bool), -1
+		for _, index := range config.Transactions {
+			if index >= uint64(len(txs)) {
+				return nil, fmt.Errorf("transaction index %d out of range, block #%d has %d transactions", index, block.NumberU64(), len(txs))
+			}
+			if int(index) == excluded {
+				return nil, fmt.Errorf("transaction index %d excluded from the execution", index)
+			}
+			selected[int(index)] = true
+			if int(index) > last {
+				last = int(index)
+			}
+		}
+	}
 	threads := runtime.NumCPU()
 	if threads > len(txs) {
 		threads = len(txs)
@@ -518,6 +731,12 @@ func traceBlock(ctx context.Context, eth *Ethereum, block *types.Block, config *
 	// Feed the transactions into the tracers and return
 	var failed error
 	for i, tx := range txs {
+		if i > last {
+			break
+		}
+		if i == excluded {
+			continue
+		}
 		taskExtraContext := map[string]interface{}{
 			"blockNumber":         block.NumberU64(),
 			"blockHash":           block.Hash().Hex(),
@@ -525,17 +744,37 @@ func traceBlock(ctx context.Context, eth *Ethereum, block *types.Block, config *
 			"transactionPosition": uint64(i),
 		}
 
-		// Send the trace task over for execution
-		jobs <- &txTraceTask{statedb: statedb.Copy(), index: i, taskExtraContext: taskExtraContext}
-
-		// Generate the next state snapshot fast without tracing
-		msg, _ := tx.AsMessage(signer)
+		// Generate the next state snapshot fast without tracing. Transactions whose
+		// sender can't be recovered (e.g. of bad blocks) never execute, so their
+		// trace reports the failure without aborting the rest of the block.
+		msg, err := tx.AsMessage(signer)
+		if err != nil {
+			if (selected == nil || selected[i]) && (config == nil || config.Sender == nil) {
+				results[i] = &txTraceResult{Error: fmt.Sprintf("invalid transaction sender: %v", err)}
+			}
+			log.Debug("Traced transaction has an invalid sender", "block", block.NumberU64(), "hash", tx.Hash(), "err", err)
+			continue
+		}
+		// Send the trace task over for execution, unless filtered out by index or sender.
+		// Like during block processing, each transaction starts with a fresh access list
+		statedb.Prepare(tx.Hash(), block.Hash(), i)
+		if (selected == nil || selected[i]) && (config == nil || config.Sender == nil || *config.Sender == msg.From()) {
+			jobs <- &txTraceTask{statedb: statedb.Copy(), index: i, taskExtraContext: taskExtraContext}
+		}
 		vmctx := core.NewEVMContext(msg, block.Header(), eth.blockchain, nil)
 
 		vmenv := vm.NewEVM(vmctx, statedb, eth.blockchain.Config(), vm.Config{})
+		core.PrepareAccessList(eth.blockchain.Config(), statedb, block.Number(), msg)
 		if _, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.Gas())); err != nil {
-			failed = err
-			break
+			// Transactions not covering their intrinsic gas never execute, so their
+			// trace reports the failure without aborting the rest of the block. The
+			// same goes for any transaction invalidated by leaving another one out
+			// (e.g. a later nonce of the same sender).
+			if err != core.ErrIntrinsicGas && excluded < 0 {
+				failed = err
+				break
+			}
+			log.Debug("Traced transaction not executable", "block", block.NumberU64(), "hash", tx.Hash(), "err", err)
 		}
 		// Finalize the state so any modifications are written to the trie
 		// Only delete empty objects if EIP158/161 (a.k.a Spurious Dragon) is in effect
@@ -548,6 +787,7 @@ func traceBlock(ctx context.Context, eth *Ethereum, block *types.Block, config *
 	if failed != nil {
 		return nil, failed
 	}
+	traceBlocksMeter.Mark(1)
 	return results, nil
 }
 
@@ -581,6 +821,8 @@ func (api *PrivateDebugAPI) standardTraceBlockToFile(ctx context.Context, block
 	if err != nil {
 		return nil, err
 	}
+	applyBlockPreamble(api.eth.blockchain.Config(), block, statedb)
+
 	// Retrieve the tracing configurations, or use default values
 	var (
 		logConfig vm.LogConfig
@@ -665,6 +907,8 @@ func (api *PrivateDebugAPI) standardTraceBlockToFile(ctx context.Context, block
 		}
 		// Execute the transaction and flush any traces to disk
 		vmenv := vm.NewEVM(vmctx, statedb, chainConfig, vmConf)
+		statedb.Prepare(tx.Hash(), block.Hash(), i)
+		core.PrepareAccessList(chainConfig, statedb, block.Number(), msg)
 		_, err = core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.Gas()))
 		if writer != nil {
 			writer.Flush()
@@ -703,20 +947,39 @@ func containsTx(block *types.Block, hash common.Hash) bool {
 // If no state is locally available for the given block, a number of blocks are
 // attempted to be reexecuted to generate the desired state.
 func computeStateDB(eth *Ethereum, block *types.Block, reexec uint64) (*state.StateDB, error) {
+	return computeStateDBAt(eth, block, reexec, 0)
+}
+
+// computeStateDBAt retrieves the state of the given block like computeStateDB,
+// but ignores the states of the closest skip blocks (including the block's own),
+// regenerating from an older one. This allows recovering from partially pruned
+// states, which open fine but miss trie nodes.
+func computeStateDBAt(eth *Ethereum, block *types.Block, reexec uint64, skip uint64) (*state.StateDB, error) {
 	// If we have the state fully available, use that
-	statedb, err := eth.blockchain.StateAt(block.Root())
-	if err == nil {
-		return statedb, nil
+	var (
+		statedb *state.StateDB
+		err     = fmt.Errorf("%w (reexec=%d)", errHistoricalStateUnavailable, reexec)
+	)
+	if skip == 0 {
+		if statedb, err = eth.blockchain.StateAt(block.Root()); err == nil {
+			return statedb, nil
+		}
 	}
-	// Otherwise try to reexec blocks until we find a state or reach our limit
+	// Otherwise try to reexec blocks until we find a state or reach our limit,
+	// tracking the walked ancestry as the block might not be canonical
 	origin := block.NumberU64()
 	database := state.NewDatabaseWithCache(eth.ChainDb(), 16, "")
 
+	var ancestors []*types.Block
 	for i := uint64(0); i < reexec; i++ {
+		ancestors = append(ancestors, block)
 		block = eth.blockchain.GetBlock(block.ParentHash(), block.NumberU64()-1)
 		if block == nil {
 			break
 		}
+		if i+1 < skip {
+			continue
+		}
 		if statedb, err = state.New(block.Root(), database, nil); err == nil {
 			break
 		}
@@ -724,7 +987,7 @@ func computeStateDB(eth *Ethereum, block *types.Block, reexec uint64) (*state.St
 	if err != nil {
 		switch err.(type) {
 		case *trie.MissingNodeError:
-			return nil, fmt.Errorf("required historical state unavailable (reexec=%d)", reexec)
+			return nil, fmt.Errorf("%w (reexec=%d)", errHistoricalStateUnavailable, reexec)
 		default:
 			return nil, err
 		}
@@ -732,6 +995,7 @@ func computeStateDB(eth *Ethereum, block *types.Block, reexec uint64) (*state.St
 	// State was available at historical point, regenerate
 	var (
 		start  = time.Now()
+		base   = block.NumberU64()
 		logged time.Time
 		proot  common.Hash
 	)
@@ -742,12 +1006,15 @@ func computeStateDB(eth *Ethereum, block *types.Block, reexec uint64) (*state.St
 			logged = time.Now()
 		}
 		// Retrieve the next block to regenerate and process it
-		if block = eth.blockchain.GetBlockByNumber(block.NumberU64() + 1); block == nil {
-			return nil, fmt.Errorf("block #%d not found", block.NumberU64()+1)
-		}
+		block, ancestors = ancestors[len(ancestors)-1], ancestors[:len(ancestors)-1]
 		_, _, _, err := eth.blockchain.Processor().Process(block, statedb, vm.Config{})
 		if err != nil {
-			return nil, fmt.Errorf("processing block %d failed: %v", block.NumberU64(), err)
+			return nil, fmt.Errorf("processing block %d failed: %w", block.NumberU64(), err)
+		}
+		// Missing code or trie nodes only get recorded, executing on an empty
+		// account instead, which would silently corrupt the regenerated state
+		if err := statedb.Error(); err != nil {
+			return nil, fmt.Errorf("processing block %d failed: %w", block.NumberU64(), err)
 		}
 		// Finalize the state so any modifications are written to the trie
 		root, err := statedb.Commit(eth.blockchain.Config().IsEnabled(eth.blockchain.Config().GetEIP161dTransition, block.Number()))
@@ -764,7 +1031,7 @@ func computeStateDB(eth *Ethereum, block *types.Block, reexec uint64) (*state.St
 		proot = root
 	}
 	nodes, imgs := database.TrieDB().Size()
-	log.Info("Historical state regenerated", "block", block.NumberU64(), "elapsed", time.Since(start), "nodes", nodes, "preimages", imgs)
+	log.Info("Historical state regenerated", "block", block.NumberU64(), "reexecuted", origin-base, "elapsed", time.Since(start), "nodes", nodes, "preimages", imgs)
 	return statedb, nil
 }
 
@@ -789,19 +1056,102 @@ func traceTransaction(ctx context.Context, eth *Ethereum, hash common.Hash, conf
 	if block == nil {
 		return nil, fmt.Errorf("block %#x not found", blockHash)
 	}
-	msg, vmctx, statedb, err := computeTxEnv(eth, block, int(index), reexec)
-	if err != nil {
-		return nil, err
-	}
-
 	taskExtraContext := map[string]interface{}{
 		"blockNumber":         block.NumberU64(),
 		"blockHash":           blockHash.Hex(),
 		"transactionHash":     tx.Hash().Hex(),
 		"transactionPosition": index,
 	}
+	// Trace the transaction, regenerating its state from progressively older
+	// blocks (within the reexec limit) if it turns out to be partially pruned.
+	// The code of contracts destroyed since isn't part of the current state, so
+	// if it was pruned too, it's recovered by reexecuting their creation.
+	for skip := uint64(0); ; {
+		res, err := traceTransactionAt(ctx, eth, block, int(index), reexec, skip, taskExtraContext, config)
+		if err == nil || !(isMissingTrieNode(err) || isMissingCode(err)) || skip >= reexec {
+			if err == nil && skip > 0 {
+				log.Info("Traced transaction on deeper regenerated state", "hash", hash, "skipped", skip)
+			}
+			return res, err
+		}
+		if skip *= 2; skip == 0 {
+			skip = 1
+		}
+		if skip > reexec {
+			skip = reexec
+		}
+		log.Warn("Missing historical state during trace, retrying with deeper reexec", "hash", hash, "skip", skip, "reexec", reexec, "err", err)
+	}
+}
 
-	// Trace the transaction and return
+// traceTransactionAt traces the transaction at the given index of a block on
+// top of its parent state, regenerated while ignoring the states of the closest
+// skip blocks. Missing trie nodes hit during execution are reported as errors.
+func traceTransactionAt(ctx context.Context, eth *Ethereum, block *types.Block, index int, reexec uint64, skip uint64, taskExtraContext map[string]interface{}, config *TraceConfig) (interface{}, error) {
+	msg, vmctx, statedb, err := computeTxEnvAt(eth, block, index, reexec, skip)
+	if err != nil {
+		return nil, err
+	}
+	res, err := traceTx(ctx, eth, msg, vmctx, statedb, taskExtraContext, config)
+
+	// State read failures don't abort execution, they're only recorded
+	if serr := statedb.Error(); serr != nil {
+		return nil, serr
+	}
+	return res, err
+}
+
+// isMissingTrieNode reports whether err was caused by a missing trie node. The
+// state database wraps some of its errors as text, so the message is checked
+// as well.
+func isMissingTrieNode(err error) bool {
+	var missing *trie.MissingNodeError
+	if errors.As(err, &missing) {
+		return true
+	}
+	return err != nil && strings.Contains(err.Error(), "missing trie node")
+}
+
+// isMissingCode reports whether err was caused by contract code missing from
+// the database, which the state database only reports as text.
+func isMissingCode(err error) bool {
+	return err != nil && (strings.Contains(err.Error(), "can't load code hash") || strings.Contains(err.Error(), "can't load code size"))
+}
+
+// tracePendingTransaction traces a transaction from the transaction pool which
+// is not yet mined, speculatively executing it on top of the pending state. If
+// the transaction is part of the pending block, it's traced after the pending
+// transactions preceding it.
+func tracePendingTransaction(ctx context.Context, eth *Ethereum, tx *types.Transaction, config *TraceConfig) (interface{}, error) {
+	block, statedb := eth.miner.Pending()
+	if block == nil || statedb == nil {
+		return nil, errors.New("pending state unavailable")
+	}
+	taskExtraContext := map[string]interface{}{
+		"blockNumber":     block.NumberU64(),
+		"transactionHash": tx.Hash().Hex(),
+	}
+	for index, pending := range block.Transactions() {
+		if pending.Hash() != tx.Hash() {
+			continue
+		}
+		reexec := defaultTraceReexec
+		if config != nil && config.Reexec != nil {
+			reexec = *config.Reexec
+		}
+		msg, vmctx, statedb, err := computeTxEnv(eth, block, index, reexec)
+		if err != nil {
+			return nil, err
+		}
+		taskExtraContext["transactionPosition"] = uint64(index)
+		return traceTx(ctx, eth, msg, vmctx, statedb, taskExtraContext, config)
+	}
+	// Not included in the pending block (e.g. nonce gap), trace it on top
+	msg, err := tx.AsMessage(types.MakeSigner(eth.blockchain.Config(), block.Number()))
+	if err != nil {
+		return nil, err
+	}
+	vmctx := core.NewEVMContext(msg, block.Header(), eth.blockchain, nil)
 	return traceTx(ctx, eth, msg, vmctx, statedb, taskExtraContext, config)
 }
 
@@ -811,38 +1161,67 @@ func (api *PrivateDebugAPI) TraceTransaction(ctx context.Context, hash common.Ha
 	return traceTransaction(ctx, api.eth, hash, config)
 }
 
+// traceCallStateAt retrieves the state after the block selected by number or by
+// hash, along with the block's header, for executing calls on top of it. Blocks
+// selected by hash may be non-canonical, unless canonicity is required. If the
+// state isn't available locally, it's regenerated by reexecuting up to reexec
+// blocks.
+func traceCallStateAt(ctx context.Context, eth *Ethereum, blockNrOrHash rpc.BlockNumberOrHash, config *TraceConfig) (*state.StateDB, *types.Header, error) {
+	statedb, header, err := eth.APIBackend.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err == nil {
+		return statedb, header, nil
+	}
+	// The state is not readily available, resolve the block to regenerate it
+	var block *types.Block
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		if header == nil {
+			header = eth.blockchain.GetHeaderByHash(hash)
+		}
+		if header == nil {
+			return nil, nil, fmt.Errorf("block %#x not found", hash)
+		}
+		if blockNrOrHash.RequireCanonical && eth.blockchain.GetCanonicalHash(header.Number.Uint64()) != hash {
+			return nil, nil, fmt.Errorf("block %#x is not canonical", hash)
+		}
+		block = eth.blockchain.GetBlock(hash, header.Number.Uint64())
+	} else if number, ok := blockNrOrHash.Number(); ok && number >= 0 {
+		block = eth.blockchain.GetBlockByNumber(uint64(number))
+	}
+	if block == nil {
+		return nil, nil, fmt.Errorf("block %v not found: %v", blockNrOrHash, err)
+	}
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	if statedb, err = computeStateDB(eth, block, reexec); err != nil {
+		return nil, nil, err
+	}
+	return statedb, block.Header(), nil
+}
+
 // traceCall lets you trace a given eth_call. It collects the structured logs created during the execution of EVM
 // if the given transaction was added on top of the provided block and returns them as a JSON object.
 // You can provide -2 as a block number to trace on top of the pending block.
 func traceCall(ctx context.Context, eth *Ethereum, args ethapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceConfig) (interface{}, error) {
-	// First try to retrieve the state
-	blockNrOrHash.RequireCanonical = true
-	statedb, header, err := eth.APIBackend.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	statedb, header, err := traceCallStateAt(ctx, eth, blockNrOrHash, config)
 	if err != nil {
-		// Try to retrieve the specified block
-		var block *types.Block
-		if hash, ok := blockNrOrHash.Hash(); ok {
-			block = eth.blockchain.GetBlockByHash(hash)
-		} else if number, ok := blockNrOrHash.Number(); ok {
-			block = eth.blockchain.GetBlockByNumber(uint64(number))
-		}
-		if block == nil {
-			return nil, fmt.Errorf("block %v not found: %v", blockNrOrHash, err)
-		}
-		// try to recompute the state
-		reexec := defaultTraceReexec
-		if config != nil && config.Reexec != nil {
-			reexec = *config.Reexec
-		}
-		_, _, statedb, err = computeTxEnv(eth, block, len(block.Transactions())-1, reexec)
-		if err != nil {
+		return nil, err
+	}
+	if config != nil {
+		if err := config.StateOverrides.Apply(statedb); err != nil {
 			return nil, err
 		}
 	}
 
-	// Execute the trace
-	msg := args.ToMessage(eth.APIBackend.RPCGasCap())
+	// Execute the trace. The message is sent from args.From (or the zero address)
+	// without any signature or nonce checks, so arbitrary senders can be simulated
+	// with the balance they hold in the selected state.
+	msg := traceCallMessage(eth, args, config)
 	vmctx := core.NewEVMContext(msg, header, eth.blockchain, nil)
+	if config != nil {
+		config.BlockOverrides.apply(&vmctx)
+	}
 
 	originalCanTransfer := vmctx.CanTransfer
 	originalTransfer := vmctx.Transfer
@@ -885,6 +1264,16 @@ func traceCall(ctx context.Context, eth *Ethereum, args ethapi.CallArgs, blockNr
 	return traceTx(ctx, eth, msg, vmctx, statedb, taskExtraContext, config)
 }
 
+// traceCallMessage converts the arguments of a traced call to the message to
+// execute. Free gas calls ignore the requested gas price, buying their gas for
+// nothing like eth_call does by default, so the sender's balance isn't checked.
+func traceCallMessage(eth *Ethereum, args ethapi.CallArgs, config *TraceConfig) types.Message {
+	if config != nil && config.FreeGas {
+		args.GasPrice = nil
+	}
+	return args.ToMessage(eth.APIBackend.RPCGasCap())
+}
+
 // TraceCall lets you trace a given eth_call. It collects the structured logs created during the execution of EVM
 // if the given transaction was added on top of the provided block and returns them as a JSON object.
 // You can provide -2 as a block number to trace on top of the pending block.
@@ -895,36 +1284,29 @@ func (api *PrivateDebugAPI) TraceCall(ctx context.Context, args ethapi.CallArgs,
 // TraceCallMany lets you trace a given eth_call. It collects the structured logs created during the execution of EVM
 // if the given transaction was added on top of the provided block and returns them as a JSON object.
 // You can provide -2 as a block number to trace on top of the pending block.
-func traceCallMany(ctx context.Context, eth *Ethereum, txs []ethapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceConfig) (interface{}, error) {
-	// First try to retrieve the state
-	statedb, header, err := eth.APIBackend.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+func traceCallMany(ctx context.Context, eth *Ethereum, txs []ethapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceConfig) ([]interface{}, error) {
+	statedb, header, err := traceCallStateAt(ctx, eth, blockNrOrHash, config)
 	if err != nil {
-		// Try to retrieve the specified block
-		var block *types.Block
-		if hash, ok := blockNrOrHash.Hash(); ok {
-			block = eth.blockchain.GetBlockByHash(hash)
-		} else if number, ok := blockNrOrHash.Number(); ok {
-			block = eth.blockchain.GetBlockByNumber(uint64(number))
-		}
-		if block == nil {
-			return nil, fmt.Errorf("block %v not found: %v", blockNrOrHash, err)
-		}
-		// try to recompute the state
-		reexec := defaultTraceReexec
-		if config != nil && config.Reexec != nil {
-			reexec = *config.Reexec
-		}
-		_, _, statedb, err = computeTxEnv(eth, block, len(block.Transactions())-1, reexec)
-		if err != nil {
+		return nil, err
+	}
+	if config != nil {
+		if err := config.StateOverrides.Apply(statedb); err != nil {
 			return nil, err
 		}
 	}
 
-	// Execute the trace
+	// Execute the trace, enforcing any deadline across the whole batch
 	var results = make([]interface{}, len(txs))
 	for idx, args := range txs {
-		msg := args.ToMessage(eth.APIBackend.RPCGasCap())
+		if err := ctx.Err(); err != nil {
+			log.Debug("Aborting call batch tracing", "traced", idx, "total", len(txs), "err", err)
+			return results[:idx], err
+		}
+		msg := traceCallMessage(eth, args, config)
 		vmctx := core.NewEVMContext(msg, header, eth.blockchain, nil)
+		if config != nil {
+			config.BlockOverrides.apply(&vmctx)
+		}
 
 		originalCanTransfer := vmctx.CanTransfer
 		originalTransfer := vmctx.Transfer
@@ -964,12 +1346,26 @@ func traceCallMany(ctx context.Context, eth *Ethereum, txs []ethapi.CallArgs, bl
 			"hasFromSufficientBalanceForGasCost":         hasFromSufficientBalanceForGasCost,
 		}
 
+		statedb.Prepare(common.Hash{}, header.Hash(), idx)
 		res, err := traceTx(ctx, eth, msg, vmctx, statedb, taskExtraContext, config)
 		if err != nil {
+			if config != nil && config.StrictCalls {
+				return nil, fmt.Errorf("call %d failed: %v", idx, err)
+			}
 			results[idx] = &txTraceResult{Error: err.Error()}
 			continue
 		}
+		if config != nil && config.StrictCalls {
+			if failure := callTraceFailure(res); failure != "" {
+				return nil, fmt.Errorf("call %d failed: %s", idx, failure)
+			}
+		}
 
+		if config != nil && config.redaction != nil {
+			if res, err = redactTraceResult(res, config.redaction); err != nil {
+				return nil, err
+			}
+		}
 		res, err = decorateResponse(res, config)
 		if err != nil {
 			return nil, fmt.Errorf("failed to decorate response for transaction at index %d with error %v", idx, err)
@@ -984,6 +1380,16 @@ func traceCallMany(ctx context.Context, eth *Ethereum, txs []ethapi.CallArgs, bl
 // executes the given message in the provided environment. The return value will
 // be tracer dependent.
 func traceTx(ctx context.Context, eth *Ethereum, message core.Message, vmctx vm.Context, statedb *state.StateDB, extraContext map[string]interface{}, config *TraceConfig) (interface{}, error) {
+	// Wait for a free trace worker, shared by all trace requests
+	if err := eth.tracePool.acquire(ctx); err != nil {
+		traceTxFailMeter.Mark(1)
+		return nil, err
+	}
+	defer eth.tracePool.release()
+
+	traceRunningGauge.Inc(1)
+	defer traceRunningGauge.Dec(1)
+
 	// Assemble the structured logger or the JavaScript tracer
 	var (
 		tracer vm.Tracer
@@ -1016,8 +1422,14 @@ func traceTx(ctx context.Context, eth *Ethereum, message core.Message, vmctx vm.
 	default:
 		tracer = vm.NewStructLogger(config.LogConfig)
 	}
-	// Run the transaction with tracing enabled.
-	vmenv := vm.NewEVM(vmctx, statedb, eth.blockchain.Config(), vm.Config{Debug: true, Tracer: tracer})
+	// Run the transaction with tracing enabled, collecting its access list too if
+	// requested
+	evmTracer := tracer
+	if config != nil && config.accessList != nil {
+		evmTracer = config.accessList.wrap(tracer)
+	}
+	vmenv := vm.NewEVM(vmctx, statedb, eth.blockchain.Config(), vm.Config{Debug: true, Tracer: evmTracer})
+	core.PrepareAccessList(eth.blockchain.Config(), statedb, vmctx.BlockNumber, message)
 
 	switch tracer := tracer.(type) {
 	case *tracers.Tracer:
@@ -1035,11 +1447,19 @@ func traceTx(ctx context.Context, eth *Ethereum, message core.Message, vmctx vm.
 		extraContext["gasLimit"] = message.Gas()
 		extraContext["gasPrice"] = message.GasPrice()
 
+		if config != nil {
+			extraContext["includePrecompiles"] = config.IncludePrecompiles
+			extraContext["includeGasRemaining"] = config.IncludeGasRemaining
+			extraContext["includeLogs"] = config.IncludeLogs
+			extraContext["includeFailedCreateGas"] = config.IncludeFailedCreateGas
+		}
+
 		tracer.CapturePreEVM(vmenv, extraContext)
 	}
 
 	result, err := core.ApplyMessage(vmenv, message, new(core.GasPool).AddGas(message.Gas()))
 	if err != nil {
+		traceTxFailMeter.Mark(1)
 		return nil, fmt.Errorf("tracing failed: %v", err)
 	}
 	// Depending on the tracer type, format and return the output
@@ -1058,7 +1478,18 @@ func traceTx(ctx context.Context, eth *Ethereum, message core.Message, vmctx vm.
 		}, nil
 
 	case *tracers.Tracer:
-		return tracer.GetResult()
+		res, err := tracer.GetResult()
+		if err != nil || !config.IncludeIntrinsicGas || *config.Tracer != "callTracerParity" {
+			return res, err
+		}
+		chainConfig := eth.blockchain.Config()
+		intrinsic, err := core.IntrinsicGas(message.Data(), message.To() == nil,
+			chainConfig.IsEnabled(chainConfig.GetEIP2Transition, vmctx.BlockNumber),
+			chainConfig.IsEnabled(chainConfig.GetEIP2028Transition, vmctx.BlockNumber))
+		if err != nil {
+			return nil, err
+		}
+		return addIntrinsicGas(res, intrinsic)
 
 	default:
 		panic(fmt.Sprintf("bad tracer type %T", tracer))
@@ -1074,15 +1505,23 @@ func (api *PrivateDebugAPI) traceTx(ctx context.Context, message core.Message, v
 
 // computeTxEnv returns the execution environment of a certain transaction.
 func computeTxEnv(eth *Ethereum, block *types.Block, txIndex int, reexec uint64) (core.Message, vm.Context, *state.StateDB, error) {
+	return computeTxEnvAt(eth, block, txIndex, reexec, 0)
+}
+
+// computeTxEnvAt returns the execution environment of a certain transaction
+// like computeTxEnv, regenerating the parent state while ignoring the states of
+// its closest skip blocks.
+func computeTxEnvAt(eth *Ethereum, block *types.Block, txIndex int, reexec uint64, skip uint64) (core.Message, vm.Context, *state.StateDB, error) {
 	// Create the parent state database
 	parent := eth.blockchain.GetBlock(block.ParentHash(), block.NumberU64()-1)
 	if parent == nil {
 		return nil, vm.Context{}, nil, fmt.Errorf("parent %#x not found", block.ParentHash())
 	}
-	statedb, err := computeStateDB(eth, parent, reexec)
+	statedb, err := computeStateDBAt(eth, parent, reexec, skip)
 	if err != nil {
 		return nil, vm.Context{}, nil, err
 	}
+	applyBlockPreamble(eth.blockchain.Config(), block, statedb)
 
 	if txIndex == 0 && len(block.Transactions()) == 0 {
 		return nil, vm.Context{}, statedb, nil
@@ -1093,15 +1532,24 @@ func computeTxEnv(eth *Ethereum, block *types.Block, txIndex int, reexec uint64)
 
 	for idx, tx := range block.Transactions() {
 		// Assemble the transaction call message and return if the requested offset
-		msg, _ := tx.AsMessage(signer)
+		msg, err := tx.AsMessage(signer)
+		if err != nil {
+			return nil, vm.Context{}, nil, fmt.Errorf("transaction %#x has an invalid sender: %w", tx.Hash(), err)
+		}
 		context := core.NewEVMContext(msg, block.Header(), eth.blockchain, nil)
+		statedb.Prepare(tx.Hash(), block.Hash(), idx)
 		if idx == txIndex {
 			return msg, context, statedb, nil
 		}
 		// Not yet the searched for transaction, execute on top of the current state
 		vmenv := vm.NewEVM(context, statedb, eth.blockchain.Config(), vm.Config{})
+		core.PrepareAccessList(eth.blockchain.Config(), statedb, block.Number(), msg)
 		if _, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(tx.Gas())); err != nil {
-			return nil, vm.Context{}, nil, fmt.Errorf("transaction %#x failed: %v", tx.Hash(), err)
+			// Report state read failures rather than their consequences
+			if serr := statedb.Error(); serr != nil {
+				err = serr
+			}
+			return nil, vm.Context{}, nil, fmt.Errorf("transaction %#x failed: %w", tx.Hash(), err)
 		}
 		// Ensure any modifications are committed to the state
 		// Only delete empty objects if EIP158/161 (a.k.a Spurious Dragon) is in effect
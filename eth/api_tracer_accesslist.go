@@ -0,0 +1,130 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// TraceAccessTuple is an entry of the access list generated by trace_call: an
+// account accessed by the call, along with the storage slots of it accessed.
+type TraceAccessTuple struct {
+	Address     common.Address `json:"address"`
+	StorageKeys []common.Hash  `json:"storageKeys"`
+}
+
+// accessListTracer wraps the tracer of a call, collecting the accounts and
+// storage slots the call accesses into an EIP-2930 style access list. Like in
+// eth_createAccessList, the sender, the recipient and the precompiled contracts
+// are not listed unless their storage is accessed, being warm anyway.
+type accessListTracer struct {
+	vm.Tracer
+
+	excluded    map[common.Address]bool                   // Sender and recipient of the call
+	precompiles map[common.Address]vm.PrecompiledContract // Precompiled contracts of the call's chain rules
+	list        []*TraceAccessTuple                       // Accessed accounts in order of first access
+	index       map[common.Address]*TraceAccessTuple      // Accessed accounts by address
+	slots       map[common.Address]map[common.Hash]bool   // Accessed storage slots by account
+}
+
+// newAccessListTracer creates an access list collector, wrapped around the
+// call's tracer by wrap.
+func newAccessListTracer() *accessListTracer {
+	return &accessListTracer{
+		index: make(map[common.Address]*TraceAccessTuple),
+		slots: make(map[common.Address]map[common.Hash]bool),
+	}
+}
+
+// wrap sets the tracer the collected execution steps are forwarded to.
+func (t *accessListTracer) wrap(tracer vm.Tracer) vm.Tracer {
+	t.Tracer = tracer
+	return t
+}
+
+// accessList returns the accounts and storage slots accessed by the call, in
+// the order they were first accessed.
+func (t *accessListTracer) accessList() []*TraceAccessTuple {
+	if t.list == nil {
+		return []*TraceAccessTuple{}
+	}
+	return t.list
+}
+
+// addAddress lists the given account, unless it's warm anyway.
+func (t *accessListTracer) addAddress(address common.Address) {
+	if _, ok := t.precompiles[address]; ok || t.excluded[address] {
+		return
+	}
+	if _, ok := t.index[address]; !ok {
+		tuple := &TraceAccessTuple{Address: address, StorageKeys: []common.Hash{}}
+		t.index[address] = tuple
+		t.list = append(t.list, tuple)
+	}
+}
+
+// addSlot lists the given storage slot, along with its account.
+func (t *accessListTracer) addSlot(address common.Address, slot common.Hash) {
+	if _, ok := t.index[address]; !ok {
+		tuple := &TraceAccessTuple{Address: address, StorageKeys: []common.Hash{}}
+		t.index[address] = tuple
+		t.list = append(t.list, tuple)
+	}
+	if t.slots[address] == nil {
+		t.slots[address] = make(map[common.Hash]bool)
+	}
+	if !t.slots[address][slot] {
+		t.slots[address][slot] = true
+		t.index[address].StorageKeys = append(t.index[address].StorageKeys, slot)
+	}
+}
+
+// CaptureStart excludes the sender and the recipient of the call from the access
+// list, before forwarding the event.
+func (t *accessListTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	if t.excluded == nil {
+		t.excluded = map[common.Address]bool{from: true, to: true}
+	}
+	return t.Tracer.CaptureStart(from, to, create, input, gas, value)
+}
+
+// CaptureState records the accounts and storage slots accessed by the opcode,
+// before forwarding the event.
+func (t *accessListTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, rStack *vm.ReturnStack, rData []byte, contract *vm.Contract, depth int, err error) error {
+	if t.precompiles == nil {
+		t.precompiles = vm.PrecompiledContractsForConfig(env.ChainConfig(), env.BlockNumber)
+	}
+	size := len(stack.Data())
+	switch op {
+	case vm.SLOAD, vm.SSTORE:
+		if size >= 1 {
+			t.addSlot(contract.Address(), common.Hash(stack.Back(0).Bytes32()))
+		}
+	case vm.EXTCODECOPY, vm.EXTCODEHASH, vm.EXTCODESIZE, vm.BALANCE, vm.SELFDESTRUCT:
+		if size >= 1 {
+			t.addAddress(common.Address(stack.Back(0).Bytes20()))
+		}
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		if size >= 5 {
+			t.addAddress(common.Address(stack.Back(1).Bytes20()))
+		}
+	}
+	return t.Tracer.CaptureState(env, pc, op, gas, cost, memory, stack, rStack, rData, contract, depth, err)
+}
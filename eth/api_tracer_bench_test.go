@@ -0,0 +1,153 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// benchTokens is the number of token contracts each router call of the DeFi-like
+// benchmark block touches.
+const benchTokens = 8
+
+// benchDeployCode returns the init code deploying the given runtime code, of at
+// most 64KB.
+func benchDeployCode(runtime []byte) []byte {
+	size := []byte{byte(len(runtime) >> 8), byte(len(runtime))}
+	return append([]byte{0x61, size[0], size[1], 0x60, 0x0e, 0x60, 0x00, 0x39, 0x61, size[0], size[1], 0x60, 0x00, 0xf3}, runtime...)
+}
+
+// benchCallCode returns the code calling each of the given addresses in turn
+// with all the available gas, discarding the call results.
+func benchCallCode(addresses ...common.Address) []byte {
+	var code []byte
+	for _, address := range addresses {
+		code = append(code, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x73)
+		code = append(code, address.Bytes()...)
+		code = append(code, 0x5a, 0xf1, 0x50)
+	}
+	return append(code, 0x00)
+}
+
+// testDefiBlocks is a chain generator deploying token-like contracts, which bump
+// a storage slot of their caller and emit a log when called, along with a router
+// calling all of them, then filling the following blocks with n router calls.
+func testDefiBlocks(n int) func(int, *core.BlockGen) {
+	// Token: sstore(caller, sload(caller) + 1); log0(0, 0)
+	token := []byte{0x60, 0x01, 0x33, 0x54, 0x01, 0x33, 0x55, 0x60, 0x00, 0x60, 0x00, 0xa0, 0x00}
+
+	var tokens []common.Address
+	for i := 0; i < benchTokens; i++ {
+		tokens = append(tokens, crypto.CreateAddress(testBank, uint64(i)))
+	}
+	router := crypto.CreateAddress(testBank, benchTokens)
+
+	return func(i int, block *core.BlockGen) {
+		signer := types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		if i == 0 {
+			for j := 0; j <= benchTokens; j++ {
+				code := benchDeployCode(token)
+				if j == benchTokens {
+					code = benchDeployCode(benchCallCode(tokens...))
+				}
+				tx, _ := types.SignTx(types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 200000, big.NewInt(1), code), signer, testBankKey)
+				block.AddTx(tx)
+			}
+			return
+		}
+		for j := 0; j < n; j++ {
+			tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), router, new(big.Int), 500000, big.NewInt(1), nil), signer, testBankKey)
+			block.AddTx(tx)
+		}
+	}
+}
+
+// testDeepCallBlocks is a chain generator deploying a chain of the given depth of
+// contracts, each calling the next one, then filling the following blocks with
+// n calls to the outermost contract.
+func testDeepCallBlocks(depth int, n int) func(int, *core.BlockGen) {
+	return func(i int, block *core.BlockGen) {
+		signer := types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		if i == 0 {
+			for j := 0; j < depth; j++ {
+				code := benchDeployCode([]byte{0x00})
+				if j > 0 {
+					code = benchDeployCode(benchCallCode(crypto.CreateAddress(testBank, uint64(j-1))))
+				}
+				tx, _ := types.SignTx(types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 200000, big.NewInt(1), code), signer, testBankKey)
+				block.AddTx(tx)
+			}
+			return
+		}
+		top := crypto.CreateAddress(testBank, uint64(depth-1))
+		for j := 0; j < n; j++ {
+			tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), top, new(big.Int), 1000000, big.NewInt(1), nil), signer, testBankKey)
+			block.AddTx(tx)
+		}
+	}
+}
+
+// BenchmarkTraceBlock measures the time and allocations of tracing blocks of
+// various shapes with callTracerParity through trace_block: many plain value
+// transfers, many DeFi-like transactions each fanning out into token calls
+// which touch storage and emit logs, and transactions going through deeply
+// nested calls. The blocks are generated to mimic the shapes of busy mainnet
+// blocks, the recorded mainnet transactions of the tracer fixtures are measured
+// on their own by the eth/tracers benchmarks.
+func BenchmarkTraceBlock(b *testing.B) {
+	benchmarks := []struct {
+		name      string
+		blocks    int
+		generator func(int, *core.BlockGen)
+	}{
+		{"Transfers", 1, testTransferBlocks(150)},
+		{"DefiCalls", 2, testDefiBlocks(40)},
+		{"DeepCalls", 2, testDeepCallBlocks(32, 10)},
+	}
+	for _, bench := range benchmarks {
+		b.Run(bench.name, func(b *testing.B) {
+			eth := newTestTraceBackend(b, bench.blocks, bench.generator)
+			api := NewPrivateTraceAPI(eth)
+			number := rpc.BlockNumber(bench.blocks)
+
+			// Make sure the block traces fine before measuring it
+			traces, err := api.Block(context.Background(), number, nil)
+			if err != nil {
+				b.Fatalf("failed to trace block: %v", err)
+			}
+			if len(traces) == 0 {
+				b.Fatalf("no traces produced")
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := api.Block(context.Background(), number, nil); err != nil {
+					b.Fatalf("failed to trace block: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ParityTraceRLP is the compact binary representation of a Parity trace, RLP
+// encoded as a list of its fields in declaration order. The action and result
+// fields specific to some trace types share the fields below, and absent fields
+// are encoded as empty values (e.g. the transaction hash of rewards).
+type ParityTraceRLP struct {
+	BlockNumber         uint64
+	BlockHash           common.Hash
+	TransactionHash     []byte   // Empty for rewards
+	TransactionPosition uint64   // Zero for rewards
+	TraceAddress        []uint64 // Path of the call in the transaction's call tree
+	Subtraces           uint64
+	Type                string   // call, create, suicide or reward
+	Method              string   // action.callType of calls, action.creationMethod of creates, action.rewardType of rewards
+	From                []byte   // action.from, action.address of suicides, action.author of rewards
+	To                  []byte   // action.to, result.address of creates, action.refundAddress of suicides
+	Value               *big.Int // action.value, action.balance of suicides
+	Gas                 uint64
+	GasUsed             uint64
+	Input               []byte // action.input, action.init of creates
+	Output              []byte // result.output, result.code of creates
+	Error               string
+}
+
+// parityTraceJSON is the subset of the JSON form of a Parity trace carried by
+// its binary representation.
+type parityTraceJSON struct {
+	Type   string `json:"type"`
+	Action struct {
+		CallType       string         `json:"callType"`
+		CreationMethod string         `json:"creationMethod"`
+		RewardType     string         `json:"rewardType"`
+		From           hexutil.Bytes  `json:"from"`
+		To             hexutil.Bytes  `json:"to"`
+		Address        hexutil.Bytes  `json:"address"`
+		RefundAddress  hexutil.Bytes  `json:"refundAddress"`
+		Author         hexutil.Bytes  `json:"author"`
+		Value          *hexutil.Big   `json:"value"`
+		Balance        *hexutil.Big   `json:"balance"`
+		Gas            hexutil.Uint64 `json:"gas"`
+		Input          hexutil.Bytes  `json:"input"`
+		Init           hexutil.Bytes  `json:"init"`
+	} `json:"action"`
+	Result struct {
+		GasUsed hexutil.Uint64 `json:"gasUsed"`
+		Output  hexutil.Bytes  `json:"output"`
+		Code    hexutil.Bytes  `json:"code"`
+		Address hexutil.Bytes  `json:"address"`
+	} `json:"result"`
+	Error               string       `json:"error"`
+	Subtraces           uint64       `json:"subtraces"`
+	TraceAddress        []uint64     `json:"traceAddress"`
+	TransactionHash     *common.Hash `json:"transactionHash"`
+	TransactionPosition uint64       `json:"transactionPosition"`
+	BlockHash           common.Hash  `json:"blockHash"`
+	BlockNumber         uint64       `json:"blockNumber"`
+}
+
+// binary converts the JSON form of a Parity trace into its binary representation.
+func (trace *parityTraceJSON) binary() *ParityTraceRLP {
+	choose := func(values ...hexutil.Bytes) []byte {
+		for _, value := range values {
+			if value != nil {
+				return value
+			}
+		}
+		return nil
+	}
+	out := &ParityTraceRLP{
+		BlockNumber:         trace.BlockNumber,
+		BlockHash:           trace.BlockHash,
+		TransactionPosition: trace.TransactionPosition,
+		TraceAddress:        trace.TraceAddress,
+		Subtraces:           trace.Subtraces,
+		Type:                trace.Type,
+		From:                choose(trace.Action.From, trace.Action.Address, trace.Action.Author),
+		To:                  choose(trace.Action.To, trace.Result.Address, trace.Action.RefundAddress),
+		Value:               new(big.Int),
+		Gas:                 uint64(trace.Action.Gas),
+		GasUsed:             uint64(trace.Result.GasUsed),
+		Input:               choose(trace.Action.Input, trace.Action.Init),
+		Output:              choose(trace.Result.Output, trace.Result.Code),
+		Error:               trace.Error,
+	}
+	if trace.TransactionHash != nil {
+		out.TransactionHash = trace.TransactionHash.Bytes()
+	}
+	switch {
+	case trace.Action.CallType != "":
+		out.Method = trace.Action.CallType
+	case trace.Action.CreationMethod != "":
+		out.Method = trace.Action.CreationMethod
+	default:
+		out.Method = trace.Action.RewardType
+	}
+	switch {
+	case trace.Action.Value != nil:
+		out.Value = trace.Action.Value.ToInt()
+	case trace.Action.Balance != nil:
+		out.Value = trace.Action.Balance.ToInt()
+	}
+	return out
+}
+
+// encodeTracesRLP converts the given Parity formatted traces into their binary
+// representation, returning the RLP encoded list of them.
+func encodeTracesRLP(traces []interface{}) ([]byte, error) {
+	blob, err := json.Marshal(traces)
+	if err != nil {
+		return nil, err
+	}
+	var decoded []*parityTraceJSON
+	if err := json.Unmarshal(blob, &decoded); err != nil {
+		return nil, fmt.Errorf("traces not representable in binary: %v", err)
+	}
+	out := make([]*ParityTraceRLP, len(decoded))
+	for i, trace := range decoded {
+		out[i] = trace.binary()
+	}
+	return rlp.EncodeToBytes(out)
+}
+
+// validateBinaryTraceConfig checks that the trace config doesn't alter the
+// fields of the Parity traces their binary representation relies on.
+func validateBinaryTraceConfig(config *TraceConfig) error {
+	if config == nil {
+		return nil
+	}
+	if config.DecimalValues {
+		return errors.New("decimalValues is not supported by binary traces")
+	}
+	if len(config.Fields) > 0 {
+		return errors.New("trace field projection is not supported by binary traces")
+	}
+	if config.Format != traceFormatNested {
+		return errors.New("binary traces require the nested trace format")
+	}
+	if config.IncludeBlockSummary {
+		return errors.New("includeBlockSummary is not supported by binary traces")
+	}
+	if config.MaxDataBytes > 0 {
+		return errors.New("maxDataBytes is not supported by binary traces")
+	}
+	return nil
+}
+
+// BlockRLP returns the Parity traces of the given block, as trace_block would,
+// in their compact binary representation: an RLP encoded list of ParityTraceRLP
+// items, sparing high throughput consumers the decoding of the JSON traces.
+func (api *PrivateTraceAPI) BlockRLP(ctx context.Context, number rpc.BlockNumber, config *TraceConfig) (hexutil.Bytes, error) {
+	if err := validateBinaryTraceConfig(config); err != nil {
+		return nil, err
+	}
+	traces, err := api.Block(ctx, number, config)
+	if err != nil {
+		return nil, err
+	}
+	return encodeTracesRLP(traces)
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Tests that trace_blockRLP returns the traces of trace_block in their binary
+// representation.
+func TestTraceBlockRLP(t *testing.T) {
+	runtime := []byte{0x60, 0x2a, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3} // RETURN(MSTORE(0, 42))
+	code := append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+
+	signer := types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+	eth := newTestTraceBackend(t, 1, func(i int, block *core.BlockGen) {
+		create, _ := types.SignTx(types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 100000, big.NewInt(1), code), signer, testBankKey)
+		block.AddTx(create)
+		call, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), crypto.CreateAddress(testBank, 0), big.NewInt(1000), 100000, big.NewInt(1), []byte{0x01}), signer, testBankKey)
+		block.AddTx(call)
+	})
+	api := NewPrivateTraceAPI(eth)
+	block := eth.blockchain.GetBlockByNumber(1)
+
+	blob, err := api.BlockRLP(context.Background(), rpc.BlockNumber(1), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	var traces []*ParityTraceRLP
+	if err := rlp.DecodeBytes(blob, &traces); err != nil {
+		t.Fatalf("failed to decode traces: %v", err)
+	}
+	if len(traces) != 3 {
+		t.Fatalf("trace count mismatch: have %d, want 3", len(traces))
+	}
+	create, call, reward := traces[0], traces[1], traces[2]
+	for i, trace := range traces {
+		if trace.BlockNumber != 1 || trace.BlockHash != block.Hash() {
+			t.Errorf("trace %d: block mismatch: have #%d %x", i, trace.BlockNumber, trace.BlockHash)
+		}
+	}
+	if create.Type != "create" || create.Method != "create" || !bytes.Equal(create.From, testBank.Bytes()) || !bytes.Equal(create.To, crypto.CreateAddress(testBank, 0).Bytes()) {
+		t.Errorf("create mismatch: %+v", create)
+	}
+	if !bytes.Equal(create.Input, code) || !bytes.Equal(create.Output, runtime) || !bytes.Equal(create.TransactionHash, block.Transactions()[0].Hash().Bytes()) {
+		t.Errorf("create data mismatch: %+v", create)
+	}
+	if call.Type != "call" || call.Method != "call" || !bytes.Equal(call.To, crypto.CreateAddress(testBank, 0).Bytes()) || call.Value.Cmp(big.NewInt(1000)) != 0 || call.TransactionPosition != 1 {
+		t.Errorf("call mismatch: %+v", call)
+	}
+	if !bytes.Equal(call.Input, []byte{0x01}) || !bytes.Equal(call.Output, common.BigToHash(big.NewInt(42)).Bytes()) || call.GasUsed == 0 || len(call.TraceAddress) != 0 {
+		t.Errorf("call data mismatch: %+v", call)
+	}
+	if reward.Type != "reward" || reward.Method != "block" || !bytes.Equal(reward.From, block.Coinbase().Bytes()) || len(reward.TransactionHash) != 0 || reward.Value.Sign() <= 0 {
+		t.Errorf("reward mismatch: %+v", reward)
+	}
+	// Options altering the trace fields are rejected
+	for i, config := range []*TraceConfig{{DecimalValues: true}, {Fields: []string{"type"}}, {Format: traceFormatRows}, {IncludeBlockSummary: true}} {
+		if _, err := api.BlockRLP(context.Background(), rpc.BlockNumber(1), config); err == nil {
+			t.Errorf("config %d: expected error", i)
+		}
+	}
+}
@@ -0,0 +1,407 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxTraceFilterBlocks is the maximum number of blocks a non-streaming
+// trace_filter request may span. Longer ranges need to use the subscription.
+const maxTraceFilterBlocks = 1000
+
+// TraceReplayResult is the result of replaying a transaction with the trace
+// types of OpenEthereum's trace_replay* and trace_rawTransaction methods.
+type TraceReplayResult struct {
+	Output          hexutil.Bytes `json:"output"`
+	StateDiff       interface{}   `json:"stateDiff"`
+	Trace           []interface{} `json:"trace"`
+	TransactionHash *common.Hash  `json:"transactionHash,omitempty"`
+	VMTrace         interface{}   `json:"vmTrace"` // Not implemented yet, always null
+}
+
+// PrivateTraceCompatAPI exposes the OpenEthereum trace methods whose wire name
+// is already taken by a subscription of the trace API. The rpc server merges
+// both services into the trace namespace, so trace_filter can be both called
+// and subscribed to.
+type PrivateTraceCompatAPI struct {
+	trace *PrivateTraceAPI
+}
+
+// NewPrivateTraceCompatAPI creates a new API definition for the OpenEthereum
+// compatible methods of the Ethereum service.
+func NewPrivateTraceCompatAPI(eth *Ethereum) *PrivateTraceCompatAPI {
+	return &PrivateTraceCompatAPI{trace: NewPrivateTraceAPI(eth)}
+}
+
+// Filter returns the traces of the given inclusive block range matching the
+// address filter in one response, like OpenEthereum's trace_filter does. The
+// after and count arguments page through the matching traces, optionally sorted
+// by descending value.
+func (api *PrivateTraceCompatAPI) Filter(ctx context.Context, args TraceFilterArgs, config *TraceConfig) (traces []interface{}, err error) {
+	start, end := uint64(args.FromBlock), uint64(args.ToBlock)
+	if end < start {
+		return nil, fmt.Errorf("end block (#%d) precedes start block (#%d)", end, start)
+	}
+	if end-start >= maxTraceFilterBlocks {
+		return nil, fmt.Errorf("block range of %d blocks exceeds the limit of %d, use the filter subscription instead", end-start+1, maxTraceFilterBlocks)
+	}
+	if config != nil && config.CancelToken != "" {
+		registered := *config
+		registered.CancelToken = ""
+		err = api.trace.eth.traceJobs.run(ctx, config.CancelToken, func(ctx context.Context) (err error) {
+			traces, err = api.Filter(ctx, args, &registered)
+			return err
+		})
+		return traces, err
+	}
+	if args.filtersTraces() {
+		filtered := TraceConfig{}
+		if config != nil {
+			filtered = *config
+		}
+		filtered.addresses = &args
+		config = &filtered
+	}
+	// Skip the blocks the trace index rules out, their traces would all be
+	// filtered out anyway. Block summaries are reported even for those.
+	mayMatch := func(uint64) bool { return true }
+	if config == nil || !config.IncludeBlockSummary {
+		mayMatch = indexedTraceBlocks(api.trace.eth, &args, config, start, end)
+	}
+
+	// Sorting by value needs the traces of the whole range, only the requested
+	// page of the largest ones is retained meanwhile
+	var sorted *valueSortedTraces
+	if args.SortByValue {
+		sorted = &valueSortedTraces{sizeLimit: api.trace.eth.config.Trace.FilterSizeLimit}
+		if args.Count > 0 {
+			sorted.limit = int(args.After + args.Count)
+		}
+	}
+	traces = []interface{}{}
+	for number := start; ; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if mayMatch(number) {
+			block, err := api.trace.blockTracesByNumber(ctx, rpc.BlockNumber(number), config)
+			if err != nil {
+				return nil, err
+			}
+			if sorted != nil {
+				if err := sorted.add(block); err != nil {
+					return nil, err
+				}
+			} else {
+				traces = append(traces, block...)
+			}
+		}
+
+		// Stop early if the requested page was already filled
+		if number == end || (sorted == nil && args.Count > 0 && uint64(len(traces)) >= args.After+args.Count) {
+			break
+		}
+	}
+	if sorted != nil {
+		traces = sorted.traces()
+	}
+	if args.After >= uint64(len(traces)) {
+		return []interface{}{}, nil
+	}
+	traces = traces[args.After:]
+	if args.Count > 0 && uint64(len(traces)) > args.Count {
+		traces = traces[:args.Count]
+	}
+	return traces, nil
+}
+
+// Get returns the trace at the given trace address (e.g. ["0x0", "0x1"]) of
+// a transaction, or null if it doesn't exist.
+func (api *PrivateTraceAPI) Get(ctx context.Context, hash common.Hash, indices []hexutil.Uint64) (interface{}, error) {
+	res, err := api.Transaction(ctx, hash, nil)
+	if err != nil {
+		return nil, err
+	}
+	traces, err := decodeParityTraces(res)
+	if err != nil {
+		return nil, err
+	}
+	for _, trace := range traces {
+		object, ok := trace.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		address, _ := object["traceAddress"].([]interface{})
+		if len(address) != len(indices) {
+			continue
+		}
+		matches := true
+		for i, index := range address {
+			if n, ok := index.(float64); !ok || uint64(n) != uint64(indices[i]) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return trace, nil
+		}
+	}
+	return nil, nil
+}
+
+// RawTransaction traces the given signed, RLP encoded transaction on top of
+// the latest block with the requested OpenEthereum trace types.
+func (api *PrivateTraceAPI) RawTransaction(ctx context.Context, data hexutil.Bytes, traceTypes []string) (*TraceReplayResult, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(data, tx); err != nil {
+		return nil, fmt.Errorf("could not decode transaction: %v", err)
+	}
+	head := api.eth.blockchain.CurrentBlock()
+	from, err := types.Sender(types.MakeSigner(api.eth.blockchain.Config(), head.Number()), tx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction sender: %v", err)
+	}
+	var (
+		gas   = hexutil.Uint64(tx.Gas())
+		input = hexutil.Bytes(tx.Data())
+		args  = ethapi.CallArgs{
+			From:     &from,
+			To:       tx.To(),
+			Gas:      &gas,
+			GasPrice: (*hexutil.Big)(tx.GasPrice()),
+			Value:    (*hexutil.Big)(tx.Value()),
+			Data:     &input,
+		}
+		block = rpc.BlockNumberOrHashWithHash(head.Hash(), false)
+	)
+	results, err := replayTraces(traceTypes, func(config *TraceConfig) ([]interface{}, error) {
+		res, err := api.Call(ctx, args, block, config)
+		return []interface{}{res}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// ReplayTransaction replays a transaction of the chain with the requested
+// OpenEthereum trace types.
+func (api *PrivateTraceAPI) ReplayTransaction(ctx context.Context, hash common.Hash, traceTypes []string) (*TraceReplayResult, error) {
+	results, err := replayTraces(traceTypes, func(config *TraceConfig) ([]interface{}, error) {
+		res, err := api.Transaction(ctx, hash, config)
+		return []interface{}{res}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// ReplayBlockTransactions replays all the transactions of a block with the
+// requested OpenEthereum trace types.
+func (api *PrivateTraceAPI) ReplayBlockTransactions(ctx context.Context, number rpc.BlockNumber, traceTypes []string) ([]*TraceReplayResult, error) {
+	block := blockByNumber(api.eth, number)
+	if block == nil {
+		return nil, missingBlockError(api.eth, number)
+	}
+	signer := types.MakeSigner(api.eth.blockchain.Config(), block.Number())
+
+	results, err := replayTraces(traceTypes, func(config *TraceConfig) ([]interface{}, error) {
+		config = setTraceConfigRedaction(config, &api.eth.config.Trace)
+		traced, err := traceBlock(ctx, api.eth, block, config)
+		if err != nil {
+			return nil, err
+		}
+		parity := *config.Tracer == "callTracerParity"
+
+		results := make([]interface{}, len(traced))
+		for i, result := range traced {
+			switch {
+			case result.Error != "" && parity:
+				results[i] = []interface{}{erroredTransactionTrace(signer, block, i, result.Error)}
+			case result.Error != "":
+				results[i] = nil
+			case parity:
+				if results[i], err = formatParityTraceResult(result.Result, config); err != nil {
+					return nil, err
+				}
+			default:
+				results[i] = result.Result
+			}
+		}
+		return results, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, tx := range block.Transactions() {
+		hash := tx.Hash()
+		results[i].TransactionHash = &hash
+	}
+	return results, nil
+}
+
+// replayTraces runs the given per transaction trace function with the tracers
+// backing the requested OpenEthereum trace types, assembling their results.
+// The call trace is always run, as it carries the output of the transactions.
+func replayTraces(traceTypes []string, run func(config *TraceConfig) ([]interface{}, error)) ([]*TraceReplayResult, error) {
+	var wantTrace, wantStateDiff bool
+	for _, traceType := range traceTypes {
+		switch traceType {
+		case "trace":
+			wantTrace = true
+		case "stateDiff":
+			wantStateDiff = true
+		case "vmTrace":
+			// Not implemented yet, reported as null
+		default:
+			return nil, fmt.Errorf("unknown trace type %q", traceType)
+		}
+	}
+	traces, err := run(setTraceConfigDefaultTracer(nil))
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*TraceReplayResult, len(traces))
+	for i, res := range traces {
+		calls, err := decodeParityTraces(res)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = &TraceReplayResult{Output: parityTraceOutput(calls), Trace: []interface{}{}}
+		if wantTrace {
+			results[i].Trace = replayParityTraces(calls)
+		}
+	}
+	if wantStateDiff {
+		tracer := "stateDiffTracer"
+		diffs, err := run(&TraceConfig{Tracer: &tracer})
+		if err != nil {
+			return nil, err
+		}
+		for i, diff := range diffs {
+			results[i].StateDiff = diff
+		}
+	}
+	return results, nil
+}
+
+// replayTraceFields are the fields of the flat traces returned by OpenEthereum's
+// replay methods. Unlike the traces of trace_block and friends, they don't carry
+// their location within the chain.
+var replayTraceFields = []string{"action", "error", "result", "subtraces", "traceAddress", "type"}
+
+// replayParityTraces strips the Parity traces of a transaction down to the
+// fields of OpenEthereum's replay traces.
+func replayParityTraces(traces []interface{}) []interface{} {
+	replayed := make([]interface{}, 0, len(traces))
+	for _, trace := range traces {
+		object, ok := trace.(map[string]interface{})
+		if !ok {
+			replayed = append(replayed, trace)
+			continue
+		}
+		stripped := make(map[string]interface{}, len(replayTraceFields))
+		for _, field := range replayTraceFields {
+			if value, ok := object[field]; ok {
+				stripped[field] = value
+			}
+		}
+		replayed = append(replayed, stripped)
+	}
+	return replayed
+}
+
+// decodeParityTraces converts a Parity tracer result, either raw or already
+// formatted, into its list of traces.
+func decodeParityTraces(res interface{}) ([]interface{}, error) {
+	switch res := res.(type) {
+	case []interface{}:
+		return res, nil
+	case json.RawMessage:
+		var traces []interface{}
+		if err := json.Unmarshal(res, &traces); err != nil {
+			return nil, err
+		}
+		return traces, nil
+	case nil:
+		return []interface{}{}, nil
+	default:
+		return nil, fmt.Errorf("unexpected trace result type %T", res)
+	}
+}
+
+// parityTraceOutput returns the output of the transaction the given Parity
+// traces belong to, as reported by its root trace. Like OpenEthereum, the output
+// of a contract creation is the deployed code (i.e. what the constructor returned,
+// not the init code), while failed transactions and plain transfers have none.
+func parityTraceOutput(traces []interface{}) hexutil.Bytes {
+	for _, trace := range traces {
+		object, ok := trace.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if address, _ := object["traceAddress"].([]interface{}); len(address) != 0 {
+			continue
+		}
+		field := "output"
+		if object["type"] == "create" {
+			field = "code"
+		}
+		result, _ := object["result"].(map[string]interface{})
+		output, _ := result[field].(string)
+		blob, err := hexutil.Decode(output)
+		if err != nil {
+			break
+		}
+		return blob
+	}
+	return hexutil.Bytes{}
+}
+
+// blockByNumber retrieves the block of the given number, resolving the pending
+// and latest tags, or nil if it doesn't exist.
+func blockByNumber(eth *Ethereum, number rpc.BlockNumber) *types.Block {
+	switch number {
+	case rpc.PendingBlockNumber:
+		return eth.miner.PendingBlock()
+	case rpc.LatestBlockNumber:
+		return eth.blockchain.CurrentBlock()
+	default:
+		return eth.blockchain.GetBlockByNumber(uint64(number))
+	}
+}
+
+// missingBlockError returns the error of a block of the given number failing to
+// load. A block whose header is known but whose body isn't (e.g. while syncing)
+// is reported as such, rather than being traced as one without transactions.
+func missingBlockError(eth *Ethereum, number rpc.BlockNumber) error {
+	if number >= 0 && eth.blockchain.GetHeaderByNumber(uint64(number)) != nil {
+		return fmt.Errorf("block #%d: block body not available", number)
+	}
+	return fmt.Errorf("block #%d not found", number)
+}
@@ -0,0 +1,681 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/vars"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Tests that the trace methods are served under the exact wire names of
+// OpenEthereum's trace module.
+func TestTraceOpenEthereumMethodNames(t *testing.T) {
+	eth := newTestTraceBackend(t, 2, testTransferBlocks(2))
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	for _, api := range []interface{}{NewPrivateTraceAPI(eth), NewPrivateTraceCompatAPI(eth)} {
+		if err := server.RegisterName("trace", api); err != nil {
+			t.Fatalf("failed to register trace API: %v", err)
+		}
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	var (
+		tx   = eth.blockchain.GetBlockByNumber(1).Transactions()[0]
+		to   = common.Address{0x01}
+		call = map[string]interface{}{"from": testBank, "to": to, "value": "0x1"}
+	)
+	signed, _ := types.SignTx(types.NewTransaction(4, to, big.NewInt(1), vars.TxGas, big.NewInt(1), nil), types.NewEIP155Signer(params.TestChainConfig.GetChainID()), testBankKey)
+	raw, _ := rlp.EncodeToBytes(signed)
+
+	for _, tt := range []struct {
+		method string
+		args   []interface{}
+	}{
+		{"trace_block", []interface{}{"0x1"}},
+		{"trace_transaction", []interface{}{tx.Hash()}},
+		{"trace_filter", []interface{}{map[string]interface{}{"fromBlock": "0x1", "toBlock": "0x2"}}},
+		{"trace_call", []interface{}{call, "latest"}},
+		{"trace_callMany", []interface{}{[]interface{}{call, call}, "latest"}},
+		{"trace_get", []interface{}{tx.Hash(), []string{}}},
+		{"trace_rawTransaction", []interface{}{hexutil.Bytes(raw), []string{"trace"}}},
+		{"trace_replayTransaction", []interface{}{tx.Hash(), []string{"trace", "stateDiff"}}},
+		{"trace_replayBlockTransactions", []interface{}{"0x1", []string{"trace", "vmTrace"}}},
+	} {
+		var result json.RawMessage
+		if err := client.Call(&result, tt.method, tt.args...); err != nil {
+			t.Errorf("%s: call failed: %v", tt.method, err)
+			continue
+		}
+		if string(result) == "null" {
+			t.Errorf("%s: empty result", tt.method)
+		}
+	}
+	// The streaming filter must remain available as a subscription
+	results := make(chan *blockTraceResult)
+	sub, err := client.Subscribe(context.Background(), "trace", results, "filter", TraceFilterArgs{FromBlock: 1, ToBlock: 1}, nil)
+	if err != nil {
+		t.Fatalf("failed to subscribe to the filter: %v", err)
+	}
+	sub.Unsubscribe()
+}
+
+// Tests that the non-streaming trace_filter pages through the traces of its
+// range according to the after and count arguments.
+func TestTraceFilterPaging(t *testing.T) {
+	eth := newTestTraceBackend(t, 2, testTransferBlocks(2))
+	api := NewPrivateTraceCompatAPI(eth)
+
+	// Each block holds two transfer traces and a block reward trace
+	all, err := api.Filter(context.Background(), TraceFilterArgs{FromBlock: 1, ToBlock: 2}, nil)
+	if err != nil {
+		t.Fatalf("failed to filter traces: %v", err)
+	}
+	if len(all) != 6 {
+		t.Fatalf("trace count mismatch: have %d, want %d", len(all), 6)
+	}
+	page, err := api.Filter(context.Background(), TraceFilterArgs{FromBlock: 1, ToBlock: 2, After: 2, Count: 3}, nil)
+	if err != nil {
+		t.Fatalf("failed to filter traces: %v", err)
+	}
+	// Compare the traces without their (varying) execution time
+	strip := func(traces []interface{}) string {
+		var objects []map[string]interface{}
+		blob, _ := json.Marshal(traces)
+		json.Unmarshal(blob, &objects)
+		for _, object := range objects {
+			delete(object, "time")
+		}
+		blob, _ = json.Marshal(objects)
+		return string(blob)
+	}
+	if have, want := strip(page), strip(all[2:5]); have != want {
+		t.Errorf("page mismatch:\nhave %s\nwant %s", have, want)
+	}
+	if _, err := api.Filter(context.Background(), TraceFilterArgs{FromBlock: 0, ToBlock: maxTraceFilterBlocks}, nil); err == nil {
+		t.Errorf("expected oversized range to fail")
+	}
+}
+
+// Tests that trace_filter matches self-referential calls, whose sender is their
+// recipient, under both address filters at once and only once, the address
+// filters combining like OpenEthereum's.
+func TestTraceFilterSelfCall(t *testing.T) {
+	var (
+		signer = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+
+		// A contract calling itself once, with a byte of input to stop there
+		runtime = []byte{
+			0x36, 0x60, 0x13, 0x57, // JUMPI(19, CALLDATASIZE)
+			0x60, 0x00, 0x60, 0x00, 0x60, 0x01, 0x60, 0x00, 0x60, 0x00, 0x30, 0x5a, 0xf1, 0x50, // POP(CALL(GAS, ADDRESS, 0, 0, 1, 0, 0))
+			0x00,       // STOP
+			0x5b, 0x00, // JUMPDEST, STOP
+		}
+		contract = crypto.CreateAddress(testBank, 0)
+	)
+	eth := newTestTraceBackend(t, 2, func(i int, block *core.BlockGen) {
+		var tx *types.Transaction
+		switch i {
+		case 0:
+			initcode := append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+			tx = types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 200000, big.NewInt(1), initcode)
+		case 1:
+			tx = types.NewTransaction(block.TxNonce(testBank), contract, new(big.Int), 200000, big.NewInt(1), nil)
+		}
+		tx, _ = types.SignTx(tx, signer, testBankKey)
+		block.AddTx(tx)
+	})
+	api := NewPrivateTraceCompatAPI(eth)
+
+	tests := []struct {
+		from, to *common.Address
+		want     [][]int // Trace addresses of the matching traces
+	}{
+		{from: &contract, to: &contract, want: [][]int{{0}}},
+		{from: &contract, want: [][]int{{0}}},
+		{to: &contract, want: [][]int{{}, {0}}},
+		{from: &testBank, to: &contract, want: [][]int{{}}},
+		{from: &contract, to: &testBank},
+	}
+	for i, tt := range tests {
+		traces, err := api.Filter(context.Background(), TraceFilterArgs{FromBlock: 2, ToBlock: 2, FromAddress: tt.from, ToAddress: tt.to}, nil)
+		if err != nil {
+			t.Fatalf("test %d: failed to filter traces: %v", i, err)
+		}
+		blob, _ := json.Marshal(traces)
+		var decoded []struct {
+			TraceAddress []int `json:"traceAddress"`
+		}
+		if err := json.Unmarshal(blob, &decoded); err != nil {
+			t.Fatalf("test %d: failed to decode traces: %v", i, err)
+		}
+		var have [][]int
+		for _, trace := range decoded {
+			have = append(have, trace.TraceAddress)
+		}
+		if !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("test %d: matching traces mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+}
+
+// Tests that trace_filter matches block rewards by their author under the
+// recipient address filter, like the trace index does.
+func TestTraceFilterRewardAuthor(t *testing.T) {
+	miner := common.Address{0xaa}
+	eth := newTestTraceBackend(t, 2, func(i int, block *core.BlockGen) {
+		block.SetCoinbase(miner)
+		if i == 0 {
+			tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), miner, big.NewInt(1000), vars.TxGas, big.NewInt(1), nil), types.HomesteadSigner{}, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceCompatAPI(eth)
+
+	tests := []struct {
+		from, to *common.Address
+		want     []string // Types of the matching traces
+	}{
+		{to: &miner, want: []string{"call", "reward", "reward"}},
+		{from: &testBank, to: &miner, want: []string{"call"}},
+		{from: &miner},
+	}
+	for i, tt := range tests {
+		traces, err := api.Filter(context.Background(), TraceFilterArgs{FromBlock: 1, ToBlock: 2, FromAddress: tt.from, ToAddress: tt.to}, nil)
+		if err != nil {
+			t.Fatalf("test %d: failed to filter traces: %v", i, err)
+		}
+		blob, _ := json.Marshal(traces)
+		var decoded []struct {
+			Type   string `json:"type"`
+			Action struct {
+				Author *common.Address `json:"author"`
+			} `json:"action"`
+		}
+		if err := json.Unmarshal(blob, &decoded); err != nil {
+			t.Fatalf("test %d: failed to decode traces: %v", i, err)
+		}
+		var have []string
+		for _, trace := range decoded {
+			have = append(have, trace.Type)
+			if trace.Type == "reward" && (trace.Action.Author == nil || *trace.Action.Author != miner) {
+				t.Errorf("test %d: reward author mismatch: have %v, want %x", i, trace.Action.Author, miner)
+			}
+		}
+		if !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("test %d: matching traces mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+}
+
+// Tests that trace_filter only returns the traces transferring at least the
+// requested minimum value, excluding rewards, combined with the address filters.
+func TestTraceFilterMinValue(t *testing.T) {
+	values := []int64{500, 1000, 5000}
+	eth := newTestTraceBackend(t, 1, func(i int, block *core.BlockGen) {
+		for j, value := range values {
+			tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), common.Address{byte(j + 1)}, big.NewInt(value), vars.TxGas, big.NewInt(1), nil), types.HomesteadSigner{}, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceCompatAPI(eth)
+
+	filter := func(args TraceFilterArgs) []int64 {
+		args.FromBlock, args.ToBlock = 1, 1
+		traces, err := api.Filter(context.Background(), args, nil)
+		if err != nil {
+			t.Fatalf("failed to filter traces: %v", err)
+		}
+		blob, _ := json.Marshal(traces)
+		var decoded []struct {
+			Type   string `json:"type"`
+			Action struct {
+				Value *hexutil.Big `json:"value"`
+			} `json:"action"`
+		}
+		if err := json.Unmarshal(blob, &decoded); err != nil {
+			t.Fatalf("failed to decode traces: %v", err)
+		}
+		var transferred []int64
+		for _, trace := range decoded {
+			if trace.Type == "reward" {
+				transferred = append(transferred, -1)
+				continue
+			}
+			transferred = append(transferred, trace.Action.Value.ToInt().Int64())
+		}
+		return transferred
+	}
+	var (
+		zero     = (*hexutil.Big)(new(big.Int))
+		thousand = (*hexutil.Big)(big.NewInt(1000))
+		to       = common.Address{0x03}
+	)
+	tests := []struct {
+		args TraceFilterArgs
+		want []int64
+	}{
+		{TraceFilterArgs{}, []int64{500, 1000, 5000, -1}},
+		{TraceFilterArgs{MinValue: zero}, []int64{500, 1000, 5000, -1}},
+		{TraceFilterArgs{MinValue: thousand}, []int64{1000, 5000}},
+		{TraceFilterArgs{MinValue: thousand, ToAddress: &to}, []int64{5000}},
+		{TraceFilterArgs{MinValue: thousand, FromAddress: &testBank}, []int64{1000, 5000}},
+		{TraceFilterArgs{MinValue: (*hexutil.Big)(big.NewInt(5001))}, nil},
+	}
+	for i, tt := range tests {
+		if have := filter(tt.args); !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("test %d: transferred values mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+}
+
+// Tests that trace_filter sorts the traces of a range by descending value before
+// paging through them if requested, within the size limit.
+func TestTraceFilterSortByValue(t *testing.T) {
+	values := [][]int64{{500, 5000}, {1000, 3000}}
+	eth := newTestTraceBackend(t, 2, func(i int, block *core.BlockGen) {
+		for j, value := range values[i] {
+			tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), common.Address{byte(j + 1)}, big.NewInt(value), vars.TxGas, big.NewInt(1), nil), types.HomesteadSigner{}, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceCompatAPI(eth)
+
+	filter := func(args TraceFilterArgs) []int64 {
+		args.FromBlock, args.ToBlock, args.SortByValue = 1, 2, true
+		traces, err := api.Filter(context.Background(), args, nil)
+		if err != nil {
+			t.Fatalf("failed to filter traces: %v", err)
+		}
+		blob, _ := json.Marshal(traces)
+		var decoded []struct {
+			Type   string `json:"type"`
+			Action struct {
+				Value *hexutil.Big `json:"value"`
+			} `json:"action"`
+		}
+		if err := json.Unmarshal(blob, &decoded); err != nil {
+			t.Fatalf("failed to decode traces: %v", err)
+		}
+		var transferred []int64
+		for _, trace := range decoded {
+			if trace.Type == "reward" {
+				transferred = append(transferred, -1)
+				continue
+			}
+			transferred = append(transferred, trace.Action.Value.ToInt().Int64())
+		}
+		return transferred
+	}
+	one := (*hexutil.Big)(big.NewInt(1))
+	tests := []struct {
+		args TraceFilterArgs
+		want []int64
+	}{
+		{TraceFilterArgs{}, []int64{-1, -1, 5000, 3000, 1000, 500}},
+		{TraceFilterArgs{MinValue: one}, []int64{5000, 3000, 1000, 500}},
+		{TraceFilterArgs{MinValue: one, Count: 2}, []int64{5000, 3000}},
+		{TraceFilterArgs{MinValue: one, After: 1, Count: 2}, []int64{3000, 1000}},
+		{TraceFilterArgs{MinValue: one, After: 4}, nil},
+	}
+	for i, tt := range tests {
+		if have := filter(tt.args); !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("test %d: transferred values mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+	// Buffering the sorted traces is bounded by the size limit
+	config := DefaultConfig
+	config.Trace.FilterSizeLimit = 1024
+	eth.config = &config
+
+	if _, err := api.Filter(context.Background(), TraceFilterArgs{FromBlock: 1, ToBlock: 2, SortByValue: true}, nil); err == nil {
+		t.Errorf("expected error for sorted traces exceeding the size limit")
+	}
+	if _, err := api.Filter(context.Background(), TraceFilterArgs{FromBlock: 1, ToBlock: 2, SortByValue: true, Count: 1}, nil); err != nil {
+		t.Errorf("failed to filter traces within the size limit: %v", err)
+	}
+}
+
+// Tests that the trace indexer indexes the trace addresses of the blocks imported
+// after it started, rolls back the blocks reorged out and that trace_filter only
+// traces the indexed blocks involving the filtered addresses.
+func TestTraceIndexImport(t *testing.T) {
+	eth := newTestTraceBackend(t, 2, testTransferBlocks(1))
+	config := DefaultConfig
+	config.Trace.Index = true
+	eth.config = &config
+	api := NewPrivateTraceCompatAPI(eth)
+
+	indexer := newTraceIndexer(eth)
+	indexer.start()
+	defer indexer.stop()
+
+	waitIndexed := func(hash common.Hash) {
+		for i := 0; i < 100 && rawdb.ReadTraceIndexHead(eth.chainDb) != hash; i++ {
+			time.Sleep(50 * time.Millisecond)
+		}
+		if head := rawdb.ReadTraceIndexHead(eth.chainDb); head != hash {
+			t.Fatalf("trace index head mismatch: have %x, want %x", head, hash)
+		}
+	}
+	transfers := func(recipients ...common.Address) func(int, *core.BlockGen) {
+		return func(i int, block *core.BlockGen) {
+			tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), recipients[i%len(recipients)], big.NewInt(1000), vars.TxGas, big.NewInt(1), nil), types.HomesteadSigner{}, testBankKey)
+			block.AddTx(tx)
+		}
+	}
+	filter := func(to common.Address, end uint64) []interface{} {
+		traces, err := api.Filter(context.Background(), TraceFilterArgs{FromBlock: 1, ToBlock: hexutil.Uint64(end), ToAddress: &to}, nil)
+		if err != nil {
+			t.Fatalf("failed to filter traces: %v", err)
+		}
+		return traces
+	}
+	// Import blocks #3 to #6 alternating their recipients
+	var (
+		alice, bob, carol = common.Address{0xaa}, common.Address{0xbb}, common.Address{0xcc}
+		parent            = eth.blockchain.CurrentBlock()
+	)
+	chain, _ := core.GenerateChain(eth.blockchain.Config(), parent, ethash.NewFaker(), eth.chainDb, 4, transfers(alice, bob))
+	if _, err := eth.blockchain.InsertChain(chain); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	waitIndexed(chain[3].Hash())
+
+	if tail := rawdb.ReadTraceIndexTail(eth.chainDb); tail == nil || *tail != 3 {
+		t.Fatalf("trace index tail mismatch: have %v, want 3", tail)
+	}
+	addresses, ok := rawdb.ReadTraceIndexAddresses(eth.chainDb, 3, chain[0].Hash())
+	if !ok {
+		t.Fatalf("block #3 not indexed")
+	}
+	want := []common.Address{{}, testBank, alice} // Block reward author, sender and recipient
+	sort.Slice(want, func(i, j int) bool { return bytes.Compare(want[i][:], want[j][:]) < 0 })
+	if !reflect.DeepEqual(addresses, want) {
+		t.Errorf("block #3 addresses mismatch: have %x, want %x", addresses, want)
+	}
+	mayMatch := indexedTraceBlocks(eth, &TraceFilterArgs{ToAddress: &alice}, nil, 1, 6)
+	for number, match := range []bool{true, true, true, true, false, true, false} {
+		if number > 0 && mayMatch(uint64(number)) != match {
+			t.Errorf("block #%d candidacy mismatch: have %v, want %v", number, !match, match)
+		}
+	}
+	if traces := filter(alice, 6); len(traces) != 2 {
+		t.Errorf("alice trace count mismatch: have %d, want 2", len(traces))
+	}
+	if traces := filter(common.Address{0x01}, 6); len(traces) != 2 {
+		t.Errorf("unindexed blocks trace count mismatch: have %d, want 2", len(traces))
+	}
+	// Reorg blocks #4 to #6 out with a longer side chain
+	side, _ := core.GenerateChain(eth.blockchain.Config(), chain[0], ethash.NewFaker(), eth.chainDb, 4, transfers(carol))
+	if _, err := eth.blockchain.InsertChain(side); err != nil {
+		t.Fatalf("failed to insert side chain: %v", err)
+	}
+	waitIndexed(side[3].Hash())
+
+	for _, block := range chain[1:] {
+		if _, ok := rawdb.ReadTraceIndexAddresses(eth.chainDb, block.NumberU64(), block.Hash()); ok {
+			t.Errorf("reorged block #%d still indexed", block.NumberU64())
+		}
+	}
+	if blocks := rawdb.ReadTraceIndexBlocks(eth.chainDb, bob, 0, 10); len(blocks) != 0 {
+		t.Errorf("reorged recipient still indexed: %v", blocks)
+	}
+	if traces := filter(alice, 7); len(traces) != 1 {
+		t.Errorf("alice trace count mismatch after reorg: have %d, want 1", len(traces))
+	}
+	if traces := filter(carol, 7); len(traces) != 4 {
+		t.Errorf("carol trace count mismatch: have %d, want 4", len(traces))
+	}
+}
+
+// Tests that the trace indexer prunes the blocks falling out of its retention
+// window, trace_filter tracing them like unindexed blocks.
+func TestTraceIndexRetention(t *testing.T) {
+	eth := newTestTraceBackend(t, 2, testTransferBlocks(1))
+	config := DefaultConfig
+	config.Trace.Index = true
+	config.Trace.IndexRetention = 2
+	eth.config = &config
+	api := NewPrivateTraceCompatAPI(eth)
+
+	indexer := newTraceIndexer(eth)
+	indexer.start()
+	defer indexer.stop()
+
+	// Import blocks #3 to #6, all transferring to the same recipient
+	recipient := common.Address{0xaa}
+	chain, _ := core.GenerateChain(eth.blockchain.Config(), eth.blockchain.CurrentBlock(), ethash.NewFaker(), eth.chainDb, 4, func(i int, block *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), recipient, big.NewInt(1000), vars.TxGas, big.NewInt(1), nil), types.HomesteadSigner{}, testBankKey)
+		block.AddTx(tx)
+	})
+	if _, err := eth.blockchain.InsertChain(chain); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	for i := 0; i < 100 && rawdb.ReadTraceIndexHead(eth.chainDb) != chain[3].Hash(); i++ {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if head := rawdb.ReadTraceIndexHead(eth.chainDb); head != chain[3].Hash() {
+		t.Fatalf("trace index head mismatch: have %x, want %x", head, chain[3].Hash())
+	}
+	indexer.stop()
+
+	if tail := rawdb.ReadTraceIndexTail(eth.chainDb); tail == nil || *tail != 5 {
+		t.Fatalf("trace index tail mismatch: have %v, want 5", tail)
+	}
+	for i, block := range chain {
+		if _, ok := rawdb.ReadTraceIndexAddresses(eth.chainDb, block.NumberU64(), block.Hash()); ok != (i >= 2) {
+			t.Errorf("block #%d indexed mismatch: have %v, want %v", block.NumberU64(), ok, i >= 2)
+		}
+	}
+	if blocks := rawdb.ReadTraceIndexBlocks(eth.chainDb, recipient, 0, 10); len(blocks) != 2 {
+		t.Errorf("recipient block count mismatch: have %d, want 2", len(blocks))
+	}
+	// The pruned blocks are traced again by trace_filter
+	traces, err := api.Filter(context.Background(), TraceFilterArgs{FromBlock: 1, ToBlock: 6, ToAddress: &recipient}, nil)
+	if err != nil {
+		t.Fatalf("failed to filter traces: %v", err)
+	}
+	if len(traces) != 4 {
+		t.Errorf("recipient trace count mismatch: have %d, want 4", len(traces))
+	}
+}
+
+// Tests that trace_filter doesn't rely on the trace index, which only holds the
+// addresses of the default traces, when the precompile calls are reported too.
+func TestTraceIndexPrecompiles(t *testing.T) {
+	// A contract calling the identity precompile without data
+	runtime := []byte{
+		0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x04, 0x5a, 0xfa, // STATICCALL(GAS, 4, 0, 0, 0, 0)
+		0x00, // STOP
+	}
+	var (
+		signer   = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		caller   = crypto.CreateAddress(testBank, 0)
+		identity = common.BytesToAddress([]byte{0x04})
+	)
+	eth := newTestTraceBackend(t, 1, func(i int, block *core.BlockGen) {
+		initcode := append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+		tx, _ := types.SignTx(types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 100000, big.NewInt(1), initcode), signer, testBankKey)
+		block.AddTx(tx)
+	})
+	config := DefaultConfig
+	config.Trace.Index = true
+	eth.config = &config
+	api := NewPrivateTraceCompatAPI(eth)
+
+	indexer := newTraceIndexer(eth)
+	indexer.start()
+	defer indexer.stop()
+
+	// Import block #2 calling the precompile through the contract
+	chain, _ := core.GenerateChain(eth.blockchain.Config(), eth.blockchain.CurrentBlock(), ethash.NewFaker(), eth.chainDb, 1, func(i int, block *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), caller, new(big.Int), 100000, big.NewInt(1), nil), signer, testBankKey)
+		block.AddTx(tx)
+	})
+	if _, err := eth.blockchain.InsertChain(chain); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	for i := 0; i < 100 && rawdb.ReadTraceIndexHead(eth.chainDb) != chain[0].Hash(); i++ {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if head := rawdb.ReadTraceIndexHead(eth.chainDb); head != chain[0].Hash() {
+		t.Fatalf("trace index head mismatch: have %x, want %x", head, chain[0].Hash())
+	}
+	if blocks := rawdb.ReadTraceIndexBlocks(eth.chainDb, identity, 0, 10); len(blocks) != 0 {
+		t.Fatalf("precompile indexed: %v", blocks)
+	}
+	args := TraceFilterArgs{FromBlock: 2, ToBlock: 2, ToAddress: &identity}
+	for _, include := range []bool{false, true} {
+		traces, err := api.Filter(context.Background(), args, &TraceConfig{IncludePrecompiles: include})
+		if err != nil {
+			t.Fatalf("includePrecompiles %v: failed to filter traces: %v", include, err)
+		}
+		want := 0
+		if include {
+			want = 1
+		}
+		if len(traces) != want {
+			t.Errorf("includePrecompiles %v: trace count mismatch: have %d, want %d", include, len(traces), want)
+		}
+	}
+}
+
+// Tests that the replay methods return the output and the requested trace
+// types of the replayed transactions.
+func TestTraceReplayBlockTransactions(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(2))
+	api := NewPrivateTraceAPI(eth)
+
+	results, err := api.ReplayBlockTransactions(context.Background(), rpc.BlockNumber(1), []string{"trace"})
+	if err != nil {
+		t.Fatalf("failed to replay block: %v", err)
+	}
+	block := eth.blockchain.GetBlockByNumber(1)
+	if len(results) != len(block.Transactions()) {
+		t.Fatalf("result count mismatch: have %d, want %d", len(results), len(block.Transactions()))
+	}
+	for i, result := range results {
+		if *result.TransactionHash != block.Transactions()[i].Hash() {
+			t.Errorf("result %d: transaction hash mismatch: have %x, want %x", i, *result.TransactionHash, block.Transactions()[i].Hash())
+		}
+		if len(result.Trace) != 1 || result.StateDiff != nil || result.VMTrace != nil {
+			t.Errorf("result %d: trace types mismatch: %d traces, stateDiff %v, vmTrace %v", i, len(result.Trace), result.StateDiff, result.VMTrace)
+		}
+	}
+	if _, err := api.ReplayBlockTransactions(context.Background(), rpc.BlockNumber(1), []string{"unknown"}); err == nil {
+		t.Errorf("expected unknown trace type to fail")
+	}
+}
+
+// Tests that the replay methods report the return data of the transaction as
+// its output: none for transfers and the deployed code for creations.
+func TestTraceReplayTransactionOutput(t *testing.T) {
+	var (
+		signer = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+
+		// A contract returning the word 42
+		runtime  = []byte{0x60, 0x2a, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3}
+		initcode = append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+		contract = crypto.CreateAddress(testBank, 0)
+	)
+	eth := newTestTraceBackend(t, 2, func(i int, block *core.BlockGen) {
+		var txs []*types.Transaction
+		if i == 0 {
+			txs = append(txs, types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 100000, big.NewInt(1), initcode))
+		} else {
+			txs = append(txs, types.NewTransaction(block.TxNonce(testBank), common.Address{0x01}, big.NewInt(1), vars.TxGas, big.NewInt(1), nil))
+			txs = append(txs, types.NewTransaction(block.TxNonce(testBank)+1, contract, new(big.Int), 100000, big.NewInt(1), nil))
+		}
+		for _, tx := range txs {
+			tx, _ = types.SignTx(tx, signer, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	for _, tt := range []struct {
+		name   string
+		tx     *types.Transaction
+		output []byte
+	}{
+		{"create", eth.blockchain.GetBlockByNumber(1).Transactions()[0], runtime},
+		{"transfer", eth.blockchain.GetBlockByNumber(2).Transactions()[0], []byte{}},
+		{"call", eth.blockchain.GetBlockByNumber(2).Transactions()[1], common.LeftPadBytes([]byte{0x2a}, 32)},
+	} {
+		result, err := api.ReplayTransaction(context.Background(), tt.tx.Hash(), []string{"trace"})
+		if err != nil {
+			t.Fatalf("%s: failed to replay transaction: %v", tt.name, err)
+		}
+		if !bytes.Equal(result.Output, tt.output) {
+			t.Errorf("%s: output mismatch: have %x, want %x", tt.name, result.Output, tt.output)
+		}
+	}
+}
+
+// Tests that trace_replayBlockTransactions responds exactly like OpenEthereum,
+// with an array of per transaction results, each holding an array of flat traces
+// without their location within the chain.
+func TestTraceReplayBlockTransactionsFixture(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(2))
+	api := NewPrivateTraceAPI(eth)
+
+	results, err := api.ReplayBlockTransactions(context.Background(), rpc.BlockNumber(1), []string{"trace"})
+	if err != nil {
+		t.Fatalf("failed to replay block: %v", err)
+	}
+	blob, err := json.Marshal(results)
+	if err != nil {
+		t.Fatalf("failed to encode results: %v", err)
+	}
+	var have, want interface{}
+	if err := json.Unmarshal(blob, &have); err != nil {
+		t.Fatalf("failed to decode results: %v", err)
+	}
+	fixture, err := ioutil.ReadFile(filepath.Join("testdata", "replay_block_transactions.json"))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	if err := json.Unmarshal(fixture, &want); err != nil {
+		t.Fatalf("failed to decode fixture: %v", err)
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("replay mismatch:\nhave %s\nwant %s", blob, fixture)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TraceDiscrepancy is a field of a call which differs between the outputs of
+// callTracer and callTracerParity. Missing calls are reported with the "call"
+// field, set to true on the side the call is present.
+type TraceDiscrepancy struct {
+	TraceAddress []int       `json:"traceAddress"`
+	Field        string      `json:"field"`
+	CallTracer   interface{} `json:"callTracer"`
+	Parity       interface{} `json:"parity"`
+}
+
+// TraceConsistencyResult holds the outputs of callTracer and callTracerParity
+// for the same transaction, along with their structural differences.
+type TraceConsistencyResult struct {
+	CallTracer interface{}         `json:"callTracer"`
+	Parity     interface{}         `json:"parity"`
+	Diff       []*TraceDiscrepancy `json:"diff"`
+}
+
+// comparedTraceFields lists the fields of the normalized calls which are
+// compared between the tracers.
+var comparedTraceFields = []string{"type", "callType", "from", "to", "value", "gas", "gasUsed", "input", "output", "error"}
+
+// normalizedCall is a call of either tracer, in a common representation.
+type normalizedCall struct {
+	address []int
+	fields  map[string]interface{}
+}
+
+// normalizeTraceValue lowercases hex strings and strips the leading zeroes of
+// hex quantities, so equal values are encoded identically by both tracers.
+func normalizeTraceValue(field string, value interface{}) interface{} {
+	s, ok := value.(string)
+	if !ok || !strings.HasPrefix(s, "0x") {
+		return value
+	}
+	switch field {
+	case "value", "gas", "gasUsed":
+		if number, ok := new(big.Int).SetString(s[2:], 16); ok {
+			return hexutil.EncodeBig(number)
+		}
+	}
+	return strings.ToLower(s)
+}
+
+// normalizeCallTracerCalls flattens the nested callTracer output into calls
+// keyed by their Parity trace address.
+func normalizeCallTracerCalls(call map[string]interface{}, address []int, calls map[string]*normalizedCall) {
+	fields := make(map[string]interface{})
+	for _, field := range []string{"from", "to", "value", "gas", "gasUsed", "input", "output", "error"} {
+		if value, ok := call[field]; ok {
+			fields[field] = normalizeTraceValue(field, value)
+		}
+	}
+	typ, _ := call["type"].(string)
+	switch typ = strings.ToLower(typ); typ {
+	case "create", "create2":
+		fields["type"] = "create"
+	case "selfdestruct":
+		fields["type"] = "suicide"
+		delete(fields, "gas")
+		delete(fields, "gasUsed")
+		delete(fields, "input")
+		delete(fields, "output")
+	default:
+		fields["type"], fields["callType"] = "call", typ
+	}
+	calls[fmt.Sprint(address)] = &normalizedCall{address: address, fields: fields}
+
+	children, _ := call["calls"].([]interface{})
+	for i, child := range children {
+		if child, ok := child.(map[string]interface{}); ok {
+			normalizeCallTracerCalls(child, append(append([]int{}, address...), i), calls)
+		}
+	}
+}
+
+// normalizeParityTraces converts the callTracerParity output into calls keyed by
+// their trace address.
+func normalizeParityTraces(traces []interface{}, calls map[string]*normalizedCall) {
+	for _, trace := range traces {
+		trace, ok := trace.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		action, _ := trace["action"].(map[string]interface{})
+		result, _ := trace["result"].(map[string]interface{})
+
+		fields := map[string]interface{}{"type": trace["type"]}
+		copyField := func(object map[string]interface{}, from, to string) {
+			if value, ok := object[from]; ok {
+				fields[to] = normalizeTraceValue(to, value)
+			}
+		}
+		switch trace["type"] {
+		case "create":
+			copyField(action, "from", "from")
+			copyField(action, "init", "input")
+			copyField(result, "address", "to")
+			copyField(result, "code", "output")
+		case "suicide":
+			copyField(action, "address", "from")
+			copyField(action, "refundAddress", "to")
+			copyField(action, "balance", "value")
+		default:
+			copyField(action, "from", "from")
+			copyField(action, "to", "to")
+			copyField(action, "input", "input")
+			copyField(action, "callType", "callType")
+			copyField(result, "output", "output")
+		}
+		if trace["type"] != "suicide" {
+			copyField(action, "value", "value")
+			copyField(action, "gas", "gas")
+			copyField(result, "gasUsed", "gasUsed")
+		}
+		copyField(trace, "error", "error")
+
+		var address []int
+		if path, ok := trace["traceAddress"].([]interface{}); ok {
+			for _, index := range path {
+				if index, ok := index.(float64); ok {
+					address = append(address, int(index))
+				}
+			}
+		}
+		calls[fmt.Sprint(address)] = &normalizedCall{address: address, fields: fields}
+	}
+}
+
+// diffTraces structurally compares the outputs of callTracer and
+// callTracerParity, returning the differing fields ordered by trace address.
+func diffTraces(callTracer map[string]interface{}, parity []interface{}) []*TraceDiscrepancy {
+	var (
+		calls  = make(map[string]*normalizedCall)
+		traces = make(map[string]*normalizedCall)
+	)
+	normalizeCallTracerCalls(callTracer, nil, calls)
+	normalizeParityTraces(parity, traces)
+
+	// Gather all the trace addresses and order them depth first
+	var addresses [][]int
+	for _, call := range calls {
+		addresses = append(addresses, call.address)
+	}
+	for key, trace := range traces {
+		if _, ok := calls[key]; !ok {
+			addresses = append(addresses, trace.address)
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		a, b := addresses[i], addresses[j]
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	diff := []*TraceDiscrepancy{}
+	for _, address := range addresses {
+		key := fmt.Sprint(address)
+		call, trace := calls[key], traces[key]
+		if address == nil {
+			address = []int{}
+		}
+		if call == nil || trace == nil {
+			diff = append(diff, &TraceDiscrepancy{TraceAddress: address, Field: "call", CallTracer: call != nil, Parity: trace != nil})
+			continue
+		}
+		for _, field := range comparedTraceFields {
+			have, want := trace.fields[field], call.fields[field]
+			if have != want {
+				diff = append(diff, &TraceDiscrepancy{TraceAddress: address, Field: field, CallTracer: want, Parity: have})
+			}
+		}
+	}
+	return diff
+}
+
+// TraceTransactionConsistency traces the given transaction with both callTracer
+// and callTracerParity, returning both outputs along with their structural
+// differences. It's a debugging aid for locating where the Parity conversion
+// diverges when traces are reported to differ from other clients.
+func (api *PrivateDebugAPI) TraceTransactionConsistency(ctx context.Context, hash common.Hash, config *TraceConfig) (*TraceConsistencyResult, error) {
+	trace := func(tracer string, result interface{}) error {
+		conf := TraceConfig{Tracer: &tracer}
+		if config != nil {
+			conf.Timeout, conf.Reexec = config.Timeout, config.Reexec
+		}
+		res, err := traceTransaction(ctx, api.eth, hash, &conf)
+		if err != nil {
+			return fmt.Errorf("%s: %v", tracer, err)
+		}
+		raw, ok := res.(json.RawMessage)
+		if !ok {
+			return fmt.Errorf("%s: unexpected result %T", tracer, res)
+		}
+		return json.Unmarshal(raw, result)
+	}
+	var (
+		callTracer map[string]interface{}
+		parity     []interface{}
+	)
+	if err := trace("callTracer", &callTracer); err != nil {
+		return nil, err
+	}
+	if err := trace("callTracerParity", &parity); err != nil {
+		return nil, err
+	}
+	return &TraceConsistencyResult{
+		CallTracer: callTracer,
+		Parity:     parity,
+		Diff:       diffTraces(callTracer, parity),
+	}, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// Tests that the tracer consistency check reports no differences for a plain
+// value transfer, traced identically by both tracers.
+func TestTraceTransactionConsistency(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(1))
+	api := NewPrivateDebugAPI(eth)
+
+	hash := eth.blockchain.GetBlockByNumber(1).Transactions()[0].Hash()
+	res, err := api.TraceTransactionConsistency(context.Background(), hash, nil)
+	if err != nil {
+		t.Fatalf("failed to check trace consistency: %v", err)
+	}
+	if res.CallTracer == nil || res.Parity == nil {
+		t.Fatalf("missing tracer outputs: %+v", res)
+	}
+	if len(res.Diff) != 0 {
+		t.Errorf("unexpected differences: %v", res.Diff)
+	}
+}
+
+// Tests that diverging fields and calls missing from either tracer's output are
+// reported, ordered by trace address.
+func TestDiffTraces(t *testing.T) {
+	var (
+		callTracer map[string]interface{}
+		parity     []interface{}
+	)
+	if err := json.Unmarshal([]byte(`{
+		"type": "CALL", "from": "0xAA", "to": "0xbb", "value": "0x0", "gas": "0x100", "gasUsed": "0x10", "input": "0x", "output": "0x",
+		"calls": [
+			{"type": "STATICCALL", "from": "0xbb", "to": "0xcc", "gas": "0x80", "gasUsed": "0x8", "input": "0x01", "output": "0x"},
+			{"type": "SELFDESTRUCT", "from": "0xbb", "to": "0xdd", "value": "0x5"}
+		]
+	}`), &callTracer); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`[
+		{"type": "call", "action": {"callType": "call", "from": "0xaa", "to": "0xbb", "value": "0x00", "gas": "0x100", "input": "0x"}, "result": {"gasUsed": "0x10", "output": "0x"}, "traceAddress": []},
+		{"type": "call", "action": {"callType": "staticcall", "from": "0xbb", "to": "0xcc", "gas": "0x80", "input": "0x01"}, "error": "Reverted", "traceAddress": [0]},
+		{"type": "call", "action": {"callType": "call", "from": "0xcc", "to": "0xee", "value": "0x0", "gas": "0x8", "input": "0x"}, "result": {"gasUsed": "0x0", "output": "0x"}, "traceAddress": [0, 0]}
+	]`), &parity); err != nil {
+		t.Fatal(err)
+	}
+	want := []*TraceDiscrepancy{
+		{TraceAddress: []int{0}, Field: "gasUsed", CallTracer: "0x8", Parity: nil},
+		{TraceAddress: []int{0}, Field: "output", CallTracer: "0x", Parity: nil},
+		{TraceAddress: []int{0}, Field: "error", CallTracer: nil, Parity: "Reverted"},
+		{TraceAddress: []int{0, 0}, Field: "call", CallTracer: false, Parity: true},
+		{TraceAddress: []int{1}, Field: "call", CallTracer: true, Parity: false},
+	}
+	if have := diffTraces(callTracer, parity); !reflect.DeepEqual(have, want) {
+		haveJSON, _ := json.Marshal(have)
+		wantJSON, _ := json.Marshal(want)
+		t.Errorf("diff mismatch:\nhave %s\nwant %s", haveJSON, wantJSON)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// createdContractFields are the Parity trace fields needed to find the contracts
+// created in a block.
+var createdContractFields = []string{"type", "action.from", "action.creationMethod", "result.address", "error", "traceAddress", "transactionHash", "transactionPosition"}
+
+// TraceCreatedContract describes a contract created in a block.
+type TraceCreatedContract struct {
+	Address             common.Address `json:"address"`
+	Creator             common.Address `json:"creator"`
+	CreationMethod      string         `json:"creationMethod"`
+	TransactionHash     common.Hash    `json:"transactionHash"`
+	TransactionPosition uint64         `json:"transactionPosition"`
+	TraceAddress        []int          `json:"traceAddress"`
+}
+
+// CreatedContracts traces the given block and returns the contracts created by
+// its transactions, either directly or by CREATE/CREATE2, in execution order.
+// Creations which failed or were reverted along with one of their callers are
+// left out, as their contracts don't exist.
+func (api *PrivateTraceAPI) CreatedContracts(ctx context.Context, number rpc.BlockNumber) ([]*TraceCreatedContract, error) {
+	traces, err := api.Block(ctx, number, &TraceConfig{Fields: createdContractFields})
+	if err != nil {
+		return nil, err
+	}
+	blob, err := json.Marshal(traces)
+	if err != nil {
+		return nil, err
+	}
+	var decoded []struct {
+		Type   string `json:"type"`
+		Action struct {
+			From           common.Address `json:"from"`
+			CreationMethod string         `json:"creationMethod"`
+		} `json:"action"`
+		Result struct {
+			Address *common.Address `json:"address"`
+		} `json:"result"`
+		Error               string       `json:"error"`
+		TraceAddress        []int        `json:"traceAddress"`
+		TransactionHash     *common.Hash `json:"transactionHash"`
+		TransactionPosition *uint64      `json:"transactionPosition"`
+	}
+	if err := json.Unmarshal(blob, &decoded); err != nil {
+		return nil, err
+	}
+	// The traces of a transaction are in depth first order, so any failed caller
+	// of a creation precedes it
+	var (
+		contracts = []*TraceCreatedContract{}
+		failed    = make(map[string]bool)
+	)
+	for _, trace := range decoded {
+		if trace.TransactionHash == nil || trace.TransactionPosition == nil {
+			continue
+		}
+		reverted := trace.Error != ""
+		for depth := 0; depth < len(trace.TraceAddress) && !reverted; depth++ {
+			reverted = failed[fmt.Sprintf("%x:%v", *trace.TransactionHash, trace.TraceAddress[:depth])]
+		}
+		if reverted {
+			failed[fmt.Sprintf("%x:%v", *trace.TransactionHash, trace.TraceAddress)] = true
+			continue
+		}
+		if trace.Type != "create" || trace.Result.Address == nil {
+			continue
+		}
+		contracts = append(contracts, &TraceCreatedContract{
+			Address:             *trace.Result.Address,
+			Creator:             trace.Action.From,
+			CreationMethod:      trace.Action.CreationMethod,
+			TransactionHash:     *trace.TransactionHash,
+			TransactionPosition: *trace.TransactionPosition,
+			TraceAddress:        trace.TraceAddress,
+		})
+	}
+	return contracts, nil
+}
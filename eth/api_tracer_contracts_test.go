@@ -0,0 +1,97 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Tests that the contracts created in a block are reported with their creators,
+// leaving out failed and reverted creations.
+func TestTraceCreatedContracts(t *testing.T) {
+	var (
+		signer  = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		factory = crypto.CreateAddress(testBank, 0)
+	)
+	// A contract creating an empty contract by both CREATE and CREATE2, then
+	// reverting if called with any data
+	factoryCode := []byte{
+		0x60, 0x00, 0x80, 0x80, 0xf0, 0x50, // POP(CREATE(0, 0, 0))
+		0x60, 0x00, 0x80, 0x80, 0x80, 0xf5, 0x50, // POP(CREATE2(0, 0, 0, 0))
+		0x36, 0x15, 0x60, 0x16, 0x57, // JUMPI(22, ISZERO(CALLDATASIZE))
+		0x60, 0x00, 0x80, 0xfd, // REVERT(0, 0)
+		0x5b, 0x00, // JUMPDEST, STOP
+	}
+	eth := newTestTraceBackend(t, 2, func(i int, block *core.BlockGen) {
+		var txs []*types.Transaction
+		switch i {
+		case 0:
+			initcode := append([]byte{0x60, byte(len(factoryCode)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(factoryCode)), 0x60, 0x00, 0xf3}, factoryCode...)
+			txs = append(txs, types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 200000, big.NewInt(1), initcode))
+		case 1:
+			nonce := block.TxNonce(testBank)
+			txs = append(txs,
+				types.NewTransaction(nonce, factory, new(big.Int), 200000, big.NewInt(1), nil),
+				types.NewTransaction(nonce+1, factory, new(big.Int), 200000, big.NewInt(1), []byte{0x01}),
+				types.NewContractCreation(nonce+2, new(big.Int), 100000, big.NewInt(1), []byte{0x60, 0x00, 0x80, 0xfd}),
+			)
+		}
+		for _, tx := range txs {
+			tx, _ = types.SignTx(tx, signer, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	var (
+		deploy = eth.blockchain.GetBlockByNumber(1).Transactions()[0].Hash()
+		call   = eth.blockchain.GetBlockByNumber(2).Transactions()[0].Hash()
+	)
+	tests := []struct {
+		number rpc.BlockNumber
+		want   []*TraceCreatedContract
+	}{
+		{1, []*TraceCreatedContract{
+			{Address: factory, Creator: testBank, CreationMethod: "create", TransactionHash: deploy, TransactionPosition: 0, TraceAddress: []int{}},
+		}},
+		{2, []*TraceCreatedContract{
+			{Address: crypto.CreateAddress(factory, 1), Creator: factory, CreationMethod: "create", TransactionHash: call, TransactionPosition: 0, TraceAddress: []int{0}},
+			{Address: crypto.CreateAddress2(factory, [32]byte{}, crypto.Keccak256(nil)), Creator: factory, CreationMethod: "create2", TransactionHash: call, TransactionPosition: 0, TraceAddress: []int{1}},
+		}},
+	}
+	for _, tt := range tests {
+		have, err := api.CreatedContracts(context.Background(), tt.number)
+		if err != nil {
+			t.Fatalf("block #%d: failed to find created contracts: %v", tt.number, err)
+		}
+		if !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("block #%d: created contracts mismatch", tt.number)
+			for _, contract := range have {
+				t.Logf("have %+v", contract)
+			}
+		}
+	}
+}
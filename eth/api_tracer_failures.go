@@ -0,0 +1,60 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// filterFailedTransactions retains the Parity formatted traces of the failed
+// transactions, i.e. those whose root call reverted or errored, along with the
+// successful ones with failed internal calls if requested. The traces of the
+// retained transactions are kept whole, rewards are dropped. Typed traces are
+// converted to their generic JSON form.
+func filterFailedTransactions(traces []interface{}, internal bool) ([]interface{}, error) {
+	var (
+		objects = make([]map[string]interface{}, len(traces))
+		failed  = make(map[string]bool)
+	)
+	txKey := func(trace map[string]interface{}) string {
+		return fmt.Sprintf("%v:%v", trace["transactionHash"], trace["transactionPosition"])
+	}
+	for i, trace := range traces {
+		object, ok := trace.(map[string]interface{})
+		if !ok {
+			blob, err := json.Marshal(trace)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(blob, &object); err != nil {
+				return nil, err
+			}
+		}
+		objects[i] = object
+		if _, errored := object["error"]; errored && (internal || isRootTransactionTrace(object)) {
+			failed[txKey(object)] = true
+		}
+	}
+	results := traces[:0]
+	for _, object := range objects {
+		if object["type"] != "reward" && failed[txKey(object)] {
+			results = append(results, object)
+		}
+	}
+	return results, nil
+}
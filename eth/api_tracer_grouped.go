@@ -0,0 +1,107 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// traceGroupRewards is the key grouping the traces not belonging to any
+// transaction, i.e. the block and uncle rewards.
+const traceGroupRewards = "rewards"
+
+// validateGroupedTraceConfig checks that the trace config retains the transaction
+// hashes the Parity traces are grouped under.
+func validateGroupedTraceConfig(config *TraceConfig) error {
+	if config == nil {
+		return nil
+	}
+	if config.Format != traceFormatNested {
+		return errors.New("grouped traces require the nested trace format")
+	}
+	if config.IncludeBlockSummary {
+		return errors.New("includeBlockSummary is not supported by grouped traces")
+	}
+	if len(config.Fields) > 0 {
+		for _, field := range config.Fields {
+			if field == "transactionHash" {
+				return nil
+			}
+		}
+		return errors.New("grouped traces require the transactionHash field")
+	}
+	return nil
+}
+
+// groupTracesByTransaction groups the given Parity formatted traces under the
+// hex encoded hash of their transaction, in their original order, and the ones
+// of rewards under traceGroupRewards.
+func groupTracesByTransaction(traces []interface{}) (map[string][]interface{}, error) {
+	groups := make(map[string][]interface{})
+	for _, trace := range traces {
+		blob, err := json.Marshal(trace)
+		if err != nil {
+			return nil, err
+		}
+		var decoded struct {
+			TransactionHash *common.Hash `json:"transactionHash"`
+		}
+		if err := json.Unmarshal(blob, &decoded); err != nil {
+			return nil, err
+		}
+		key := traceGroupRewards
+		if decoded.TransactionHash != nil {
+			key = decoded.TransactionHash.Hex()
+		}
+		groups[key] = append(groups[key], trace)
+	}
+	return groups, nil
+}
+
+// BlockByTransaction returns the Parity traces of the given block, as trace_block
+// would, grouped under the hash of their transaction and the reward traces under
+// the "rewards" key, sparing clients the regrouping of the flat list.
+func (api *PrivateTraceAPI) BlockByTransaction(ctx context.Context, number rpc.BlockNumber, config *TraceConfig) (map[string][]interface{}, error) {
+	if err := validateGroupedTraceConfig(config); err != nil {
+		return nil, err
+	}
+	traces, err := api.Block(ctx, number, config)
+	if err != nil {
+		return nil, err
+	}
+	return groupTracesByTransaction(traces)
+}
+
+// ReplayBlockTransactionsByHash replays all the transactions of a block like
+// trace_replayBlockTransactions, returning their results keyed by the hash of
+// their transaction.
+func (api *PrivateTraceAPI) ReplayBlockTransactionsByHash(ctx context.Context, number rpc.BlockNumber, traceTypes []string) (map[string]*TraceReplayResult, error) {
+	results, err := api.ReplayBlockTransactions(ctx, number, traceTypes)
+	if err != nil {
+		return nil, err
+	}
+	grouped := make(map[string]*TraceReplayResult, len(results))
+	for _, result := range results {
+		grouped[result.TransactionHash.Hex()] = result
+	}
+	return grouped, nil
+}
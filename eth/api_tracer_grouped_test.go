@@ -0,0 +1,82 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Tests that trace_blockByTransaction groups the traces of trace_block under the
+// hash of their transaction, and the rewards under a dedicated key.
+func TestTraceBlockByTransaction(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(3))
+	api := NewPrivateTraceAPI(eth)
+	block := eth.blockchain.GetBlockByNumber(1)
+
+	// Project the traces onto fields stable across runs, i.e. without the timings
+	fields := []string{"type", "action", "result", "traceAddress", "transactionHash", "transactionPosition"}
+	flat, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{Fields: fields})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	grouped, err := api.BlockByTransaction(context.Background(), rpc.BlockNumber(1), &TraceConfig{Fields: fields})
+	if err != nil {
+		t.Fatalf("failed to trace block by transaction: %v", err)
+	}
+	if len(grouped) != len(block.Transactions())+1 {
+		t.Fatalf("group count mismatch: have %d, want %d", len(grouped), len(block.Transactions())+1)
+	}
+	// The groups hold the flat traces in their original order
+	var regrouped []interface{}
+	for _, tx := range block.Transactions() {
+		regrouped = append(regrouped, grouped[tx.Hash().Hex()]...)
+	}
+	regrouped = append(regrouped, grouped[traceGroupRewards]...)
+
+	have, _ := json.Marshal(regrouped)
+	want, _ := json.Marshal(flat)
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("grouped traces mismatch:\nhave %s\nwant %s", have, want)
+	}
+	if rewards := grouped[traceGroupRewards]; len(rewards) != 1 {
+		t.Errorf("reward trace count mismatch: have %d, want 1", len(rewards))
+	}
+	// Configs dropping the transaction hashes are rejected
+	for i, config := range []*TraceConfig{{Format: traceFormatRows}, {Fields: []string{"type"}}, {IncludeBlockSummary: true}} {
+		if _, err := api.BlockByTransaction(context.Background(), rpc.BlockNumber(1), config); err == nil {
+			t.Errorf("config %d: expected error", i)
+		}
+	}
+	// Replays are keyed by transaction hash too
+	replays, err := api.ReplayBlockTransactionsByHash(context.Background(), rpc.BlockNumber(1), []string{"trace"})
+	if err != nil {
+		t.Fatalf("failed to replay block by hash: %v", err)
+	}
+	if len(replays) != len(block.Transactions()) {
+		t.Fatalf("replay count mismatch: have %d, want %d", len(replays), len(block.Transactions()))
+	}
+	for _, tx := range block.Transactions() {
+		if replay := replays[tx.Hash().Hex()]; replay == nil || *replay.TransactionHash != tx.Hash() || len(replay.Trace) != 1 {
+			t.Errorf("transaction %x: replay mismatch: %+v", tx.Hash(), replay)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// traceIndexer traces the blocks imported into the canonical chain, indexing
+// the addresses of their Parity traces so that address filtered trace_filter
+// calls can skip the blocks not involving the filtered addresses.
+//
+// The index covers the canonical blocks from its tail up to its head, blocks
+// reorged out of the canonical chain are rolled back before indexing the new
+// ones. Blocks imported before the indexer was first started are not indexed,
+// and with a retention limit, the blocks falling behind it are pruned.
+type traceIndexer struct {
+	eth *Ethereum
+	api *PrivateTraceAPI
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// newTraceIndexer creates a trace indexer for the given node, started by start.
+func newTraceIndexer(eth *Ethereum) *traceIndexer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &traceIndexer{
+		eth:    eth,
+		api:    NewPrivateTraceAPI(eth),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// start begins indexing the blocks imported from now on, resuming from the last
+// indexed block if the index already exists.
+func (ix *traceIndexer) start() {
+	if rawdb.ReadTraceIndexHead(ix.eth.chainDb) == (common.Hash{}) {
+		ix.restart()
+	}
+	heads := make(chan core.ChainHeadEvent, 16)
+	sub := ix.eth.blockchain.SubscribeChainHeadEvent(heads)
+
+	ix.wg.Add(1)
+	go func() {
+		defer ix.wg.Done()
+		defer sub.Unsubscribe()
+
+		ix.update()
+		for {
+			select {
+			case <-heads:
+				ix.update()
+			case <-sub.Err():
+				return
+			case <-ix.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// stop terminates the indexing, waiting for the block being indexed, if any.
+func (ix *traceIndexer) stop() {
+	ix.cancel()
+	ix.wg.Wait()
+}
+
+// restart drops the coverage of the index, resuming indexing with the blocks
+// following the current head. The entries of previously indexed blocks are left
+// in place, but are not relied on anymore.
+func (ix *traceIndexer) restart() {
+	head := ix.eth.blockchain.CurrentBlock()
+
+	batch := ix.eth.chainDb.NewBatch()
+	rawdb.WriteTraceIndexTail(batch, head.NumberU64()+1)
+	rawdb.WriteTraceIndexHead(batch, head.Hash())
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to restart the trace index", "err", err)
+	}
+}
+
+// update rolls back the indexed blocks reorged out of the canonical chain and
+// indexes the canonical blocks up to the current head.
+func (ix *traceIndexer) update() {
+	defer ix.prune()
+
+	var (
+		db    = ix.eth.chainDb
+		chain = ix.eth.blockchain
+	)
+	header := chain.GetHeaderByHash(rawdb.ReadTraceIndexHead(db))
+	if header == nil {
+		log.Warn("Trace index head not found, restarting the index")
+		ix.restart()
+		return
+	}
+	// Roll back the indexed blocks not on the canonical chain anymore
+	var (
+		batch  = db.NewBatch()
+		rolled int
+	)
+	for header.Hash() != chain.GetCanonicalHash(header.Number.Uint64()) {
+		number, hash := header.Number.Uint64(), header.Hash()
+		if addresses, ok := rawdb.ReadTraceIndexAddresses(db, number, hash); ok {
+			rawdb.DeleteTraceIndexEntries(batch, number, hash, addresses)
+		}
+		rolled++
+		if header = chain.GetHeader(header.ParentHash, number-1); header == nil {
+			log.Warn("Trace index ancestor not found, restarting the index", "number", number-1)
+			ix.restart()
+			return
+		}
+	}
+	if rolled > 0 {
+		rawdb.WriteTraceIndexHead(batch, header.Hash())
+		if tail := rawdb.ReadTraceIndexTail(db); tail == nil || *tail > header.Number.Uint64()+1 {
+			rawdb.WriteTraceIndexTail(batch, header.Number.Uint64()+1)
+		}
+		if err := batch.Write(); err != nil {
+			log.Crit("Failed to roll back the trace index", "err", err)
+		}
+		log.Info("Rolled back reorged trace index entries", "blocks", rolled, "number", header.Number, "hash", header.Hash())
+	}
+	// Index the new canonical blocks in order
+	for number := header.Number.Uint64() + 1; number <= chain.CurrentBlock().NumberU64(); number++ {
+		if ix.ctx.Err() != nil {
+			return
+		}
+		block := chain.GetBlockByNumber(number)
+		if block == nil || block.ParentHash() != header.Hash() {
+			return // Reorged meanwhile, the head event will resume from the ancestor
+		}
+		indexed, err := ix.index(block)
+		if err != nil {
+			if ix.ctx.Err() == nil {
+				log.Warn("Failed to index block traces, restarting the index", "number", number, "hash", block.Hash(), "err", err)
+				ix.restart()
+			}
+			return
+		}
+		if !indexed {
+			return
+		}
+		header = block.Header()
+	}
+}
+
+// prune removes the indexed blocks falling out of the retention window behind the
+// head of the index, moving its tail past them, and reports the blocks covered.
+func (ix *traceIndexer) prune() {
+	db := ix.eth.chainDb
+
+	tail := rawdb.ReadTraceIndexTail(db)
+	head := ix.eth.blockchain.GetHeaderByHash(rawdb.ReadTraceIndexHead(db))
+	if tail == nil || head == nil {
+		traceIndexBlocksGauge.Update(0)
+		return
+	}
+	number := head.Number.Uint64()
+	if retention := ix.eth.config.Trace.IndexRetention; retention > 0 && number+1 > *tail+retention {
+		// Move the tail first so that the entries being pruned aren't relied on
+		limit := number + 1 - retention
+		rawdb.WriteTraceIndexTail(db, limit)
+		*tail = limit
+
+		if pruned := rawdb.PruneTraceIndex(db, limit); pruned > 0 {
+			traceIndexPruneMeter.Mark(int64(pruned))
+			log.Debug("Pruned trace index entries", "blocks", pruned, "tail", limit)
+		}
+	}
+	if number+1 > *tail {
+		traceIndexBlocksGauge.Update(int64(number + 1 - *tail))
+	} else {
+		traceIndexBlocksGauge.Update(0)
+	}
+}
+
+// index traces the given canonical block and stores the addresses of its traces,
+// moving the head of the index onto it. Blocks reorged out of the canonical chain
+// while being traced are not indexed.
+func (ix *traceIndexer) index(block *types.Block) (bool, error) {
+	traces, err := ix.api.blockTraces(ix.ctx, block, setTraceConfigDefaultTracer(&TraceConfig{internal: true}))
+	if err != nil {
+		return false, err
+	}
+	if ix.eth.blockchain.GetCanonicalHash(block.NumberU64()) != block.Hash() {
+		return false, nil
+	}
+	addresses, err := blockTraceAddresses(traces)
+	if err != nil {
+		return false, err
+	}
+	batch := ix.eth.chainDb.NewBatch()
+	rawdb.WriteTraceIndexEntries(batch, block.NumberU64(), block.Hash(), addresses)
+	rawdb.WriteTraceIndexHead(batch, block.Hash())
+	return true, batch.Write()
+}
+
+// blockTraceAddresses returns the distinct senders and recipients of the given
+// Parity formatted traces, as matched by trace_filter, in ascending order.
+func blockTraceAddresses(traces []interface{}) ([]common.Address, error) {
+	seen := make(map[common.Address]struct{})
+	for _, trace := range traces {
+		object, ok := trace.(map[string]interface{})
+		if !ok {
+			blob, err := json.Marshal(trace)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(blob, &object); err != nil {
+				return nil, err
+			}
+		}
+		from, to := traceAddresses(object)
+		for _, address := range []string{from, to} {
+			if address != "" {
+				seen[common.HexToAddress(address)] = struct{}{}
+			}
+		}
+	}
+	addresses := make([]common.Address, 0, len(seen))
+	for address := range seen {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+	return addresses, nil
+}
+
+// indexedTraceBlocks returns a predicate reporting whether the canonical block
+// of the given number may hold traces matching the address filter. Blocks in the
+// requested range covered by the trace index are ruled out unless the index
+// lists the filtered addresses for them, all others may match.
+//
+// The index only holds the addresses of the default traces, so it isn't relied
+// on if the trace config reports more of them (e.g. the precompile calls).
+func indexedTraceBlocks(eth *Ethereum, filter *TraceFilterArgs, config *TraceConfig, start, end uint64) func(uint64) bool {
+	mayMatch := func(uint64) bool { return true }
+	if !eth.config.Trace.Index || (filter.FromAddress == nil && filter.ToAddress == nil) {
+		return mayMatch
+	}
+	if config != nil && config.IncludePrecompiles {
+		return mayMatch
+	}
+	// Only rely on the index if its head is canonical, otherwise a reorg is
+	// being rolled back and some canonical blocks may be missing
+	var (
+		db    = eth.chainDb
+		chain = eth.blockchain
+	)
+	tail := rawdb.ReadTraceIndexTail(db)
+	head := chain.GetHeaderByHash(rawdb.ReadTraceIndexHead(db))
+	if tail == nil || head == nil || chain.GetCanonicalHash(head.Number.Uint64()) != head.Hash() {
+		return mayMatch
+	}
+	first, last := start, end
+	if first < *tail {
+		first = *tail
+	}
+	if last > head.Number.Uint64() {
+		last = head.Number.Uint64()
+	}
+	if first > last {
+		return mayMatch
+	}
+	// A matching trace has to involve both filtered addresses
+	var candidates map[uint64]bool
+	for _, address := range []*common.Address{filter.FromAddress, filter.ToAddress} {
+		if address == nil {
+			continue
+		}
+		matches := make(map[uint64]bool)
+		for number, hashes := range rawdb.ReadTraceIndexBlocks(db, *address, first, last) {
+			if candidates != nil && !candidates[number] {
+				continue
+			}
+			canonical := chain.GetCanonicalHash(number)
+			for _, hash := range hashes {
+				if hash == canonical {
+					matches[number] = true
+				}
+			}
+		}
+		candidates = matches
+	}
+	return func(number uint64) bool {
+		return number < first || number > last || candidates[number]
+	}
+}
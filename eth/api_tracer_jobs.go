@@ -0,0 +1,122 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// maxTraceCancelTokenLength is the maximum length of the client chosen tokens
+// in-flight traces are registered under.
+const maxTraceCancelTokenLength = 128
+
+// errTraceCancelled is returned by the traces aborted via trace_cancel.
+var errTraceCancelled = errors.New("trace cancelled")
+
+// errCancelTokenUnsupported is returned when a cancel token is passed to the
+// trace methods not registering their traces, which trace_cancel couldn't abort.
+var errCancelTokenUnsupported = errors.New("cancelToken is only supported by trace_block and trace_filter")
+
+// traceJobs tracks the in-flight traces registered under a cancel token, so that
+// clients which can't abort a request themselves (e.g. over HTTP) can do so via
+// trace_cancel. Tokens are node-wide, as every HTTP request arrives on its own
+// connection.
+type traceJobs struct {
+	active map[string]*traceJob
+	lock   sync.Mutex
+}
+
+// traceJob is an in-flight trace registered under a cancel token.
+type traceJob struct {
+	cancel    context.CancelFunc
+	cancelled bool
+}
+
+// newTraceJobs creates an empty registry of cancellable traces.
+func newTraceJobs() *traceJobs {
+	return &traceJobs{
+		active: make(map[string]*traceJob),
+	}
+}
+
+// run executes the given trace under the token, passing it a context which is
+// cancelled once the token is passed to cancel. A cancelled trace fails with
+// errTraceCancelled. An empty token registers nothing, others must be unique
+// among the in-flight traces.
+func (j *traceJobs) run(ctx context.Context, token string, trace func(ctx context.Context) error) error {
+	if token == "" {
+		return trace(ctx)
+	}
+	if j == nil {
+		return errors.New("trace cancellation is not available")
+	}
+	if len(token) > maxTraceCancelTokenLength {
+		return fmt.Errorf("cancel token of %d bytes exceeds the limit of %d", len(token), maxTraceCancelTokenLength)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	job := &traceJob{cancel: cancel}
+	j.lock.Lock()
+	if _, ok := j.active[token]; ok {
+		j.lock.Unlock()
+		return fmt.Errorf("cancel token %q already in use", token)
+	}
+	j.active[token] = job
+	j.lock.Unlock()
+
+	err := trace(ctx)
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.active[token] == job {
+		delete(j.active, token)
+	}
+	if job.cancelled {
+		return errTraceCancelled
+	}
+	return err
+}
+
+// cancel aborts the in-flight trace registered under the given token, reporting
+// whether there was one.
+func (j *traceJobs) cancel(token string) bool {
+	if j == nil {
+		return false
+	}
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	job, ok := j.active[token]
+	if ok {
+		delete(j.active, token)
+		job.cancelled = true
+		job.cancel()
+	}
+	return ok
+}
+
+// Cancel aborts the in-flight trace_filter or trace_block request registered
+// under the given cancelToken, which then fails. It reports whether such a
+// request was running.
+func (api *PrivateTraceAPI) Cancel(ctx context.Context, token string) bool {
+	return api.eth.traceJobs.cancel(token)
+}
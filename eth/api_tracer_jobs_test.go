@@ -0,0 +1,129 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Tests that an in-flight trace_filter registered under a cancel token is aborted
+// by trace_cancel, releasing the token for reuse.
+func TestTraceFilterCancel(t *testing.T) {
+	eth := newTestTraceBackend(t, 4, testTransferBlocks(1))
+	eth.traceJobs = newTraceJobs()
+	api := NewPrivateTraceCompatAPI(eth)
+
+	// Hold the only trace worker, so that the filter blocks until cancelled
+	eth.tracePool = newTracePool(1, time.Minute)
+	if err := eth.tracePool.acquire(context.Background()); err != nil {
+		t.Fatalf("failed to acquire trace worker: %v", err)
+	}
+	config := &TraceConfig{CancelToken: "job"}
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := api.Filter(context.Background(), TraceFilterArgs{FromBlock: 1, ToBlock: 4}, config)
+		errc <- err
+	}()
+	for !api.trace.Cancel(context.Background(), "job") {
+		select {
+		case err := <-errc:
+			t.Fatalf("filter returned before being cancelled: %v", err)
+		case <-time.After(time.Millisecond):
+		}
+	}
+	select {
+	case err := <-errc:
+		if err != errTraceCancelled {
+			t.Fatalf("cancelled filter error mismatch: have %v, want %v", err, errTraceCancelled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("filter not aborted by cancellation")
+	}
+	if api.trace.Cancel(context.Background(), "job") {
+		t.Errorf("cancelled token still registered")
+	}
+	eth.tracePool.release()
+
+	// Tokens can be reused once released, but not while in use
+	if traces, err := api.Filter(context.Background(), TraceFilterArgs{FromBlock: 1, ToBlock: 4}, config); err != nil || len(traces) != 8 {
+		t.Fatalf("filter mismatch: have %d traces (%v), want %d", len(traces), err, 8)
+	}
+	err := eth.traceJobs.run(context.Background(), "job", func(ctx context.Context) error {
+		_, err := api.trace.Block(ctx, 1, config)
+		return err
+	})
+	if err == nil {
+		t.Errorf("expected trace with a token in use to fail")
+	}
+}
+
+// Tests that the trace methods not registering their traces reject a cancel
+// token, which trace_cancel couldn't abort them with.
+func TestTraceCancelTokenUnsupported(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(1))
+	api := NewPrivateTraceAPI(eth)
+
+	var (
+		config = &TraceConfig{CancelToken: "job"}
+		block  = eth.blockchain.GetBlockByNumber(1)
+		head   = rpc.BlockNumberOrHashWithNumber(1)
+	)
+	if _, err := api.Transaction(context.Background(), block.Transactions()[0].Hash(), config); err != errCancelTokenUnsupported {
+		t.Errorf("trace_transaction error mismatch: have %v, want %v", err, errCancelTokenUnsupported)
+	}
+	if _, err := api.Call(context.Background(), ethapi.CallArgs{}, head, config); err != errCancelTokenUnsupported {
+		t.Errorf("trace_call error mismatch: have %v, want %v", err, errCancelTokenUnsupported)
+	}
+	if _, err := api.CallMany(context.Background(), []ethapi.CallArgs{{}}, head, config); err != errCancelTokenUnsupported {
+		t.Errorf("trace_callMany error mismatch: have %v, want %v", err, errCancelTokenUnsupported)
+	}
+	if _, err := api.Since(context.Background(), TraceSinceArgs{}, config); err != errCancelTokenUnsupported {
+		t.Errorf("trace_since error mismatch: have %v, want %v", err, errCancelTokenUnsupported)
+	}
+	if _, err := api.BlockWithout(context.Background(), 1, 0, config); err != errCancelTokenUnsupported {
+		t.Errorf("trace_blockWithout error mismatch: have %v, want %v", err, errCancelTokenUnsupported)
+	}
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("trace", api); err != nil {
+		t.Fatalf("failed to register trace API: %v", err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	subscriptions := map[string][]interface{}{
+		"filter":         {TraceFilterArgs{FromBlock: 1, ToBlock: 1}, config},
+		"blockRange":     {"0x1", "0x1", config},
+		"newBlockTraces": {config},
+	}
+	for name, args := range subscriptions {
+		_, err := client.Subscribe(context.Background(), "trace", make(chan interface{}), append([]interface{}{name}, args...)...)
+		if err == nil || err.Error() != errCancelTokenUnsupported.Error() {
+			t.Errorf("trace_subscribe(%q) error mismatch: have %v, want %v", name, err, errCancelTokenUnsupported)
+		}
+	}
+	// None of the rejected requests registered the token
+	if api.Cancel(context.Background(), "job") {
+		t.Errorf("rejected request registered its token")
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Contains the metrics collected by the trace API.
+
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	traceBlockReqMeter  = metrics.NewRegisteredMeter("eth/trace/block/requests", nil)
+	traceBlockFailMeter = metrics.NewRegisteredMeter("eth/trace/block/failures", nil)
+	traceBlockTimer     = metrics.NewRegisteredTimer("eth/trace/block/duration", nil)
+
+	traceFilterReqMeter  = metrics.NewRegisteredMeter("eth/trace/filter/requests", nil)
+	traceFilterFailMeter = metrics.NewRegisteredMeter("eth/trace/filter/failures", nil)
+
+	traceTransactionReqMeter  = metrics.NewRegisteredMeter("eth/trace/transaction/requests", nil)
+	traceTransactionFailMeter = metrics.NewRegisteredMeter("eth/trace/transaction/failures", nil)
+	traceTransactionTimer     = metrics.NewRegisteredTimer("eth/trace/transaction/duration", nil)
+
+	traceChainFailMeter = metrics.NewRegisteredMeter("eth/trace/chain/failures", nil)
+	traceChainTimer     = metrics.NewRegisteredTimer("eth/trace/chain/duration", nil)
+
+	traceBlocksMeter  = metrics.NewRegisteredMeter("eth/trace/blocks", nil)
+	traceTxFailMeter  = metrics.NewRegisteredMeter("eth/trace/txs/failures", nil)
+	traceRunningGauge = metrics.NewRegisteredGauge("eth/trace/running", nil)
+
+	traceIndexBlocksGauge = metrics.NewRegisteredGauge("eth/trace/index/blocks", nil)
+	traceIndexPruneMeter  = metrics.NewRegisteredMeter("eth/trace/index/pruned", nil)
+)
@@ -17,15 +17,24 @@
 package eth
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -35,8 +44,16 @@ type TraceFilterArgs struct {
 	ToBlock     hexutil.Uint64  `json:"toBlock,omitempty"`     // Trace utill this end block
 	FromAddress *common.Address `json:"fromAddress,omitempty"` // Sent from these addresses
 	ToAddress   *common.Address `json:"toAddress,omitempty"`   // Sent to these addresses
+	MinValue    *hexutil.Big    `json:"minValue,omitempty"`    // Transferring at least this amount of wei
 	After       uint64          `json:"after,omitempty"`       // The offset trace number
 	Count       uint64          `json:"count,omitempty"`       // Integer number of traces to display in a batch
+	SortByValue bool            `json:"sortByValue,omitempty"` // Orders the traces by descending value before paging (not streamed)
+}
+
+// filtersTraces reports whether the arguments restrict the traces returned for
+// the blocks of the range.
+func (args *TraceFilterArgs) filtersTraces() bool {
+	return args.FromAddress != nil || args.ToAddress != nil || (args.MinValue != nil && args.MinValue.ToInt().Sign() > 0)
 }
 
 // ParityTrace A trace in the desired format (Parity/OpenEtherum) See: https://Parity.github.io/wiki/JSONRPC-trace-module
@@ -51,13 +68,103 @@ type ParityTrace struct {
 	TransactionHash     *common.Hash      `json:"transactionHash"`
 	TransactionPosition *uint64           `json:"transactionPosition"`
 	Type                string            `json:"type"`
+	Fork                string            `json:"fork,omitempty"`
+	ChainID             *hexutil.Big      `json:"chainId,omitempty"`
 }
 
 // TraceRewardAction An Parity formatted trace reward action
 type TraceRewardAction struct {
-	Value      *hexutil.Big    `json:"value,omitempty"`
-	Author     *common.Address `json:"author,omitempty"`
-	RewardType string          `json:"rewardType,omitempty"`
+	Value       *hexutil.Big    `json:"value,omitempty"`
+	Author      *common.Address `json:"author,omitempty"`
+	RewardType  string          `json:"rewardType,omitempty"`
+	UncleNumber *hexutil.Uint64 `json:"uncleNumber,omitempty"` // Uncle block number, if uncle details were requested
+	UncleDepth  *hexutil.Uint64 `json:"uncleDepth,omitempty"`  // Distance from the uncle to the including block, if requested
+}
+
+// TraceBlockSummary describes the traced block at the end of a trace_block
+// result, allowing the traces to be reconciled against the block.
+type TraceBlockSummary struct {
+	Type             string         `json:"type"` // Always "blockSummary", distinguishing it from the traces
+	BlockNumber      uint64         `json:"blockNumber"`
+	BlockHash        common.Hash    `json:"blockHash"`
+	GasUsed          hexutil.Uint64 `json:"gasUsed"`
+	TransactionCount int            `json:"transactionCount"`
+	Checksum         common.Hash    `json:"checksum"` // Hash of the canonical serialization of the preceding traces
+}
+
+// traceChecksum hashes the Parity traces of a block, allowing caches shared by
+// several nodes to validate them. The traces are serialized canonically, their
+// objects' keys sorted and their execution times dropped, so that the same
+// traces hash the same on every node.
+func traceChecksum(traces []interface{}) (common.Hash, error) {
+	blob, err := json.Marshal(traces)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var canonical []interface{}
+	decoder := json.NewDecoder(bytes.NewReader(blob))
+	decoder.UseNumber()
+	if err := decoder.Decode(&canonical); err != nil {
+		return common.Hash{}, err
+	}
+	for _, trace := range canonical {
+		if fields, ok := trace.(map[string]interface{}); ok {
+			delete(fields, "time")
+		}
+	}
+	if blob, err = json.Marshal(canonical); err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(blob), nil
+}
+
+// forkRule pairs a named protocol upgrade with the feature transition that
+// marks its activation.
+type forkRule struct {
+	name       string
+	transition func(config ctypes.ChainConfigurator) func() *uint64
+}
+
+// ethereumForkRules lists the named Ethereum Foundation chain upgrades, latest first.
+var ethereumForkRules = []forkRule{
+	{"berlin", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEIP2929Transition }},
+	{"muirGlacier", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEthashEIP2384Transition }},
+	{"istanbul", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEIP1884Transition }},
+	{"petersburg", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEIP1283DisableTransition }},
+	{"constantinople", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEIP145Transition }},
+	{"byzantium", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEIP140Transition }},
+	{"spuriousDragon", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEIP161dTransition }},
+	{"tangerineWhistle", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEIP150Transition }},
+	{"homestead", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEIP2Transition }},
+}
+
+// classicForkRules lists the named Ethereum Classic chain upgrades, latest first.
+var classicForkRules = []forkRule{
+	{"thanos", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEthashECIP1099Transition }},
+	{"phoenix", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEIP1884Transition }},
+	{"agharta", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEIP145Transition }},
+	{"atlantis", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEIP140Transition }},
+	{"defuse", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEthashECIP1041Transition }},
+	{"gotham", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEthashECIP1017Transition }},
+	{"dieHard", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEIP160Transition }},
+	{"gasReprice", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEIP150Transition }},
+	{"homestead", func(c ctypes.ChainConfigurator) func() *uint64 { return c.GetEIP2Transition }},
+}
+
+// forkNameAt returns the name of the latest named hardfork whose rules are in
+// effect at the given block number. Chains using the ECIP-1010/1017 monetary
+// and difficulty policies are reported using the Ethereum Classic fork names.
+func forkNameAt(config ctypes.ChainConfigurator, num *big.Int) string {
+	rules := ethereumForkRules
+	if config.GetEthashECIP1017Transition() != nil || config.GetEthashECIP1010PauseTransition() != nil {
+		rules = classicForkRules
+	}
+	for _, rule := range rules {
+		if config.IsEnabled(rule.transition(config), num) {
+			return rule.name
+		}
+	}
+	return "frontier"
 }
 
 // setTraceConfigDefaultTracer sets the default tracer to "callTracerParity" if none set
@@ -74,6 +181,18 @@ func setTraceConfigDefaultTracer(config *TraceConfig) *TraceConfig {
 	return config
 }
 
+// setTraceConfigRedaction attaches the node-wide redaction policy, if any, to a
+// copy of the given trace config. Traces requested by the node itself are not
+// redacted.
+func setTraceConfigRedaction(config *TraceConfig, settings *TraceAPIConfig) *TraceConfig {
+	if len(settings.Redact) == 0 || config.internal {
+		return config
+	}
+	redacted := *config
+	redacted.redaction = settings
+	return &redacted
+}
+
 // decorateResponse applies formatting to trace results if needed.
 func decorateResponse(res interface{}, config *TraceConfig) (interface{}, error) {
 	if config != nil && config.NestedTraceOutput && config.Tracer != nil {
@@ -109,7 +228,14 @@ func decorateNestedTraceResponse(res interface{}, tracer string) interface{} {
 	return out
 }
 
+// traceBlockReward creates the reward trace of the block's miner, aborting with
+// the context's error if it's done. The reward is the one credited by the ethash
+// engine, following the chain's configured reward schedule (including ECIP-1017
+// eras), not a hardcoded amount.
 func traceBlockReward(ctx context.Context, eth *Ethereum, block *types.Block, config *TraceConfig) (*ParityTrace, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	chainConfig := eth.blockchain.Config()
 	minerReward, _ := ethash.GetRewards(chainConfig, block.Header(), block.Uncles())
 
@@ -130,14 +256,28 @@ func traceBlockReward(ctx context.Context, eth *Ethereum, block *types.Block, co
 	return tr, nil
 }
 
+// traceBlockUncleRewards returns the Parity traces of the rewards credited to the
+// miners of the uncles of the given block, aborting with the context's error if
+// it's done.
 func traceBlockUncleRewards(ctx context.Context, eth *Ethereum, block *types.Block, config *TraceConfig) ([]*ParityTrace, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	chainConfig := eth.blockchain.Config()
 	_, uncleRewards := ethash.GetRewards(chainConfig, block.Header(), block.Uncles())
 
 	results := make([]*ParityTrace, len(uncleRewards))
 	for i, uncle := range block.Uncles() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if i < len(uncleRewards) {
-			coinbase := uncle.Coinbase
+			// Attribute the reward the way the consensus engine does, which is not
+			// necessarily the uncle's coinbase (e.g. signer based engines)
+			coinbase, err := eth.engine.Author(uncle)
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve author of uncle %#x: %v", uncle.Hash(), err)
+			}
 
 			results[i] = &ParityTrace{
 				Type: "reward",
@@ -150,81 +290,894 @@ func traceBlockUncleRewards(ctx context.Context, eth *Ethereum, block *types.Blo
 				BlockNumber:  block.NumberU64(),
 				BlockHash:    block.Hash(),
 			}
+			// Report the inputs of the depth based reward calculation if requested
+			if config != nil && config.IncludeUncleDetails {
+				number, depth := hexutil.Uint64(uncle.Number.Uint64()), hexutil.Uint64(block.NumberU64()-uncle.Number.Uint64())
+				results[i].Action.UncleNumber = &number
+				results[i].Action.UncleDepth = &depth
+			}
 		}
 	}
 
 	return results, nil
 }
 
+// addIntrinsicGas adds the given intrinsic gas of a transaction to the gas and
+// gasUsed of its root Parity trace, which by default only account for the gas
+// available to and used by the EVM.
+func addIntrinsicGas(res json.RawMessage, intrinsic uint64) (json.RawMessage, error) {
+	var traces []map[string]interface{}
+	if err := json.Unmarshal(res, &traces); err != nil {
+		return nil, err
+	}
+	add := func(object interface{}, field string) {
+		fields, ok := object.(map[string]interface{})
+		if !ok {
+			return
+		}
+		if value, ok := fields[field].(string); ok {
+			if gas, err := hexutil.DecodeUint64(value); err == nil {
+				fields[field] = hexutil.EncodeUint64(gas + intrinsic)
+			}
+		}
+	}
+	for _, trace := range traces {
+		if address, _ := trace["traceAddress"].([]interface{}); len(address) == 0 {
+			add(trace["action"], "gas")
+			add(trace["result"], "gasUsed")
+		}
+	}
+	return json.Marshal(traces)
+}
+
+// annotatePendingTraces marks each of the Parity traces of a raw tracer result as
+// pending, i.e. speculatively traced on top of the pending state. Results of
+// other tracers are returned as is.
+func annotatePendingTraces(res interface{}) interface{} {
+	raw, ok := res.(json.RawMessage)
+	if !ok {
+		return res
+	}
+	var traces []interface{}
+	if err := json.Unmarshal(raw, &traces); err != nil {
+		return res
+	}
+	for _, trace := range traces {
+		if trace, ok := trace.(map[string]interface{}); ok {
+			trace["pending"] = true
+		}
+	}
+	return traces
+}
+
+// annotateForkName sets the fork name on each of the given Parity formatted traces.
+func annotateForkName(traces []interface{}, fork string) {
+	for _, trace := range traces {
+		switch trace := trace.(type) {
+		case map[string]interface{}:
+			trace["fork"] = fork
+		case *ParityTrace:
+			trace.Fork = fork
+		}
+	}
+}
+
+// annotateChainID sets the chain id on each of the given Parity formatted traces,
+// leaving them untouched if the chain has none (i.e. predates EIP-155).
+func annotateChainID(traces []interface{}, id *big.Int) {
+	if id == nil {
+		return
+	}
+	for _, trace := range traces {
+		switch trace := trace.(type) {
+		case map[string]interface{}:
+			trace["chainId"] = (*hexutil.Big)(id)
+		case *ParityTrace:
+			trace.ChainID = (*hexutil.Big)(id)
+		}
+	}
+}
+
+// callTraceFailure returns the error of the root call of a raw call trace, be it
+// a list of Parity traces or a single call frame (e.g. of the callTracer), or the
+// empty string if the call succeeded.
+func callTraceFailure(res interface{}) string {
+	switch res := res.(type) {
+	case *ethapi.ExecutionResult:
+		if res.Failed {
+			return "execution failed"
+		}
+	case json.RawMessage:
+		var traces []struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(res, &traces); err == nil {
+			if len(traces) > 0 {
+				return traces[0].Error
+			}
+			return ""
+		}
+		var frame struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(res, &frame); err == nil {
+			return frame.Error
+		}
+	}
+	return ""
+}
+
+// erroredTransactionTrace creates the single root trace reported for a block
+// transaction which could not be traced at all, e.g. because it could not cover
+// its intrinsic gas. The fields are encoded the same way as the Parity tracer's,
+// the sender being left out if it can't be recovered.
+func erroredTransactionTrace(signer types.Signer, block *types.Block, index int, failure string) map[string]interface{} {
+	tx := block.Transactions()[index]
+
+	trace := map[string]interface{}{
+		"error":               failure,
+		"subtraces":           0,
+		"traceAddress":        []int{},
+		"transactionPosition": uint64(index),
+		"transactionHash":     tx.Hash().Hex(),
+		"blockNumber":         block.NumberU64(),
+		"blockHash":           block.Hash().Hex(),
+	}
+	action := map[string]interface{}{
+		"value": hexutil.EncodeBig(tx.Value()),
+		"gas":   hexutil.EncodeUint64(tx.Gas()),
+	}
+	if from, err := types.Sender(signer, tx); err == nil {
+		action["from"] = hexutil.Encode(from.Bytes())
+	}
+	if to := tx.To(); to != nil {
+		trace["type"] = "call"
+		action["callType"] = "call"
+		action["to"] = hexutil.Encode(to.Bytes())
+		action["input"] = hexutil.Encode(tx.Data())
+	} else {
+		trace["type"] = "create"
+		action["creationMethod"] = "create"
+		action["init"] = hexutil.Encode(tx.Data())
+	}
+	trace["action"] = action
+	return trace
+}
+
+// parityTransactionTraces flattens the Parity tracer results of the block's
+// transactions into a single trace list. Transactions which failed to be traced
+// are represented by an errored root trace, keeping the list aligned with the
+// block's transactions.
+func parityTransactionTraces(config ctypes.ChainConfigurator, block *types.Block, results []*txTraceResult) ([]interface{}, error) {
+	var (
+		signer = types.MakeSigner(config, block.Number())
+		traces = []interface{}{}
+	)
+	for i, result := range results {
+		if result == nil {
+			continue // Transaction not traced (e.g. filtered by sender)
+		}
+		if result.Error != "" {
+			traces = append(traces, erroredTransactionTrace(signer, block, i, result.Error))
+			continue
+		}
+		// Only JavaScript tracers produce raw JSON results, anything else (e.g. the
+		// struct logger) isn't in the Parity format
+		raw, ok := result.Result.(json.RawMessage)
+		if !ok {
+			return nil, fmt.Errorf("transaction %d: unexpected trace result of type %T, not a Parity trace", i, result.Result)
+		}
+		var tmp []interface{}
+		if err := json.Unmarshal(raw, &tmp); err != nil {
+			return nil, err
+		}
+		correlateTransactionTraces(tmp, block, i)
+		traces = append(traces, tmp...)
+	}
+	return traces, nil
+}
+
+// correlateTransactionTraces ensures the block and transaction correlation fields
+// of the decoded Parity traces of the given block transaction match the block
+// itself, so that clients can always verify the inclusion of the transaction the
+// traces belong to, whatever the tracer reported. Missing or mismatching fields
+// are replaced.
+func correlateTransactionTraces(traces []interface{}, block *types.Block, index int) {
+	tx := block.Transactions()[index]
+	for _, trace := range traces {
+		trace, ok := trace.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if number, ok := trace["blockNumber"].(float64); !ok || number != float64(block.NumberU64()) {
+			trace["blockNumber"] = block.NumberU64()
+		}
+		if hash, ok := trace["blockHash"].(string); !ok || hash != block.Hash().Hex() {
+			trace["blockHash"] = block.Hash().Hex()
+		}
+		if hash, ok := trace["transactionHash"].(string); !ok || hash != tx.Hash().Hex() {
+			trace["transactionHash"] = tx.Hash().Hex()
+		}
+		if position, ok := trace["transactionPosition"].(float64); !ok || position != float64(index) {
+			trace["transactionPosition"] = uint64(index)
+		}
+	}
+}
+
+// hashTraceInputs adds the keccak256 hash of the call input data to each of the
+// given Parity formatted traces carrying one, optionally dropping the input.
+func hashTraceInputs(traces []interface{}, include, omit bool) {
+	for _, trace := range traces {
+		trace, ok := trace.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		action, ok := trace["action"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		input, ok := action["input"].(string)
+		if !ok {
+			continue
+		}
+		if include {
+			data, err := hexutil.Decode(input)
+			if err != nil {
+				log.Warn("Failed to decode trace input", "input", input, "err", err)
+			} else {
+				action["inputHash"] = crypto.Keccak256Hash(data)
+			}
+		}
+		if omit {
+			delete(action, "input")
+		}
+	}
+}
+
+// parityTraceData lists the data fields of the action and result objects of
+// Parity formatted traces, which are subject to truncation.
+var parityTraceData = map[string][]string{
+	"action": {"input", "init"},
+	"result": {"output", "code"},
+}
+
+// truncateTraceData truncates the data fields of each of the given Parity
+// formatted traces to the given number of bytes. The traces losing data are
+// marked as truncated, and each truncated field is accompanied by its original
+// length in bytes, e.g. action.inputLength.
+func truncateTraceData(traces []interface{}, limit uint64) {
+	for _, trace := range traces {
+		trace, ok := trace.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for name, fields := range parityTraceData {
+			object, ok := trace[name].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, field := range fields {
+				data, ok := object[field].(string)
+				if !ok || uint64(len(data)) <= 2+2*limit {
+					continue
+				}
+				object[field+"Length"] = hexutil.EncodeUint64(uint64(len(data)-2) / 2)
+				object[field] = data[:2+2*limit]
+				trace["truncated"] = true
+			}
+		}
+	}
+}
+
+// annotateTraceSelectors adds the function selector, i.e. the first 4 bytes of
+// the input data, to the action of each of the given Parity formatted call
+// traces. Calls with less than 4 bytes of input and creations get an empty one.
+func annotateTraceSelectors(traces []interface{}) {
+	for _, trace := range traces {
+		trace, ok := trace.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		action, ok := trace["action"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch trace["type"] {
+		case "call":
+			input, _ := action["input"].(string)
+			if data, err := hexutil.Decode(input); err == nil && len(data) >= 4 {
+				action["selector"] = hexutil.Bytes(data[:4])
+			} else {
+				action["selector"] = hexutil.Bytes{}
+			}
+		case "create":
+			action["selector"] = hexutil.Bytes{}
+		}
+	}
+}
+
+// annotateTransactionStatus sets the status of the transaction, 0x1 for success
+// and 0x0 for failure, on the root trace of each transaction, mirroring the
+// receipt status. A transaction failed if its root call reverted or errored.
+func annotateTransactionStatus(traces []interface{}) {
+	for _, trace := range traces {
+		trace, ok := trace.(map[string]interface{})
+		if !ok || !isRootTransactionTrace(trace) {
+			continue
+		}
+		if _, failed := trace["error"]; failed {
+			trace["status"] = hexutil.Uint64(types.ReceiptStatusFailed)
+		} else {
+			trace["status"] = hexutil.Uint64(types.ReceiptStatusSuccessful)
+		}
+	}
+}
+
+// errEffectiveGasPriceUnsupported is returned when the effective gas prices are
+// requested from the trace methods not tracing whole blocks, which don't look
+// them up.
+var errEffectiveGasPriceUnsupported = errors.New("includeEffectiveGasPrice is only supported by the block tracing methods")
+
+// annotateEffectiveGasPrice sets the gas price effectively paid per unit of gas
+// by each transaction of the block on its root trace, as reported by its receipt.
+// Without EIP-1559 fee markets, that's the gas price of the transaction.
+func annotateEffectiveGasPrice(traces []interface{}, block *types.Block) {
+	txs := block.Transactions()
+	for _, trace := range traces {
+		trace, ok := trace.(map[string]interface{})
+		if !ok || !isRootTransactionTrace(trace) {
+			continue
+		}
+		var index uint64
+		switch position := trace["transactionPosition"].(type) {
+		case float64:
+			index = uint64(position)
+		case uint64:
+			index = position
+		default:
+			continue
+		}
+		if index < uint64(len(txs)) {
+			trace["effectiveGasPrice"] = hexutil.EncodeBig(txs[index].GasPrice())
+		}
+	}
+}
+
+// isRootTransactionTrace reports whether a Parity formatted trace is the root
+// trace of a transaction, i.e. one with a position but no trace address. The
+// position of rewards is null, they never belong to a transaction.
+func isRootTransactionTrace(trace map[string]interface{}) bool {
+	if position, ok := trace["transactionPosition"]; !ok || position == nil {
+		return false
+	}
+	switch address := trace["traceAddress"].(type) {
+	case []interface{}:
+		return len(address) == 0
+	case []int:
+		return len(address) == 0
+	}
+	return true
+}
+
+// traceAddresses returns the sender and recipient of a Parity formatted trace,
+// following OpenEthereum's trace_filter matching rules.
+func traceAddresses(trace map[string]interface{}) (from, to string) {
+	str := func(object map[string]interface{}, field string) string {
+		s, _ := object[field].(string)
+		return s
+	}
+	action, _ := trace["action"].(map[string]interface{})
+	result, _ := trace["result"].(map[string]interface{})
+
+	switch trace["type"] {
+	case "create":
+		return str(action, "from"), str(result, "address")
+	case "suicide":
+		return str(action, "address"), str(action, "refundAddress")
+	case "reward":
+		return "", str(action, "author")
+	default:
+		return str(action, "from"), str(action, "to")
+	}
+}
+
+// filterTraces retains the Parity formatted traces sent from and to the
+// addresses of the filter, transferring at least its minimum value. Unset
+// addresses match any trace. Rewards are not transfers, so a positive minimum
+// value excludes them. Typed traces are converted to their generic JSON form.
+func filterTraces(traces []interface{}, filter *TraceFilterArgs) ([]interface{}, error) {
+	matches := func(want *common.Address, have string) bool {
+		return want == nil || (have != "" && common.HexToAddress(have) == *want)
+	}
+	var minValue *big.Int
+	if filter.MinValue != nil && filter.MinValue.ToInt().Sign() > 0 {
+		minValue = filter.MinValue.ToInt()
+	}
+	results := traces[:0]
+	for _, trace := range traces {
+		object, ok := trace.(map[string]interface{})
+		if !ok {
+			blob, err := json.Marshal(trace)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(blob, &object); err != nil {
+				return nil, err
+			}
+		}
+		from, to := traceAddresses(object)
+		if !matches(filter.FromAddress, from) || !matches(filter.ToAddress, to) {
+			continue
+		}
+		if minValue != nil {
+			if value := transferredValue(object); object["type"] == "reward" || value == nil || value.Cmp(minValue) < 0 {
+				continue
+			}
+		}
+		results = append(results, object)
+	}
+	return results, nil
+}
+
+// parityTraceQuantities lists the hex encoded quantity fields of the action and
+// result objects of Parity formatted traces.
+var parityTraceQuantities = map[string][]string{
+	"action": {"value", "gas", "balance", "inputLength", "initLength"},
+	"result": {"gasUsed", "gasRemaining", "gasRetained", "outputLength", "codeLength"},
+}
+
+// parityTraceTopQuantities lists the hex encoded quantity fields of the Parity
+// formatted traces themselves.
+var parityTraceTopQuantities = []string{"effectiveGasPrice"}
+
+// decimalTraceQuantities re-encodes the hex quantity fields of each of the given
+// Parity formatted traces as decimal strings. Typed traces are converted to
+// their generic JSON form.
+func decimalTraceQuantities(traces []interface{}) ([]interface{}, error) {
+	for i, trace := range traces {
+		object, ok := trace.(map[string]interface{})
+		if !ok {
+			blob, err := json.Marshal(trace)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(blob, &object); err != nil {
+				return nil, err
+			}
+			traces[i] = object
+		}
+		for _, field := range parityTraceTopQuantities {
+			value, ok := object[field].(string)
+			if !ok {
+				continue
+			}
+			number, err := hexutil.DecodeBig(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trace %s %q: %v", field, value, err)
+			}
+			object[field] = number.String()
+		}
+		for name, fields := range parityTraceQuantities {
+			nested, ok := object[name].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, field := range fields {
+				value, ok := nested[field].(string)
+				if !ok {
+					continue
+				}
+				number, err := hexutil.DecodeBig(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid trace %s.%s %q: %v", name, field, value, err)
+				}
+				nested[field] = number.String()
+			}
+		}
+	}
+	return traces, nil
+}
+
+// errStepCaptureUnsupported is returned when the step data toggles of the struct
+// logger are passed to the trace_* methods, whose JavaScript tracers read the
+// step data lazily instead of capturing it.
+var errStepCaptureUnsupported = errors.New("disableStack, disableMemory, disableStorage and disableReturnData are only supported by the debug_trace* methods")
+
+// togglesStepCapture reports whether the trace config sets any of the step data
+// toggles of the struct logger.
+func togglesStepCapture(config *TraceConfig) bool {
+	return config.LogConfig != nil && (config.DisableStack || config.DisableMemory || config.DisableStorage || config.DisableReturnData)
+}
+
+// validateParityTraceConfig checks the output options of the trace config
+// before any tracing is done.
+func validateParityTraceConfig(config *TraceConfig) error {
+	// Transaction-trace filtering methods always return flat trace lists like
+	// OpenEthereum does, nesting only applies to the ad-hoc tracing methods
+	if config.NestedTraceOutput {
+		return errors.New("nestedTraceOutput is only supported by trace_call and trace_callMany")
+	}
+	if config.IncludeAccessList {
+		return errors.New("includeAccessList is only supported by trace_call")
+	}
+	if config.BlockOverrides != nil {
+		return errors.New("blockOverrides is only supported by trace_call and trace_callMany")
+	}
+	if config.StateOverrides != nil {
+		return errors.New("stateOverrides is only supported by trace_call and trace_callMany")
+	}
+	if config.StrictCalls {
+		return errors.New("strictCalls is only supported by trace_callMany")
+	}
+	if togglesStepCapture(config) {
+		return errStepCaptureUnsupported
+	}
+	if config.IncludeInternalFailures && !config.FailedTransactionsOnly {
+		return errors.New("includeInternalFailures requires failedTransactionsOnly")
+	}
+	if err := validateTraceFields(config.Fields); err != nil {
+		return err
+	}
+	if err := validateTraceFormat(config.Format); err != nil {
+		return err
+	}
+	if len(config.Fields) > 0 && config.Format != traceFormatNested {
+		return errors.New("trace field projection requires the nested trace format")
+	}
+	if config.IncludeLogs && config.Format != traceFormatNested {
+		return errors.New("trace logs require the nested trace format")
+	}
+	return nil
+}
+
+// formatParityTraces applies the output transformations requested by the
+// trace config onto the given Parity formatted traces.
+func formatParityTraces(traces []interface{}, config *TraceConfig) ([]interface{}, error) {
+	if config == nil {
+		return traces, nil
+	}
+	// Transactions fail or not as a whole, so they're filtered on all their traces
+	if config.FailedTransactionsOnly {
+		var err error
+		if traces, err = filterFailedTransactions(traces, config.IncludeInternalFailures); err != nil {
+			return nil, err
+		}
+	}
+	if config.addresses != nil {
+		var err error
+		if traces, err = filterTraces(traces, config.addresses); err != nil {
+			return nil, err
+		}
+	}
+	if config.ValueTransfersOnly {
+		var err error
+		if traces, err = filterValueTransfers(traces); err != nil {
+			return nil, err
+		}
+	}
+	if config.IncludeSelector {
+		annotateTraceSelectors(traces)
+	}
+	if config.IncludeInputHash || config.OmitInput {
+		hashTraceInputs(traces, config.IncludeInputHash, config.OmitInput)
+	}
+	if config.MaxDataBytes > 0 {
+		truncateTraceData(traces, config.MaxDataBytes)
+	}
+	if config.IncludeStatus {
+		annotateTransactionStatus(traces)
+	}
+	if config.redaction != nil {
+		var err error
+		if traces, err = redactTraces(traces, config.redaction); err != nil {
+			return nil, err
+		}
+	}
+	if config.DecimalValues {
+		var err error
+		if traces, err = decimalTraceQuantities(traces); err != nil {
+			return nil, err
+		}
+	}
+	if len(config.Fields) > 0 {
+		return projectTraces(traces, config.Fields)
+	}
+	if config.Format == traceFormatRows {
+		return flattenTraces(traces)
+	}
+	return traces, nil
+}
+
+// formatParityTraceResult applies the output transformations requested by the
+// trace config onto a raw transaction trace result of the Parity tracer.
+func formatParityTraceResult(res interface{}, config *TraceConfig) (interface{}, error) {
+	if config == nil || (len(config.Fields) == 0 && config.addresses == nil && !config.ValueTransfersOnly && !config.FailedTransactionsOnly && config.redaction == nil && !config.DecimalValues && !config.IncludeInputHash && !config.OmitInput && config.MaxDataBytes == 0 && !config.IncludeSelector && !config.IncludeStatus && config.Format == traceFormatNested) {
+		return res, nil
+	}
+	if traces, ok := res.([]interface{}); ok {
+		return formatParityTraces(traces, config)
+	}
+	raw, ok := res.(json.RawMessage)
+	if !ok {
+		return res, nil
+	}
+	var traces []interface{}
+	if err := json.Unmarshal(raw, &traces); err != nil {
+		return nil, err
+	}
+	return formatParityTraces(traces, config)
+}
+
+// dedupeTransactionTraces drops any transaction trace already present in the
+// given list, keyed on its (transactionPosition, traceAddress) pair. Traces not
+// carrying a transaction position (e.g. rewards) are always retained.
+func dedupeTransactionTraces(traces []interface{}) []interface{} {
+	seen := make(map[string]struct{})
+	results := traces[:0]
+	for _, trace := range traces {
+		if trace, ok := trace.(map[string]interface{}); ok {
+			if position, ok := trace["transactionPosition"]; ok {
+				key := fmt.Sprintf("%v:%v", position, trace["traceAddress"])
+				if _, dup := seen[key]; dup {
+					log.Warn("Dropping duplicate transaction trace", "position", position, "address", trace["traceAddress"])
+					continue
+				}
+				seen[key] = struct{}{}
+			}
+		}
+		results = append(results, trace)
+	}
+	return results
+}
+
 // Block returns the structured logs created during the execution of
 // EVM and returns them as a JSON object.
 // The correct name will be TraceBlockByNumber, though we want to be compatible with Parity trace module.
-func (api *PrivateTraceAPI) Block(ctx context.Context, number rpc.BlockNumber, config *TraceConfig) ([]interface{}, error) {
-	// Fetch the block that we want to trace
-	var block *types.Block
-
-	switch number {
-	case rpc.PendingBlockNumber:
-		block = api.eth.miner.PendingBlock()
-	case rpc.LatestBlockNumber:
-		block = api.eth.blockchain.CurrentBlock()
-	default:
-		block = api.eth.blockchain.GetBlockByNumber(uint64(number))
+func (api *PrivateTraceAPI) Block(ctx context.Context, number rpc.BlockNumber, config *TraceConfig) (traces []interface{}, err error) {
+	if config != nil && config.CancelToken != "" {
+		registered := *config
+		registered.CancelToken = ""
+		err = api.eth.traceJobs.run(ctx, config.CancelToken, func(ctx context.Context) (err error) {
+			traces, err = api.Block(ctx, number, &registered)
+			return err
+		})
+		return traces, err
 	}
-	// Trace the block if it was found
+	traceBlockReqMeter.Mark(1)
+	defer func(start time.Time) {
+		traceBlockTimer.UpdateSince(start)
+		if err != nil {
+			traceBlockFailMeter.Mark(1)
+		}
+	}(time.Now())
+
+	return api.blockTracesByNumber(ctx, number, config)
+}
+
+// blockTracesByNumber traces the block of the given number like trace_block, but
+// without accounting for it in the trace_block request metrics, for the methods
+// tracing blocks on their own behalf.
+func (api *PrivateTraceAPI) blockTracesByNumber(ctx context.Context, number rpc.BlockNumber, config *TraceConfig) ([]interface{}, error) {
+	// Fetch the block that we want to trace
+	block := blockByNumber(api.eth, number)
 	if block == nil {
-		return nil, fmt.Errorf("block #%d not found", number)
+		return nil, missingBlockError(api.eth, number)
 	}
 
 	config = setTraceConfigDefaultTracer(config)
-
-	traceResults, err := traceBlockByNumber(ctx, api.eth, number, config)
-	if err != nil {
+	if err := validateParityTraceConfig(config); err != nil {
 		return nil, err
 	}
+	config = setTraceConfigRedaction(config, &api.eth.config.Trace)
 
-	traceReward, err := traceBlockReward(ctx, api.eth, block, config)
+	return api.blockTraces(ctx, block, config)
+}
+
+// blockTraces traces the given block, which need not be canonical, returning its
+// Parity traces formatted according to the already validated config.
+func (api *PrivateTraceAPI) blockTraces(ctx context.Context, block *types.Block, config *TraceConfig) ([]interface{}, error) {
+	traceResults, err := traceBlock(ctx, api.eth, block, config)
 	if err != nil {
 		return nil, err
 	}
 
-	traceUncleRewards, err := traceBlockUncleRewards(ctx, api.eth, block, config)
+	results, err := parityTransactionTraces(api.eth.blockchain.Config(), block, traceResults)
 	if err != nil {
 		return nil, err
 	}
+	results = dedupeTransactionTraces(results)
 
-	results := []interface{}{}
-
-	for _, result := range traceResults {
-		var tmp []interface{}
-		if err := json.Unmarshal(result.Result.(json.RawMessage), &tmp); err != nil {
+	// Block rewards are derived from the ethash reward schedule, reporting them
+	// for chains sealed by other engines (e.g. clique) would be misleading. They
+	// are not part of any transaction either, so are skipped if specific ones were
+	// requested.
+	switch {
+	case config.Transactions != nil:
+	case api.eth.blockchain.Config().GetConsensusEngineType().IsEthash():
+		traceReward, err := traceBlockReward(ctx, api.eth, block, config)
+		if err != nil {
 			return nil, err
 		}
-		results = append(results, tmp...)
-	}
 
-	results = append(results, traceReward)
+		traceUncleRewards, err := traceBlockUncleRewards(ctx, api.eth, block, config)
+		if err != nil {
+			return nil, err
+		}
 
-	for _, uncleReward := range traceUncleRewards {
-		results = append(results, uncleReward)
+		// Like OpenEthereum, the rewards follow the transaction traces, the block
+		// reward first and then the uncle rewards in the order of the uncles in
+		// the block. Rewards reduced to zero by the chain's reward schedule credit
+		// nothing, so there's nothing to report for them.
+		if traceReward.Action.Value.ToInt().Sign() > 0 {
+			results = append(results, traceReward)
+		}
+		for _, uncleReward := range traceUncleRewards {
+			if uncleReward.Action.Value.ToInt().Sign() > 0 {
+				results = append(results, uncleReward)
+			}
+		}
+	default:
+		log.Warn("Skipping block reward traces of non-ethash chain", "number", block.NumberU64(), "engine", api.eth.blockchain.Config().GetConsensusEngineType())
 	}
 
+	if config.IncludeForkName {
+		annotateForkName(results, forkNameAt(api.eth.blockchain.Config(), block.Number()))
+	}
+	if config.IncludeChainID {
+		annotateChainID(results, api.eth.blockchain.Config().GetChainID())
+	}
+	if config.IncludeEffectiveGasPrice {
+		annotateEffectiveGasPrice(results, block)
+	}
+	if results, err = formatParityTraces(results, config); err != nil {
+		return nil, err
+	}
+	if config.IncludeBlockSummary {
+		checksum, err := traceChecksum(results)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &TraceBlockSummary{
+			Type:             "blockSummary",
+			BlockNumber:      block.NumberU64(),
+			BlockHash:        block.Hash(),
+			GasUsed:          hexutil.Uint64(block.GasUsed()),
+			TransactionCount: len(block.Transactions()),
+			Checksum:         checksum,
+		})
+	}
 	return results, nil
 }
 
 // Transaction returns the structured logs created during the execution of EVM
 // and returns them as a JSON object.
-func (api *PrivateTraceAPI) Transaction(ctx context.Context, hash common.Hash, config *TraceConfig) (interface{}, error) {
+func (api *PrivateTraceAPI) Transaction(ctx context.Context, hash common.Hash, config *TraceConfig) (trace interface{}, err error) {
+	traceTransactionReqMeter.Mark(1)
+	defer func(start time.Time) {
+		traceTransactionTimer.UpdateSince(start)
+		if err != nil {
+			traceTransactionFailMeter.Mark(1)
+		}
+	}(time.Now())
+
 	config = setTraceConfigDefaultTracer(config)
-	return traceTransaction(ctx, api.eth, hash, config)
+	if err := validateParityTraceConfig(config); err != nil {
+		return nil, err
+	}
+	if config.IncludeEffectiveGasPrice {
+		return nil, errEffectiveGasPriceUnsupported
+	}
+	if config.CancelToken != "" {
+		return nil, errCancelTokenUnsupported
+	}
+	config = setTraceConfigRedaction(config, &api.eth.config.Trace)
+
+	res, pending, err := traceTransactionOrPending(ctx, api.eth, hash, config)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		res = annotatePendingTraces(res)
+	}
+	return formatParityTraceResult(res, config)
+}
+
+// TraceFormatsResult holds the trace of a transaction in both the Parity and the
+// standard Geth (callTracer) formats.
+type TraceFormatsResult struct {
+	Parity interface{} `json:"parity"`
+	Geth   interface{} `json:"geth"`
+}
+
+// TransactionFormats traces a transaction with both the callTracerParity and the
+// callTracer tracers, returning both formats of its trace in one response to help
+// validating Parity format parsers against the Geth format. The config applies
+// to the Parity trace, only its timeout and reexec are used for the Geth one.
+func (api *PrivateTraceAPI) TransactionFormats(ctx context.Context, hash common.Hash, config *TraceConfig) (*TraceFormatsResult, error) {
+	if config != nil && config.Tracer != nil && *config.Tracer != "callTracerParity" {
+		return nil, fmt.Errorf("tracer %q not supported, the Parity trace is produced by callTracerParity", *config.Tracer)
+	}
+	// The redaction policy is expressed in Parity trace fields, which don't
+	// translate to the Geth format
+	if len(api.eth.config.Trace.Redact) > 0 {
+		return nil, errors.New("geth trace format unavailable, trace outputs are redacted")
+	}
+	parity, err := api.Transaction(ctx, hash, config)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		tracer = "callTracer"
+		geth   = &TraceConfig{Tracer: &tracer}
+	)
+	if config != nil {
+		geth.Timeout, geth.Reexec = config.Timeout, config.Reexec
+	}
+	res, _, err := traceTransactionOrPending(ctx, api.eth, hash, geth)
+	if err != nil {
+		return nil, err
+	}
+	return &TraceFormatsResult{Parity: parity, Geth: res}, nil
+}
+
+// ValidateConfig checks a trace config the way the trace methods would, without
+// tracing anything: its output options, its timeout and its tracer, which must
+// be a built-in tracer name or compile as JavaScript tracer code.
+func (api *PrivateTraceAPI) ValidateConfig(ctx context.Context, config *TraceConfig) (bool, error) {
+	config = setTraceConfigDefaultTracer(config)
+	if err := validateParityTraceConfig(config); err != nil {
+		return false, err
+	}
+	if config.Timeout != nil {
+		if _, err := time.ParseDuration(*config.Timeout); err != nil {
+			return false, fmt.Errorf("invalid timeout %q: %v", *config.Timeout, err)
+		}
+	}
+	if _, err := tracers.New(*config.Tracer); err != nil {
+		return false, fmt.Errorf("invalid tracer %q: %v", *config.Tracer, err)
+	}
+	return true, nil
+}
+
+// traceTransactionOrPending traces a mined transaction, or if it's not mined yet,
+// speculatively traces it from the transaction pool, reporting it as pending.
+func traceTransactionOrPending(ctx context.Context, eth *Ethereum, hash common.Hash, config *TraceConfig) (interface{}, bool, error) {
+	if tx, _, _, _ := rawdb.ReadTransaction(eth.ChainDb(), hash); tx == nil && eth.txPool != nil {
+		if tx := eth.txPool.Get(hash); tx != nil {
+			res, err := tracePendingTransaction(ctx, eth, tx, config)
+			return res, true, err
+		}
+	}
+	res, err := traceTransaction(ctx, eth, hash, config)
+	return res, false, err
 }
 
 // Filter configures a new tracer according to the provided configuration, and
 // executes all the transactions contained within. The return value will be one item
 // per transaction, dependent on the requested tracer.
-func (api *PrivateTraceAPI) Filter(ctx context.Context, args TraceFilterArgs, config *TraceConfig) (*rpc.Subscription, error) {
+func (api *PrivateTraceAPI) Filter(ctx context.Context, args TraceFilterArgs, config *TraceConfig) (sub *rpc.Subscription, err error) {
+	traceFilterReqMeter.Mark(1)
+	defer func() {
+		if err != nil {
+			traceFilterFailMeter.Mark(1)
+		}
+	}()
+
 	config = setTraceConfigDefaultTracer(config)
+	if err := validateParityTraceConfig(config); err != nil {
+		return nil, err
+	}
+	if config.IncludeEffectiveGasPrice {
+		return nil, errEffectiveGasPriceUnsupported
+	}
+	if config.CancelToken != "" {
+		return nil, errCancelTokenUnsupported
+	}
+	config = setTraceConfigRedaction(config, &api.eth.config.Trace)
 
+	if args.SortByValue {
+		return nil, errors.New("sortByValue needs all traces before returning any, use the trace_filter method instead of subscribing")
+	}
 	// Fetch the block interval that we want to trace
 	start := uint64(args.FromBlock)
 	end := uint64(args.ToBlock)
@@ -239,32 +1192,138 @@ func (api *PrivateTraceAPI) Filter(ctx context.Context, args TraceFilterArgs, co
 	if to == nil {
 		return nil, fmt.Errorf("end block #%d not found", end)
 	}
-	if from.Number().Cmp(to.Number()) >= 0 {
-		return nil, fmt.Errorf("end block (#%d) needs to come after start block (#%d)", end, start)
+	if from.Number().Cmp(to.Number()) > 0 {
+		return nil, fmt.Errorf("end block (#%d) precedes start block (#%d)", end, start)
 	}
-	return traceChain(ctx, api.eth, from, to, config)
+	if args.filtersTraces() || api.eth.config.Trace.FilterSizeLimit > 0 || api.eth.config.Trace.FilterBuffer > 0 {
+		filtered := *config
+		if args.filtersTraces() {
+			filtered.addresses = &args
+		}
+		filtered.sizeLimit = api.eth.config.Trace.FilterSizeLimit
+		filtered.bufferLen = api.eth.config.Trace.FilterBuffer
+		config = &filtered
+	}
+	// Chain tracing excludes the starting block, start from its parent so that
+	// the range is inclusive (allowing single block filters) like OpenEthereum's
+	if start > 0 {
+		from = api.eth.blockchain.GetBlock(from.ParentHash(), start-1)
+		if from == nil {
+			return nil, fmt.Errorf("parent block #%d not found", start-1)
+		}
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	if err := api.eth.traceSubs.acquire(notifier); err != nil {
+		return nil, err
+	}
+	if sub, err = traceChain(ctx, api.eth, from, to, config); err != nil {
+		api.eth.traceSubs.release(notifier)
+		return nil, err
+	}
+	api.eth.traceSubs.track(notifier, sub)
+	return sub, nil
 }
 
 // Call lets you trace a given eth_call. It collects the structured logs created during the execution of EVM
 // if the given transaction was added on top of the provided block and returns them as a JSON object.
 // You can provide -2 as a block number to trace on top of the pending block.
 func (api *PrivateTraceAPI) Call(ctx context.Context, args ethapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceConfig) (interface{}, error) {
-	config = setTraceConfigDefaultTracer(config)
+	config = setTraceConfigRedaction(setTraceConfigDefaultTracer(config), &api.eth.config.Trace)
+	if togglesStepCapture(config) {
+		return nil, errStepCaptureUnsupported
+	}
+	if config.IncludeEffectiveGasPrice {
+		return nil, errEffectiveGasPriceUnsupported
+	}
+	if config.CancelToken != "" {
+		return nil, errCancelTokenUnsupported
+	}
+	if config.IncludeAccessList {
+		collecting := *config
+		collecting.accessList = newAccessListTracer()
+		config = &collecting
+	}
 	res, err := traceCall(ctx, api.eth, args, blockNrOrHash, config)
 	if err != nil {
 		return nil, err
 	}
+	if config.redaction != nil {
+		if res, err = redactTraceResult(res, config.redaction); err != nil {
+			return nil, err
+		}
+	}
+	if config.accessList != nil {
+		return decorateAccessListResponse(res, *config.Tracer, config.accessList.accessList()), nil
+	}
 	return decorateResponse(res, config)
 }
 
+// decorateAccessListResponse returns the trace result of a call along with the
+// access list it generated, the trace being nested under its trace name key
+// like decorateNestedTraceResponse does, or under "result" for custom tracers.
+func decorateAccessListResponse(res interface{}, tracer string, list []*TraceAccessTuple) interface{} {
+	out, ok := decorateNestedTraceResponse(res, tracer).(map[string]interface{})
+	if !ok {
+		out = map[string]interface{}{"result": res}
+	}
+	out["accessList"] = list
+	return out
+}
+
 // CallMany lets you trace a given eth_call. It collects the structured logs created during the execution of EVM
 // if the given transaction was added on top of the provided block and returns them as a JSON object.
 // You can provide -2 as a block number to trace on top of the pending block.
 func (api *PrivateTraceAPI) CallMany(ctx context.Context, txs []ethapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceConfig) (interface{}, error) {
-	config = setTraceConfigDefaultTracer(config)
+	config = setTraceConfigRedaction(setTraceConfigDefaultTracer(config), &api.eth.config.Trace)
+	if config.IncludeAccessList {
+		return nil, errors.New("includeAccessList is only supported by trace_call")
+	}
+	if togglesStepCapture(config) {
+		return nil, errStepCaptureUnsupported
+	}
+	if config.IncludeEffectiveGasPrice {
+		return nil, errEffectiveGasPriceUnsupported
+	}
+	if config.CancelToken != "" {
+		return nil, errCancelTokenUnsupported
+	}
+	if limit := api.eth.config.Trace.CallManyLimit; limit > 0 && len(txs) > limit {
+		return nil, fmt.Errorf("%d calls exceed the trace_callMany limit of %d", len(txs), limit)
+	}
 	res, err := traceCallMany(ctx, api.eth, txs, blockNrOrHash, config)
 	if err != nil {
+		// If the deadline passed mid-batch, fail with the partial results, the
+		// calls left untraced marked by the deadline error
+		if res != nil && (err == context.DeadlineExceeded || err == context.Canceled) {
+			for len(res) < len(txs) {
+				res = append(res, &txTraceResult{Error: err.Error()})
+			}
+			return nil, &incompleteCallManyError{error: err, results: res}
+		}
 		return nil, err
 	}
 	return res, nil
 }
+
+// incompleteCallManyError is returned by trace_callMany if its context is done
+// before the whole batch is traced. The results of the calls traced meanwhile
+// are returned as the data of the JSON-RPC error, so that clients can tell the
+// batch apart from one whose calls individually failed.
+type incompleteCallManyError struct {
+	error
+	results []interface{}
+}
+
+// ErrorData returns the results of the batch, the calls left untraced carrying
+// the context error.
+func (e *incompleteCallManyError) ErrorData() interface{} {
+	return e.results
+}
+
+// Unwrap returns the context error which interrupted the batch.
+func (e *incompleteCallManyError) Unwrap() error {
+	return e.error
+}
@@ -1,9 +1,138 @@
 package eth
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/coregeth"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/vars"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
 )
 
+// newTestTraceBackend creates a minimal Ethereum service for tracing, backed by
+// a chain of the given number of blocks built with the given generator on top
+// of a genesis funding the test bank.
+func newTestTraceBackend(t testing.TB, blocks int, generator func(int, *core.BlockGen)) *Ethereum {
+	return newTestTraceBackendWithConfig(t, params.TestChainConfig, blocks, generator)
+}
+
+// newTestTraceBackendWithConfig creates a trace backend like newTestTraceBackend
+// does, running the chain with the given chain config.
+func newTestTraceBackendWithConfig(t testing.TB, config ctypes.ChainConfigurator, blocks int, generator func(int, *core.BlockGen)) *Ethereum {
+	var (
+		engine = ethash.NewFaker()
+		db     = rawdb.NewMemoryDatabase()
+		gspec  = &genesisT.Genesis{
+			Config: config,
+			Alloc:  genesisT.GenesisAlloc{testBank: {Balance: big.NewInt(vars.Ether)}},
+		}
+		genesis = core.MustCommitGenesis(db, gspec)
+	)
+	blockchain, err := core.NewBlockChain(db, nil, gspec.Config, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	chain, _ := core.GenerateChain(gspec.Config, genesis, engine, db, blocks, generator)
+	if _, err := blockchain.InsertChain(chain); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	eth := &Ethereum{
+		config:     &DefaultConfig,
+		blockchain: blockchain,
+		engine:     engine,
+		chainDb:    db,
+	}
+	eth.APIBackend = &EthAPIBackend{eth: eth}
+	return eth
+}
+
+// testTransferBlocks is a chain generator placing n value transfers from the
+// test bank into every block.
+func testTransferBlocks(n int) func(int, *core.BlockGen) {
+	return func(i int, block *core.BlockGen) {
+		signer := types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		for j := 0; j < n; j++ {
+			tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), common.Address{byte(j + 1)}, big.NewInt(1000), vars.TxGas, big.NewInt(1), nil), signer, testBankKey)
+			block.AddTx(tx)
+		}
+	}
+}
+
+// testSideTransferBlocks is a block generator sending a different amount than
+// testTransferBlocks, producing competing blocks for side chains.
+func testSideTransferBlocks(i int, block *core.BlockGen) {
+	tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), common.Address{0x01}, big.NewInt(5000), vars.TxGas, big.NewInt(1), nil), types.HomesteadSigner{}, testBankKey)
+	block.AddTx(tx)
+}
+
+// generateTestTraceChain generates a chain on top of the test genesis in its own
+// database, without importing it anywhere.
+func generateTestTraceChain(blocks int, generator func(int, *core.BlockGen)) []*types.Block {
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		gspec = &genesisT.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  genesisT.GenesisAlloc{testBank: {Balance: big.NewInt(vars.Ether)}},
+		}
+	)
+	chain, _ := core.GenerateChain(gspec.Config, core.MustCommitGenesis(db, gspec), ethash.NewFaker(), db, blocks, generator)
+	return chain
+}
+
+func TestForkNameAt(t *testing.T) {
+	tests := []struct {
+		config ctypes.ChainConfigurator
+		number uint64
+		want   string
+	}{
+		{params.MainnetChainConfig, 0, "frontier"},
+		{params.MainnetChainConfig, 1_150_000, "homestead"},
+		{params.MainnetChainConfig, 2_675_000, "spuriousDragon"},
+		{params.MainnetChainConfig, 4_370_000, "byzantium"},
+		{params.MainnetChainConfig, 7_280_000, "petersburg"},
+		{params.MainnetChainConfig, 9_069_000, "istanbul"},
+		{params.MainnetChainConfig, 9_200_000, "muirGlacier"},
+		{params.ClassicChainConfig, 0, "frontier"},
+		{params.ClassicChainConfig, 3_000_000, "dieHard"},
+		{params.ClassicChainConfig, 8_771_999, "defuse"},
+		{params.ClassicChainConfig, 8_772_000, "atlantis"},
+		{params.ClassicChainConfig, 9_573_000, "agharta"},
+		{params.ClassicChainConfig, 10_500_839, "phoenix"},
+		{params.ClassicChainConfig, 11_700_000, "thanos"},
+	}
+	for i, tt := range tests {
+		if have := forkNameAt(tt.config, new(big.Int).SetUint64(tt.number)); have != tt.want {
+			t.Errorf("test %d: fork name mismatch at block %d: have %q, want %q", i, tt.number, have, tt.want)
+		}
+	}
+}
+
 // BenchmarkTraceResultsAppend1 compares performance against BenchmarkTraceResultsAppend2,
 // comparing the performance of different ways of appending items to slices.
 // This is used in PrivateTraceAPI#Block appending results to the traceResults value.
@@ -39,3 +168,3760 @@ func BenchmarkTraceResultsAppend2(b *testing.B) {
 		results = append(results, traceResults...) // nolint:ineffassign
 	}
 }
+
+// Tests that each transaction trace appears exactly once in the output of
+// trace_block, and that duplicated traces are dropped.
+func TestTraceBlockUniqueTraces(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(3))
+	api := NewPrivateTraceAPI(eth)
+
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(1), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	seen := make(map[string]int)
+	for _, trace := range traces {
+		if trace, ok := trace.(map[string]interface{}); ok {
+			seen[fmt.Sprintf("%v:%v", trace["transactionPosition"], trace["traceAddress"])]++
+		}
+	}
+	if len(seen) != 3 {
+		t.Fatalf("transaction trace count mismatch: have %d, want %d", len(seen), 3)
+	}
+	for key, count := range seen {
+		if count != 1 {
+			t.Errorf("trace %s appeared %d times", key, count)
+		}
+	}
+	// Feed the helper overlapping results and ensure they're collapsed
+	dup := []interface{}{
+		map[string]interface{}{"transactionPosition": float64(0), "traceAddress": []interface{}{}},
+		map[string]interface{}{"transactionPosition": float64(0), "traceAddress": []interface{}{float64(0)}},
+		map[string]interface{}{"transactionPosition": float64(1), "traceAddress": []interface{}{}},
+		map[string]interface{}{"transactionPosition": float64(0), "traceAddress": []interface{}{}},
+		map[string]interface{}{"transactionPosition": float64(0), "traceAddress": []interface{}{float64(0)}},
+	}
+	if have := dedupeTransactionTraces(dup); len(have) != 3 {
+		t.Fatalf("deduplicated trace count mismatch: have %d, want %d", len(have), 3)
+	}
+}
+
+// Tests that trace_block only returns the requested fields and rejects unknown
+// ones.
+func TestTraceBlockFieldProjection(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(2))
+	api := NewPrivateTraceAPI(eth)
+
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{Fields: []string{"action.from", "action.to", "action.value", "transactionPosition"}})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	for i, trace := range traces[:2] {
+		trace := trace.(map[string]interface{})
+		if len(trace) != 2 {
+			t.Errorf("trace %d: field count mismatch: have %v", i, trace)
+		}
+		action, ok := trace["action"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("trace %d: missing action: %v", i, trace)
+		}
+		if from, _ := action["from"].(string); len(action) != 3 || !strings.EqualFold(from, testBank.Hex()) {
+			t.Errorf("trace %d: action mismatch: have %v", i, action)
+		}
+	}
+	// The block reward trace only carries the value out of the requested action fields
+	reward := traces[2].(map[string]interface{})
+	if action := reward["action"].(map[string]interface{}); len(action) != 1 || action["value"] == nil {
+		t.Errorf("reward action mismatch: have %v", action)
+	}
+	for _, field := range []string{"action.nonexistent", "bogus", "action.from.x", "type.x"} {
+		if _, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{Fields: []string{field}}); err == nil {
+			t.Errorf("field %q: expected error, got none", field)
+		}
+	}
+}
+
+// Tests that the keccak256 hash of the call input data can be emitted along
+// with, or instead of, the input itself.
+func TestTraceBlockInputHash(t *testing.T) {
+	input := []byte{0x01, 0x02, 0x03}
+	eth := newTestTraceBackend(t, 1, func(i int, block *core.BlockGen) {
+		signer := types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), common.Address{0x01}, big.NewInt(1000), 30000, big.NewInt(1), input), signer, testBankKey)
+		block.AddTx(tx)
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	tests := []struct {
+		config *TraceConfig
+		input  bool
+		hash   bool
+	}{
+		{&TraceConfig{}, true, false},
+		{&TraceConfig{IncludeInputHash: true}, true, true},
+		{&TraceConfig{IncludeInputHash: true, OmitInput: true}, false, true},
+	}
+	for i, tt := range tests {
+		traces, err := api.Block(context.Background(), rpc.BlockNumber(1), tt.config)
+		if err != nil {
+			t.Fatalf("test %d: failed to trace block: %v", i, err)
+		}
+		action := traces[0].(map[string]interface{})["action"].(map[string]interface{})
+		if _, ok := action["input"]; ok != tt.input {
+			t.Errorf("test %d: input presence mismatch: have %v, want %v", i, ok, tt.input)
+		}
+		hash, ok := action["inputHash"]
+		if ok != tt.hash {
+			t.Errorf("test %d: input hash presence mismatch: have %v, want %v", i, ok, tt.hash)
+		}
+		if ok && hash != crypto.Keccak256Hash(input) {
+			t.Errorf("test %d: input hash mismatch: have %v, want %v", i, hash, crypto.Keccak256Hash(input))
+		}
+	}
+	// The input hash can be projected like any other action field
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{IncludeInputHash: true, Fields: []string{"action.inputHash"}})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	action := traces[0].(map[string]interface{})["action"].(map[string]interface{})
+	if len(action) != 1 || action["inputHash"] != crypto.Keccak256Hash(input) {
+		t.Errorf("projected input hash mismatch: have %v, want %v", action, crypto.Keccak256Hash(input))
+	}
+}
+
+// Tests that the function selector of each call is added to the Parity traces
+// if requested, empty for calls with too short inputs and for creations.
+func TestTraceBlockSelector(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, func(i int, block *core.BlockGen) {
+		signer := types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		txs := []*types.Transaction{
+			types.NewTransaction(block.TxNonce(testBank), common.Address{0x01}, big.NewInt(1000), 30000, big.NewInt(1), []byte{0xa9, 0x05, 0x9c, 0xbb, 0x01}),
+			types.NewTransaction(block.TxNonce(testBank)+1, common.Address{0x01}, big.NewInt(1000), 30000, big.NewInt(1), []byte{0xa9, 0x05, 0x9c}),
+			types.NewContractCreation(block.TxNonce(testBank)+2, new(big.Int), 60000, big.NewInt(1), []byte{0x60, 0x00, 0x60, 0x00, 0xf3}),
+		}
+		for _, tx := range txs {
+			tx, _ = types.SignTx(tx, signer, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{IncludeSelector: true})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	blob, _ := json.Marshal(traces)
+	var decoded []struct {
+		Type   string                 `json:"type"`
+		Action map[string]interface{} `json:"action"`
+	}
+	if err := json.Unmarshal(blob, &decoded); err != nil {
+		t.Fatalf("failed to decode traces: %v", err)
+	}
+	for i, want := range []interface{}{"0xa9059cbb", "0x", "0x", nil} {
+		if have := decoded[i].Action["selector"]; have != want {
+			t.Errorf("trace %d (%s): selector mismatch: have %v, want %v", i, decoded[i].Type, have, want)
+		}
+	}
+	// Without the option, there's no selector
+	traces, err = api.Block(context.Background(), rpc.BlockNumber(1), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if _, ok := traces[0].(map[string]interface{})["action"].(map[string]interface{})["selector"]; ok {
+		t.Errorf("selector reported without being requested")
+	}
+}
+
+// Tests that the Parity traces optionally report the logs emitted directly by
+// each call frame, the logs of reverted frames being dropped like in receipts.
+func TestTraceBlockLogs(t *testing.T) {
+	var (
+		signer = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+
+		// A contract emitting an anonymous log and a log with two topics
+		emitter = []byte{
+			0x60, 0x00, 0x60, 0x00, 0xa0, // LOG0(0, 0)
+			0x60, 0x02, 0x60, 0x01, 0x60, 0x00, 0x60, 0x00, 0xa2, // LOG2(0, 0, 1, 2)
+			0x00, // STOP
+		}
+		// A contract emitting a log, then reverting
+		reverter = []byte{
+			0x60, 0x0c, 0x60, 0x00, 0x60, 0x00, 0xa1, // LOG1(0, 0, 0x0c)
+			0x60, 0x00, 0x60, 0x00, 0xfd, // REVERT(0, 0)
+		}
+		emitterAddr  = crypto.CreateAddress(testBank, 0)
+		reverterAddr = crypto.CreateAddress(testBank, 1)
+		caller       = crypto.CreateAddress(testBank, 2)
+	)
+	// A contract emitting a log with data, then calling both of the above
+	callerCode := []byte{
+		0x60, 0x42, 0x60, 0x00, 0x53, // MSTORE8(0, 0x42)
+		0x60, 0xaa, 0x60, 0x01, 0x60, 0x00, 0xa1, // LOG1(0, 1, 0xaa)
+	}
+	for _, callee := range []common.Address{emitterAddr, reverterAddr} {
+		callerCode = append(callerCode, 0x60, 0x00, 0x80, 0x80, 0x80, 0x80, 0x73)
+		callerCode = append(callerCode, callee.Bytes()...)
+		callerCode = append(callerCode, 0x5a, 0xf1, 0x50) // POP(CALL(GAS, callee, 0, 0, 0, 0, 0))
+	}
+	callerCode = append(callerCode, 0x00)
+
+	eth := newTestTraceBackend(t, 2, func(i int, block *core.BlockGen) {
+		var txs []*types.Transaction
+		switch i {
+		case 0:
+			for _, runtime := range [][]byte{emitter, reverter, callerCode} {
+				initcode := append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+				txs = append(txs, types.NewContractCreation(block.TxNonce(testBank)+uint64(len(txs)), new(big.Int), 200000, big.NewInt(1), initcode))
+			}
+		case 1:
+			txs = append(txs, types.NewTransaction(block.TxNonce(testBank), caller, new(big.Int), 200000, big.NewInt(1), nil))
+		}
+		for _, tx := range txs {
+			tx, _ = types.SignTx(tx, signer, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(2), &TraceConfig{IncludeLogs: true})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	blob, _ := json.Marshal(traces)
+	var decoded []struct {
+		Type  string             `json:"type"`
+		Error string             `json:"error"`
+		Logs  *[]json.RawMessage `json:"logs"`
+	}
+	if err := json.Unmarshal(blob, &decoded); err != nil {
+		t.Fatalf("failed to decode traces: %v", err)
+	}
+	if len(decoded) != 4 {
+		t.Fatalf("trace count mismatch: have %d, want %d", len(decoded), 4)
+	}
+	receipt := eth.blockchain.GetReceiptsByHash(eth.blockchain.GetBlockByNumber(2).Hash())[0]
+	marshal := func(logs []*types.Log) []string {
+		var blobs []string
+		for _, log := range logs {
+			blob, _ := json.Marshal(map[string]interface{}{"address": log.Address, "topics": log.Topics, "data": hexutil.Bytes(log.Data)})
+			blobs = append(blobs, string(blob))
+		}
+		return blobs
+	}
+	// The caller's and the emitter's logs are the ones of the receipt, the
+	// reverter's log is dropped and the reward trace carries none
+	for i, want := range [][]*types.Log{receipt.Logs[:1], receipt.Logs[1:], {}} {
+		if decoded[i].Logs == nil {
+			t.Fatalf("trace %d (%s): logs missing", i, decoded[i].Type)
+		}
+		var have []string
+		for _, log := range *decoded[i].Logs {
+			var canonical map[string]interface{}
+			json.Unmarshal(log, &canonical)
+			blob, _ := json.Marshal(canonical)
+			have = append(have, string(blob))
+		}
+		if !reflect.DeepEqual(have, marshal(want)) {
+			t.Errorf("trace %d (%s): logs mismatch:\nhave %v\nwant %v", i, decoded[i].Type, have, marshal(want))
+		}
+	}
+	if len(receipt.Logs) != 3 || decoded[2].Error == "" {
+		t.Errorf("unexpected execution: %d receipt logs, reverter error %q", len(receipt.Logs), decoded[2].Error)
+	}
+	if decoded[3].Logs != nil {
+		t.Errorf("reward trace carries logs")
+	}
+	// Without the option, there are no logs
+	traces, err = api.Block(context.Background(), rpc.BlockNumber(2), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if _, ok := traces[0].(map[string]interface{})["logs"]; ok {
+		t.Errorf("logs reported without being requested")
+	}
+}
+
+// Tests that failed contract creations are traced with their error but without
+// deployed code or a contract address, along with the gas they burnt if requested.
+// Like in OpenEthereum, no create action has a recipient.
+func TestTraceFailedCreate(t *testing.T) {
+	var (
+		signer = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+
+		reverter = []byte{0x60, 0x00, 0x60, 0x00, 0xfd} // REVERT(0, 0)
+		looper   = []byte{0x5b, 0x60, 0x00, 0x56}       // JUMPDEST, JUMP(0)
+	)
+	// Contracts creating a contract with the reverting constructor, deployed
+	// with nonces 2 and 3
+	factories := [][]byte{
+		{
+			0x64, 0x60, 0x00, 0x60, 0x00, 0xfd, 0x60, 0x00, 0x52, // MSTORE(0, reverter)
+			0x60, 0x05, 0x60, 0x1b, 0x60, 0x00, 0xf0, 0x00, // CREATE(0, 27, 5), STOP
+		},
+		{
+			0x64, 0x60, 0x00, 0x60, 0x00, 0xfd, 0x60, 0x00, 0x52, // MSTORE(0, reverter)
+			0x60, 0x00, 0x60, 0x05, 0x60, 0x1b, 0x60, 0x00, 0xf5, 0x00, // CREATE2(0, 27, 5, 0), STOP
+		},
+	}
+	eth := newTestTraceBackend(t, 2, func(i int, block *core.BlockGen) {
+		var txs []*types.Transaction
+		switch i {
+		case 0:
+			codes := [][]byte{reverter, looper}
+			for _, code := range factories {
+				codes = append(codes, append([]byte{0x60, byte(len(code)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(code)), 0x60, 0x00, 0xf3}, code...))
+			}
+			for _, code := range codes {
+				txs = append(txs, types.NewContractCreation(block.TxNonce(testBank)+uint64(len(txs)), new(big.Int), 100000, big.NewInt(1), code))
+			}
+		case 1:
+			for j := range factories {
+				factory := crypto.CreateAddress(testBank, uint64(2+j))
+				txs = append(txs, types.NewTransaction(block.TxNonce(testBank)+uint64(len(txs)), factory, new(big.Int), 200000, big.NewInt(1), nil))
+			}
+		}
+		for _, tx := range txs {
+			tx, _ = types.SignTx(tx, signer, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	traceCreates := func(config *TraceConfig) []map[string]interface{} {
+		var creates []map[string]interface{}
+		for _, number := range []rpc.BlockNumber{1, 2} {
+			traces, err := api.Block(context.Background(), number, config)
+			if err != nil {
+				t.Fatalf("failed to trace block #%d: %v", number, err)
+			}
+			blob, _ := json.Marshal(traces)
+			var decoded []map[string]interface{}
+			if err := json.Unmarshal(blob, &decoded); err != nil {
+				t.Fatalf("failed to decode traces: %v", err)
+			}
+			for _, trace := range decoded {
+				if trace["type"] == "create" {
+					creates = append(creates, trace)
+				}
+			}
+		}
+		if len(creates) != 6 {
+			t.Fatalf("create trace count mismatch: have %d, want 6", len(creates))
+		}
+		return creates
+	}
+	// By default, failed creations have no result, like in OpenEthereum
+	for i, create := range traceCreates(nil) {
+		if _, failed := create["error"]; failed && create["result"] != nil {
+			t.Errorf("create %d: failed create reports a result: %v", i, create["result"])
+		}
+	}
+	creates := traceCreates(&TraceConfig{IncludeFailedCreateGas: true})
+	tests := []struct {
+		method  string
+		error   string
+		gasUsed string // Empty for all the gas provided
+	}{
+		{method: "create", error: "Reverted", gasUsed: "0x6"},
+		{method: "create", error: "Out of gas"},
+		{method: "create"},
+		{method: "create"},
+		{method: "create", error: "Reverted", gasUsed: "0x6"},
+		{method: "create2", error: "Reverted", gasUsed: "0x6"},
+	}
+	for i, tt := range tests {
+		action, _ := creates[i]["action"].(map[string]interface{})
+		result, _ := creates[i]["result"].(map[string]interface{})
+		if to, ok := action["to"]; ok {
+			t.Errorf("create %d: action has a recipient: %v", i, to)
+		}
+		if have := action["creationMethod"]; have != tt.method {
+			t.Errorf("create %d: creation method mismatch: have %v, want %s", i, have, tt.method)
+		}
+		if have, _ := creates[i]["error"].(string); have != tt.error {
+			t.Errorf("create %d: error mismatch: have %q, want %q", i, have, tt.error)
+		}
+		if tt.error == "" {
+			if result["code"] == nil || result["address"] == nil {
+				t.Errorf("create %d: missing deployed code or address: %v", i, result)
+			}
+			continue
+		}
+		if result["code"] != nil || result["address"] != nil {
+			t.Errorf("create %d: failed create reports deployed code or address: %v", i, result)
+		}
+		want := tt.gasUsed
+		if want == "" {
+			want, _ = action["gas"].(string)
+		}
+		if have := result["gasUsed"]; have != want {
+			t.Errorf("create %d: gas used mismatch: have %v, want %v", i, have, want)
+		}
+	}
+}
+
+// Tests that trace_call executes from the requested sender, without requiring
+// its key, using the balance it holds in the selected state.
+func TestTraceCallFrom(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(1))
+	api := NewPrivateTraceAPI(eth)
+
+	var (
+		to    = common.Address{0xff}
+		value = (*hexutil.Big)(big.NewInt(vars.GWei))
+	)
+	for _, from := range []common.Address{testBank, {0xee}} {
+		from := from
+		args := ethapi.CallArgs{From: &from, To: &to, Value: value}
+		res, err := api.Call(context.Background(), args, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), nil)
+		if err != nil {
+			t.Fatalf("from %x: failed to trace call: %v", from, err)
+		}
+		var traces []map[string]interface{}
+		if err := json.Unmarshal(res.(json.RawMessage), &traces); err != nil {
+			t.Fatalf("from %x: failed to decode traces: %v", from, err)
+		}
+		action := traces[0]["action"].(map[string]interface{})
+		if have := common.HexToAddress(action["from"].(string)); have != from {
+			t.Errorf("sender mismatch: have %x, want %x", have, from)
+		}
+	}
+}
+
+// Tests that trace_call optionally returns the access list generated by the call,
+// listing the storage slots accessed by account, but leaving out the sender, the
+// recipient and the precompiled contracts unless their storage is accessed.
+func TestTraceCallAccessList(t *testing.T) {
+	var (
+		// A contract reading and writing its storage, querying a balance and
+		// calling both a precompiled contract and a plain account
+		runtime = []byte{
+			0x60, 0x01, 0x54, 0x50, // POP(SLOAD(1))
+			0x60, 0x01, 0x60, 0x02, 0x55, // SSTORE(2, 1)
+			0x60, 0xaa, 0x31, 0x50, // POP(BALANCE(0xaa))
+			0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x02, 0x5a, 0xf1, 0x50, // POP(CALL(GAS, 0x02, 0, 0, 0, 0, 0))
+			0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0xbb, 0x5a, 0xf1, 0x50, // POP(CALL(GAS, 0xbb, 0, 0, 0, 0, 0))
+			0x00, // STOP
+		}
+		initcode = append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+		contract = crypto.CreateAddress(testBank, 0)
+		signer   = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+	)
+	eth := newTestTraceBackend(t, 1, func(i int, block *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 100000, big.NewInt(1), initcode), signer, testBankKey)
+		block.AddTx(tx)
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	from := testBank
+	args := ethapi.CallArgs{From: &from, To: &contract}
+	res, err := api.Call(context.Background(), args, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), &TraceConfig{IncludeAccessList: true})
+	if err != nil {
+		t.Fatalf("failed to trace call: %v", err)
+	}
+	blob, _ := json.Marshal(res)
+	var result struct {
+		Trace      []map[string]interface{} `json:"trace"`
+		AccessList []TraceAccessTuple       `json:"accessList"`
+	}
+	if err := json.Unmarshal(blob, &result); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	// The precompile call is not traced, like in Parity
+	if len(result.Trace) != 2 {
+		t.Errorf("trace count mismatch: have %d, want %d", len(result.Trace), 2)
+	}
+	want := []TraceAccessTuple{
+		{Address: contract, StorageKeys: []common.Hash{common.BigToHash(big.NewInt(1)), common.BigToHash(big.NewInt(2))}},
+		{Address: common.BigToAddress(big.NewInt(0xaa)), StorageKeys: []common.Hash{}},
+		{Address: common.BigToAddress(big.NewInt(0xbb)), StorageKeys: []common.Hash{}},
+	}
+	if !reflect.DeepEqual(result.AccessList, want) {
+		t.Errorf("access list mismatch:\nhave %+v\nwant %+v", result.AccessList, want)
+	}
+	// Without the option, the traces are returned as is
+	res, err = api.Call(context.Background(), args, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), nil)
+	if err != nil {
+		t.Fatalf("failed to trace call: %v", err)
+	}
+	if _, ok := res.(json.RawMessage); !ok {
+		t.Errorf("result type mismatch: have %T, want json.RawMessage", res)
+	}
+	// The option is specific to trace_call
+	if _, err := api.CallMany(context.Background(), []ethapi.CallArgs{args}, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), &TraceConfig{IncludeAccessList: true}); err == nil {
+		t.Errorf("expected trace_callMany with an access list to fail")
+	}
+	if _, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{IncludeAccessList: true}); err == nil {
+		t.Errorf("expected trace_block with an access list to fail")
+	}
+}
+
+// Tests that trace_call and trace_callMany execute in the context of the traced
+// block unless overridden, the block context opcodes returning the pinned values.
+func TestTraceCallBlockOverrides(t *testing.T) {
+	var (
+		signer = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+
+		// A contract returning its block context
+		runtime = []byte{
+			0x42, 0x60, 0x00, 0x52, // MSTORE(0, TIMESTAMP)
+			0x43, 0x60, 0x20, 0x52, // MSTORE(32, NUMBER)
+			0x41, 0x60, 0x40, 0x52, // MSTORE(64, COINBASE)
+			0x45, 0x60, 0x60, 0x52, // MSTORE(96, GASLIMIT)
+			0x44, 0x60, 0x80, 0x52, // MSTORE(128, DIFFICULTY)
+			0x60, 0xa0, 0x60, 0x00, 0xf3, // RETURN(0, 160)
+		}
+		initcode = append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+		contract = crypto.CreateAddress(testBank, 0)
+	)
+	eth := newTestTraceBackend(t, 1, func(i int, block *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 100000, big.NewInt(1), initcode), signer, testBankKey)
+		block.AddTx(tx)
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	blockContext := func(timestamp, number uint64, coinbase common.Address, gasLimit uint64, difficulty *big.Int) string {
+		output := append(common.LeftPadBytes(new(big.Int).SetUint64(timestamp).Bytes(), 32), common.LeftPadBytes(new(big.Int).SetUint64(number).Bytes(), 32)...)
+		output = append(output, common.LeftPadBytes(coinbase.Bytes(), 32)...)
+		output = append(output, common.LeftPadBytes(new(big.Int).SetUint64(gasLimit).Bytes(), 32)...)
+		return hexutil.Encode(append(output, common.LeftPadBytes(difficulty.Bytes(), 32)...))
+	}
+	output := func(res interface{}) string {
+		var traces []map[string]interface{}
+		if err := json.Unmarshal(res.(json.RawMessage), &traces); err != nil {
+			t.Fatalf("failed to decode traces: %v", err)
+		}
+		return traces[0]["result"].(map[string]interface{})["output"].(string)
+	}
+	var (
+		from  = testBank
+		args  = ethapi.CallArgs{From: &from, To: &contract}
+		block = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		head  = eth.blockchain.CurrentBlock().Header()
+	)
+	// Without overrides, the call executes in the context of the traced block
+	res, err := api.Call(context.Background(), args, block, nil)
+	if err != nil {
+		t.Fatalf("failed to trace call: %v", err)
+	}
+	if have, want := output(res), blockContext(head.Time, head.Number.Uint64(), head.Coinbase, head.GasLimit, head.Difficulty); have != want {
+		t.Errorf("block context mismatch:\nhave %s\nwant %s", have, want)
+	}
+	// With overrides, the pinned values are returned, by every call of a batch
+	var (
+		timestamp  = hexutil.Uint64(1600000000)
+		gasLimit   = hexutil.Uint64(12345678)
+		coinbase   = common.Address{0xc0}
+		number     = (*hexutil.Big)(big.NewInt(1000))
+		difficulty = (*hexutil.Big)(big.NewInt(42))
+		config     = &TraceConfig{BlockOverrides: &TraceBlockOverrides{Number: number, Time: &timestamp, GasLimit: &gasLimit, Coinbase: &coinbase, Difficulty: difficulty}}
+		want       = blockContext(uint64(timestamp), 1000, coinbase, uint64(gasLimit), big.NewInt(42))
+	)
+	if res, err = api.Call(context.Background(), args, block, config); err != nil {
+		t.Fatalf("failed to trace call: %v", err)
+	}
+	if have := output(res); have != want {
+		t.Errorf("overridden block context mismatch:\nhave %s\nwant %s", have, want)
+	}
+	if res, err = api.CallMany(context.Background(), []ethapi.CallArgs{args, args}, block, config); err != nil {
+		t.Fatalf("failed to trace calls: %v", err)
+	}
+	for i, res := range res.([]interface{}) {
+		if have := output(res); have != want {
+			t.Errorf("call %d: overridden block context mismatch:\nhave %s\nwant %s", i, have, want)
+		}
+	}
+	// Transactions of the chain are always traced in their original context
+	if _, err := api.Block(context.Background(), rpc.BlockNumber(1), config); err == nil {
+		t.Errorf("expected trace_block with block overrides to fail")
+	}
+}
+
+// Tests that transactions which could not be traced at all still produce a
+// single errored root trace, keeping the traces aligned with the transactions.
+func TestParityTransactionTracesErrored(t *testing.T) {
+	var (
+		signer = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		to     = common.Address{0x01}
+	)
+	tx1, _ := types.SignTx(types.NewTransaction(0, to, big.NewInt(1), vars.TxGas, big.NewInt(1), nil), signer, testBankKey)
+	tx2, _ := types.SignTx(types.NewTransaction(1, to, big.NewInt(2), 1000, big.NewInt(1), []byte{0x01}), signer, testBankKey)
+	tx3, _ := types.SignTx(types.NewContractCreation(2, big.NewInt(0), 1000, big.NewInt(1), []byte{0x00}), signer, testBankKey)
+
+	block := types.NewBlock(&types.Header{Number: big.NewInt(1)}, []*types.Transaction{tx1, tx2, tx3}, nil, nil, new(trie.Trie))
+	results := []*txTraceResult{
+		{Result: json.RawMessage(`[{"type":"call","transactionPosition":0,"traceAddress":[]},{"type":"call","transactionPosition":0,"traceAddress":[0]}]`)},
+		{Error: core.ErrIntrinsicGas.Error()},
+		{Error: core.ErrIntrinsicGas.Error()},
+	}
+	traces, err := parityTransactionTraces(params.TestChainConfig, block, results)
+	if err != nil {
+		t.Fatalf("failed to assemble traces: %v", err)
+	}
+	if len(traces) != 4 {
+		t.Fatalf("trace count mismatch: have %d, want %d", len(traces), 4)
+	}
+	for i, want := range []struct {
+		position uint64
+		typ      string
+	}{{1, "call"}, {2, "create"}} {
+		trace := traces[2+i].(map[string]interface{})
+		if trace["error"] != core.ErrIntrinsicGas.Error() || trace["type"] != want.typ || trace["transactionPosition"] != want.position {
+			t.Errorf("errored trace %d mismatch: have %v", i, trace)
+		}
+		if from := trace["action"].(map[string]interface{})["from"]; !strings.EqualFold(from.(string), testBank.Hex()) {
+			t.Errorf("errored trace %d sender mismatch: have %v, want %x", i, from, testBank)
+		}
+	}
+}
+
+// Tests that assembling the Parity traces of a block fails gracefully, instead
+// of panicking, on trace results not produced by a JavaScript tracer.
+func TestParityTransactionTracesUnexpectedResult(t *testing.T) {
+	signer := types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+	tx, _ := types.SignTx(types.NewTransaction(0, common.Address{0x01}, big.NewInt(1), vars.TxGas, big.NewInt(1), nil), signer, testBankKey)
+
+	block := types.NewBlock(&types.Header{Number: big.NewInt(1)}, []*types.Transaction{tx}, nil, nil, new(trie.Trie))
+	results := []*txTraceResult{
+		{Result: &ethapi.ExecutionResult{Gas: vars.TxGas}},
+	}
+	if _, err := parityTransactionTraces(params.TestChainConfig, block, results); err == nil {
+		t.Fatalf("expected non Parity trace result to fail")
+	}
+}
+
+// Tests that trace_block can be restricted to the transactions of one sender.
+func TestTraceBlockSender(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	eth := newTestTraceBackend(t, 1, func(i int, block *core.BlockGen) {
+		signer := types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), addr, big.NewInt(vars.GWei), vars.TxGas, big.NewInt(1), nil), signer, testBankKey)
+		block.AddTx(tx)
+		tx, _ = types.SignTx(types.NewTransaction(block.TxNonce(addr), testBank, big.NewInt(1000), vars.TxGas, big.NewInt(1), nil), signer, key)
+		block.AddTx(tx)
+		tx, _ = types.SignTx(types.NewTransaction(block.TxNonce(testBank), addr, big.NewInt(1000), vars.TxGas, big.NewInt(1), nil), signer, testBankKey)
+		block.AddTx(tx)
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	for _, tt := range []struct {
+		sender    common.Address
+		positions []float64
+	}{
+		{testBank, []float64{0, 2}},
+		{addr, []float64{1}},
+		{common.Address{0xff}, nil},
+	} {
+		sender := tt.sender
+		traces, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{Sender: &sender})
+		if err != nil {
+			t.Fatalf("sender %x: failed to trace block: %v", sender, err)
+		}
+		var positions []float64
+		for _, trace := range traces {
+			if trace, ok := trace.(map[string]interface{}); ok {
+				positions = append(positions, trace["transactionPosition"].(float64))
+			}
+		}
+		if fmt.Sprint(positions) != fmt.Sprint(tt.positions) {
+			t.Errorf("sender %x: traced positions mismatch: have %v, want %v", sender, positions, tt.positions)
+		}
+	}
+}
+
+// Tests that trace_block can be restricted to the transactions at the given
+// indices, still executing the ones preceding them.
+func TestTraceBlockTransactions(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(4))
+	api := NewPrivateTraceAPI(eth)
+
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{Transactions: []uint64{3, 1}})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	var positions []float64
+	for i, trace := range traces {
+		trace, ok := trace.(map[string]interface{})
+		if !ok {
+			t.Fatalf("trace %d: unexpected reward trace", i)
+		}
+		if failure, ok := trace["error"]; ok {
+			t.Errorf("trace %d: unexpected failure: %v", i, failure)
+		}
+		positions = append(positions, trace["transactionPosition"].(float64))
+	}
+	if fmt.Sprint(positions) != fmt.Sprint([]float64{1, 3}) {
+		t.Errorf("traced positions mismatch: have %v, want %v", positions, []float64{1, 3})
+	}
+	if _, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{Transactions: []uint64{4}}); err == nil {
+		t.Errorf("expected out of range transaction index to fail")
+	}
+}
+
+// Tests that trace_block appends a summary of the traced block if requested.
+func TestTraceBlockSummary(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(2))
+	api := NewPrivateTraceAPI(eth)
+
+	plain, err := api.Block(context.Background(), rpc.BlockNumber(1), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{IncludeBlockSummary: true})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if len(traces) != len(plain)+1 {
+		t.Fatalf("trace count mismatch: have %d, want %d", len(traces), len(plain)+1)
+	}
+	block := eth.blockchain.GetBlockByNumber(1)
+	checksum, err := traceChecksum(plain)
+	if err != nil {
+		t.Fatalf("failed to checksum traces: %v", err)
+	}
+	want := &TraceBlockSummary{
+		Type:             "blockSummary",
+		BlockNumber:      1,
+		BlockHash:        block.Hash(),
+		GasUsed:          hexutil.Uint64(2 * vars.TxGas),
+		TransactionCount: 2,
+		Checksum:         checksum,
+	}
+	if summary := traces[len(traces)-1].(*TraceBlockSummary); !reflect.DeepEqual(summary, want) {
+		t.Errorf("summary mismatch: have %+v, want %+v", summary, want)
+	}
+}
+
+// Tests that the trace checksums of the block summaries only depend on the
+// traces, not on their serialization or execution times.
+func TestTraceBlockChecksum(t *testing.T) {
+	eth := newTestTraceBackend(t, 2, testTransferBlocks(2))
+	api := NewPrivateTraceAPI(eth)
+
+	checksum := func(number rpc.BlockNumber) common.Hash {
+		traces, err := api.Block(context.Background(), number, &TraceConfig{IncludeBlockSummary: true})
+		if err != nil {
+			t.Fatalf("failed to trace block %d: %v", number, err)
+		}
+		return traces[len(traces)-1].(*TraceBlockSummary).Checksum
+	}
+	if first, second := checksum(1), checksum(1); first != second {
+		t.Errorf("checksum of block 1 unstable: %x != %x", first, second)
+	}
+	if checksum(1) == checksum(2) {
+		t.Errorf("checksums of blocks 1 and 2 collide")
+	}
+	// Key order and execution times don't affect the checksum
+	have, err := traceChecksum([]interface{}{json.RawMessage(`{"type":"call","time":"1.2ms","subtraces":0}`)})
+	if err != nil {
+		t.Fatalf("failed to checksum traces: %v", err)
+	}
+	if want := crypto.Keccak256Hash([]byte(`[{"subtraces":0,"type":"call"}]`)); have != want {
+		t.Errorf("checksum mismatch: have %x, want %x", have, want)
+	}
+}
+
+// Tests that trace_block can return its traces as flat rows.
+func TestTraceBlockRows(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(2))
+	api := NewPrivateTraceAPI(eth)
+
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{Format: traceFormatRows})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if len(traces) != 3 {
+		t.Fatalf("row count mismatch: have %d, want %d", len(traces), 3)
+	}
+	for i, trace := range traces[:2] {
+		row := trace.(*ParityTraceRow)
+		if row.Type != "call" || !strings.EqualFold(row.From, testBank.Hex()) || row.TraceAddress != "" || row.TransactionPosition != float64(i) {
+			t.Errorf("row %d mismatch: have %+v", i, row)
+		}
+		if want := hexutil.EncodeBig(big.NewInt(1000)); row.Value != want {
+			t.Errorf("row %d value mismatch: have %s, want %s", i, row.Value, want)
+		}
+	}
+	reward := traces[2].(*ParityTraceRow)
+	if block := eth.blockchain.GetBlockByNumber(1); reward.Type != "reward" || common.HexToAddress(reward.To) != block.Coinbase() {
+		t.Errorf("reward row mismatch: have %+v", reward)
+	}
+	if _, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{Format: "csv"}); err == nil {
+		t.Errorf("expected error for unknown format")
+	}
+	if _, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{Format: traceFormatRows, Fields: []string{"type"}}); err == nil {
+		t.Errorf("expected error for projecting rows")
+	}
+}
+
+// Tests that trace_callMany stops tracing the batch once its context is done,
+// failing with the context error along with the partial results, the calls left
+// untraced marked with the context error.
+func TestTraceCallManyDeadline(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(1))
+	api := NewPrivateTraceAPI(eth)
+
+	to := common.Address{0xff}
+	txs := []ethapi.CallArgs{{From: &testBank, To: &to}, {From: &testBank, To: &to}, {From: &testBank, To: &to}}
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	res, err := api.CallMany(context.Background(), txs, latest, nil)
+	if err != nil {
+		t.Fatalf("failed to trace calls: %v", err)
+	}
+	for i, result := range res.([]interface{}) {
+		if _, ok := result.(json.RawMessage); !ok {
+			t.Errorf("call %d: unexpected result %v", i, result)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	res, err = api.CallMany(ctx, txs, latest, nil)
+	if res != nil {
+		t.Errorf("unexpected result along with the deadline error: %v", res)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error mismatch: have %v, want %v", err, context.DeadlineExceeded)
+	}
+	// The partial results are returned as the data of the JSON-RPC error
+	data, ok := err.(rpc.DataError)
+	if !ok {
+		t.Fatalf("deadline error carries no data: %v", err)
+	}
+	results, _ := data.ErrorData().([]interface{})
+	if len(results) != len(txs) {
+		t.Fatalf("result count mismatch: have %d, want %d", len(results), len(txs))
+	}
+	for i, result := range results {
+		if result, ok := result.(*txTraceResult); !ok || result.Error != context.DeadlineExceeded.Error() {
+			t.Errorf("call %d: expected deadline error, have %v", i, result)
+		}
+	}
+}
+
+// Tests that trace_callMany reports the failed calls of a batch in place along
+// with the traces of the successful ones, unless strict calls were requested.
+func TestTraceCallManyPartialFailures(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(1))
+	api := NewPrivateTraceAPI(eth)
+
+	var (
+		to       = common.Address{0xff}
+		reverter = common.Address{0xfd}
+		code     = hexutil.Bytes{0x60, 0x00, 0x60, 0x00, 0xfd} // REVERT(0, 0)
+		lowGas   = hexutil.Uint64(1000)
+		latest   = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+		ok       = ethapi.CallArgs{From: &testBank, To: &to}
+		unfunded = ethapi.CallArgs{From: &testBank, To: &to, Gas: &lowGas} // Not covering the intrinsic gas
+		reverted = ethapi.CallArgs{From: &testBank, To: &reverter}
+	)
+	config := func(strict bool) *TraceConfig {
+		return &TraceConfig{
+			StateOverrides: &ethapi.StateOverride{reverter: ethapi.OverrideAccount{Code: &code}},
+			StrictCalls:    strict,
+		}
+	}
+	res, err := api.CallMany(context.Background(), []ethapi.CallArgs{ok, unfunded, reverted, ok}, latest, config(false))
+	if err != nil {
+		t.Fatalf("failed to trace calls: %v", err)
+	}
+	results := res.([]interface{})
+	if len(results) != 4 {
+		t.Fatalf("result count mismatch: have %d, want 4", len(results))
+	}
+	for i, want := range map[int]string{0: "", 2: "Reverted", 3: ""} {
+		raw, ok := results[i].(json.RawMessage)
+		if !ok {
+			t.Errorf("call %d: unexpected result %v", i, results[i])
+			continue
+		}
+		if failure := callTraceFailure(raw); failure != want {
+			t.Errorf("call %d: trace error mismatch: have %q, want %q", i, failure, want)
+		}
+	}
+	if result, ok := results[1].(*txTraceResult); !ok || result.Error == "" {
+		t.Errorf("call 1: expected in place error, have %v", results[1])
+	}
+	// Strict batches fail on their first failed call, be it unexecutable or reverted
+	tests := []struct {
+		calls []ethapi.CallArgs
+		err   string
+	}{
+		{calls: []ethapi.CallArgs{ok, unfunded, reverted, ok}, err: "call 1 failed"},
+		{calls: []ethapi.CallArgs{ok, reverted, ok}, err: "call 1 failed: Reverted"},
+		{calls: []ethapi.CallArgs{ok, ok}},
+	}
+	for i, tt := range tests {
+		_, err := api.CallMany(context.Background(), tt.calls, latest, config(true))
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("test %d: failed to trace strict calls: %v", i, err)
+		case tt.err != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.err)):
+			t.Errorf("test %d: error mismatch: have %v, want %s", i, err, tt.err)
+		}
+	}
+	if _, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{StrictCalls: true}); err == nil {
+		t.Errorf("expected error for strict calls on trace_block")
+	}
+}
+
+// Tests that trace_callMany rejects batches holding more calls than allowed.
+func TestTraceCallManyLimit(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(1))
+	config := DefaultConfig
+	config.Trace.CallManyLimit = 2
+	eth.config = &config
+	api := NewPrivateTraceAPI(eth)
+
+	to := common.Address{0xff}
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	txs := []ethapi.CallArgs{{From: &testBank, To: &to}, {From: &testBank, To: &to}}
+	if _, err := api.CallMany(context.Background(), txs, latest, nil); err != nil {
+		t.Fatalf("failed to trace calls within the limit: %v", err)
+	}
+	txs = append(txs, ethapi.CallArgs{From: &testBank, To: &to})
+	if _, err := api.CallMany(context.Background(), txs, latest, nil); err == nil {
+		t.Errorf("expected error for calls exceeding the limit")
+	}
+	// A zero limit disables the check
+	config.Trace.CallManyLimit = 0
+	if _, err := api.CallMany(context.Background(), txs, latest, nil); err != nil {
+		t.Errorf("failed to trace calls without a limit: %v", err)
+	}
+}
+
+// Tests that uncle reward traces can report the uncle number and depth used in
+// their reward calculation.
+func TestTraceBlockUncleDetails(t *testing.T) {
+	eth := newTestTraceBackend(t, 4, func(i int, block *core.BlockGen) {
+		if i == 3 {
+			// Block 4 includes blocks 2 and 3 as uncle headers (with modified extra data)
+			for _, n := range []int{1, 2} {
+				uncle := block.PrevBlock(n).Header()
+				uncle.Extra = []byte("uncle")
+				block.AddUncle(uncle)
+			}
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	for _, details := range []bool{false, true} {
+		traces, err := api.Block(context.Background(), rpc.BlockNumber(4), &TraceConfig{IncludeUncleDetails: details})
+		if err != nil {
+			t.Fatalf("failed to trace block: %v", err)
+		}
+		if len(traces) != 3 {
+			t.Fatalf("trace count mismatch: have %d, want %d", len(traces), 3)
+		}
+		for i, want := range []struct{ number, depth uint64 }{{2, 2}, {3, 1}} {
+			action := traces[1+i].(*ParityTrace).Action
+			if action.RewardType != "uncle" {
+				t.Fatalf("trace %d: reward type mismatch: have %s, want uncle", 1+i, action.RewardType)
+			}
+			if !details {
+				if action.UncleNumber != nil || action.UncleDepth != nil {
+					t.Errorf("uncle %d: unrequested details reported", i)
+				}
+				continue
+			}
+			if action.UncleNumber == nil || uint64(*action.UncleNumber) != want.number {
+				t.Errorf("uncle %d: number mismatch: have %v, want %d", i, action.UncleNumber, want.number)
+			}
+			if action.UncleDepth == nil || uint64(*action.UncleDepth) != want.depth {
+				t.Errorf("uncle %d: depth mismatch: have %v, want %d", i, action.UncleDepth, want.depth)
+			}
+			// The uncle reward is (8 - depth) / 8 of the block reward
+			reward := new(big.Int).Mul(big.NewInt(int64(8-want.depth)), ctypes.EthashBlockReward(params.TestChainConfig, big.NewInt(4)))
+			if reward.Div(reward, big.NewInt(8)); action.Value.ToInt().Cmp(reward) != 0 {
+				t.Errorf("uncle %d: reward mismatch: have %v, want %v", i, action.Value, reward)
+			}
+		}
+	}
+}
+
+// Tests that the traces of trace_block and trace_filter can be tagged with the
+// chain id of the node's chain.
+func TestTraceBlockChainID(t *testing.T) {
+	eth := newTestTraceBackend(t, 2, testTransferBlocks(2))
+	api := NewPrivateTraceAPI(eth)
+	compat := NewPrivateTraceCompatAPI(eth)
+
+	want := (*hexutil.Big)(params.TestChainConfig.GetChainID()).String()
+	for _, include := range []bool{false, true} {
+		block, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{IncludeChainID: include})
+		if err != nil {
+			t.Fatalf("failed to trace block: %v", err)
+		}
+		filter, err := compat.Filter(context.Background(), TraceFilterArgs{FromBlock: 1, ToBlock: 2}, &TraceConfig{IncludeChainID: include})
+		if err != nil {
+			t.Fatalf("failed to filter traces: %v", err)
+		}
+		if len(block) != 3 || len(filter) != 6 {
+			t.Fatalf("trace count mismatch: have %d block and %d filter traces, want 3 and 6", len(block), len(filter))
+		}
+		blob, _ := json.Marshal(append(block, filter...))
+		var decoded []map[string]interface{}
+		if err := json.Unmarshal(blob, &decoded); err != nil {
+			t.Fatalf("failed to decode traces: %v", err)
+		}
+		for i, trace := range decoded {
+			id, ok := trace["chainId"]
+			switch {
+			case include && id != want:
+				t.Errorf("trace %d (%v): chain id mismatch: have %v, want %s", i, trace["type"], id, want)
+			case !include && ok:
+				t.Errorf("trace %d (%v): chain id reported without being requested", i, trace["type"])
+			}
+		}
+	}
+}
+
+// Tests that the reward traces abort with the context's error once it's done,
+// keeping trace_block cancellable all the way through.
+func TestTraceBlockRewardCancellation(t *testing.T) {
+	eth := newTestTraceBackend(t, 2, func(i int, block *core.BlockGen) {
+		if i == 1 {
+			uncle := block.PrevBlock(0).Header()
+			uncle.Extra = []byte("uncle")
+			block.AddUncle(uncle)
+		}
+	})
+	block := eth.blockchain.GetBlockByNumber(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := traceBlockReward(ctx, eth, block, nil); err != nil {
+		t.Fatalf("failed to trace block reward: %v", err)
+	}
+	if rewards, err := traceBlockUncleRewards(ctx, eth, block, nil); err != nil || len(rewards) != 1 {
+		t.Fatalf("failed to trace uncle rewards: %d rewards, %v", len(rewards), err)
+	}
+	cancel()
+	if _, err := traceBlockReward(ctx, eth, block, nil); err != context.Canceled {
+		t.Errorf("block reward error mismatch: have %v, want %v", err, context.Canceled)
+	}
+	if _, err := traceBlockUncleRewards(ctx, eth, block, nil); err != context.Canceled {
+		t.Errorf("uncle rewards error mismatch: have %v, want %v", err, context.Canceled)
+	}
+	if _, err := NewPrivateTraceAPI(eth).Block(ctx, rpc.BlockNumber(2), nil); err == nil {
+		t.Errorf("cancelled block trace succeeded")
+	}
+}
+
+// Tests that the nested trace output option, which only applies to the ad-hoc
+// tracing methods, is rejected by trace_block.
+func TestTraceBlockRejectsNestedOutput(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(1))
+	api := NewPrivateTraceAPI(eth)
+
+	if _, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{NestedTraceOutput: true}); err == nil {
+		t.Fatalf("expected error for nested trace output")
+	}
+	if _, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{}); err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+}
+
+// Tests that the transaction status is reported on the root traces of a block's
+// transactions only, matching the success of their execution.
+func TestTraceBlockStatus(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, func(i int, block *core.BlockGen) {
+		signer := types.HomesteadSigner{}
+
+		tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), common.Address{0x01}, big.NewInt(1000), vars.TxGas, big.NewInt(1), nil), signer, testBankKey)
+		block.AddTx(tx)
+		// PUSH1 0x00 PUSH1 0x00 REVERT
+		tx, _ = types.SignTx(types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 100000, big.NewInt(1), common.FromHex("0x60006000fd")), signer, testBankKey)
+		block.AddTx(tx)
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{IncludeStatus: true})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if len(traces) != 3 {
+		t.Fatalf("trace count mismatch: have %d, want %d", len(traces), 3)
+	}
+	for i, want := range []uint64{types.ReceiptStatusSuccessful, types.ReceiptStatusFailed} {
+		if status := traces[i].(map[string]interface{})["status"]; status != hexutil.Uint64(want) {
+			t.Errorf("trace %d status mismatch: have %v, want %v", i, status, want)
+		}
+	}
+	if _, ok := traces[2].(*ParityTrace); !ok {
+		t.Errorf("reward trace type mismatch: have %T", traces[2])
+	}
+	traces, err = api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if _, ok := traces[0].(map[string]interface{})["status"]; ok {
+		t.Errorf("status reported without being requested")
+	}
+}
+
+// Tests that no block reward traces are reported for chains not sealed by ethash,
+// the rewards of which don't follow the ethash reward schedule.
+func TestTraceBlockRewardsNonEthash(t *testing.T) {
+	config := *params.TestChainConfig
+	config.Clique = &ctypes.CliqueConfig{Period: 0, Epoch: 30000}
+
+	eth := newTestTraceBackendWithConfig(t, &config, 1, testTransferBlocks(2))
+	api := NewPrivateTraceAPI(eth)
+
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(1), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if len(traces) != 2 {
+		t.Fatalf("trace count mismatch: have %d, want %d", len(traces), 2)
+	}
+	for i, trace := range traces {
+		if _, ok := trace.(*ParityTrace); ok {
+			t.Errorf("trace %d: unexpected reward trace", i)
+		}
+	}
+}
+
+// Tests that uncle rewards are attributed to the author reported by the chain's
+// consensus engine, rather than blindly to the uncle's coinbase.
+func TestTraceBlockUncleRewardAuthor(t *testing.T) {
+	coinbase := common.Address{0xaa}
+
+	// Ethash attributes the reward to the uncle's coinbase
+	eth := newTestTraceBackend(t, 3, func(i int, block *core.BlockGen) {
+		if i == 2 {
+			uncle := block.PrevBlock(1).Header()
+			uncle.Extra, uncle.Coinbase = []byte("uncle"), coinbase
+			block.AddUncle(uncle)
+		}
+	})
+	traces, err := traceBlockUncleRewards(context.Background(), eth, eth.blockchain.GetBlockByNumber(3), nil)
+	if err != nil {
+		t.Fatalf("failed to trace ethash uncle rewards: %v", err)
+	}
+	if len(traces) != 1 || *traces[0].Action.Author != coinbase {
+		t.Fatalf("ethash uncle author mismatch: have %v, want %x", traces, coinbase)
+	}
+	// Clique attributes the reward to the signer of the uncle
+	eth.engine = clique.New(&ctypes.CliqueConfig{Period: 0, Epoch: 30000}, eth.chainDb)
+
+	uncle := &types.Header{Number: big.NewInt(1), Coinbase: coinbase, Difficulty: big.NewInt(2), Extra: make([]byte, 32+crypto.SignatureLength)}
+	sig, err := crypto.Sign(clique.SealHash(uncle).Bytes(), testBankKey)
+	if err != nil {
+		t.Fatalf("failed to sign uncle: %v", err)
+	}
+	copy(uncle.Extra[32:], sig)
+
+	block := types.NewBlock(&types.Header{Number: big.NewInt(2)}, nil, []*types.Header{uncle}, nil, new(trie.Trie))
+	if traces, err = traceBlockUncleRewards(context.Background(), eth, block, nil); err != nil {
+		t.Fatalf("failed to trace clique uncle rewards: %v", err)
+	}
+	if len(traces) != 1 || *traces[0].Action.Author != testBank {
+		t.Fatalf("clique uncle author mismatch: have %v, want %x", traces, testBank)
+	}
+	// Uncles without a recoverable signer can't be attributed
+	uncle = &types.Header{Number: big.NewInt(1), Coinbase: coinbase}
+	block = types.NewBlock(&types.Header{Number: big.NewInt(2)}, nil, []*types.Header{uncle}, nil, new(trie.Trie))
+	if _, err := traceBlockUncleRewards(context.Background(), eth, block, nil); err == nil {
+		t.Fatalf("expected unsigned clique uncle to fail")
+	}
+}
+
+// Tests that the reward traces of a block follow its transaction traces, the
+// block reward first and then the uncle rewards in the order of the uncles.
+func TestTraceBlockRewardOrder(t *testing.T) {
+	var (
+		miner   = common.Address{0xc0}
+		authors = []common.Address{{0xaa}, {0xbb}}
+	)
+	eth := newTestTraceBackend(t, 3, func(i int, block *core.BlockGen) {
+		block.SetCoinbase(miner)
+		testTransferBlocks(2)(i, block)
+		if i == 2 {
+			for j, author := range authors {
+				uncle := block.PrevBlock(1).Header()
+				uncle.Extra, uncle.Coinbase = []byte{byte(j)}, author
+				block.AddUncle(uncle)
+			}
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(3), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	blob, _ := json.Marshal(traces)
+	var decoded []struct {
+		Type   string `json:"type"`
+		Action struct {
+			Author     *common.Address `json:"author"`
+			RewardType string          `json:"rewardType"`
+		} `json:"action"`
+		TransactionPosition *uint64 `json:"transactionPosition"`
+	}
+	if err := json.Unmarshal(blob, &decoded); err != nil {
+		t.Fatalf("failed to decode traces: %v", err)
+	}
+	if len(decoded) != 5 {
+		t.Fatalf("trace count mismatch: have %d, want %d", len(decoded), 5)
+	}
+	for i := 0; i < 2; i++ {
+		if decoded[i].Type != "call" || decoded[i].TransactionPosition == nil || *decoded[i].TransactionPosition != uint64(i) {
+			t.Errorf("trace %d: want transaction %d, have %s", i, i, blob)
+		}
+	}
+	want := []struct {
+		author     common.Address
+		rewardType string
+	}{{miner, "block"}, {authors[0], "uncle"}, {authors[1], "uncle"}}
+	for i, reward := range want {
+		trace := decoded[2+i]
+		if trace.Type != "reward" || trace.Action.RewardType != reward.rewardType || trace.Action.Author == nil || *trace.Action.Author != reward.author {
+			t.Errorf("trace %d: want %s reward of %x, have %s", 2+i, reward.rewardType, reward.author, blob)
+		}
+	}
+}
+
+// Tests that reward traces carry explicit null transaction hashes and positions
+// like OpenEthereum's, rather than omitting them or zeroing them, whatever the
+// options annotating the transactions' traces.
+func TestTraceBlockRewardNullTransaction(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(1))
+	api := NewPrivateTraceAPI(eth)
+
+	configs := []*TraceConfig{
+		nil,
+		{DecimalValues: true, IncludeForkName: true},
+		{IncludeStatus: true, IncludeEffectiveGasPrice: true},
+		{IncludeStatus: true, ValueTransfersOnly: true},
+		{IncludeStatus: true, IncludeEffectiveGasPrice: true, DecimalValues: true},
+	}
+	for _, config := range configs {
+		traces, err := api.Block(context.Background(), rpc.BlockNumber(1), config)
+		if err != nil {
+			t.Fatalf("failed to trace block: %v", err)
+		}
+		blob, _ := json.Marshal(traces)
+		var decoded []map[string]json.RawMessage
+		if err := json.Unmarshal(blob, &decoded); err != nil {
+			t.Fatalf("failed to decode traces: %v", err)
+		}
+		rewards := 0
+		for _, trace := range decoded {
+			if string(trace["type"]) != `"reward"` {
+				continue
+			}
+			rewards++
+			for _, key := range []string{"transactionHash", "transactionPosition"} {
+				if value, ok := trace[key]; !ok || string(value) != "null" {
+					t.Errorf("config %+v: reward %s mismatch: have %s (present %v), want null", config, key, value, ok)
+				}
+			}
+			for _, key := range []string{"status", "effectiveGasPrice"} {
+				if value, ok := trace[key]; ok {
+					t.Errorf("config %+v: reward carries transaction %s: %s", config, key, value)
+				}
+			}
+		}
+		if rewards != 1 {
+			t.Errorf("config %+v: reward count mismatch: have %d, want %d", config, rewards, 1)
+		}
+	}
+}
+
+// Tests that the root traces of trace_block carry the effective gas price of
+// their transactions if requested, and only them.
+func TestTraceBlockEffectiveGasPrice(t *testing.T) {
+	var (
+		signer = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		prices = []*big.Int{big.NewInt(1), big.NewInt(3000000000)}
+	)
+	eth := newTestTraceBackend(t, 1, func(i int, block *core.BlockGen) {
+		for _, price := range prices {
+			tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), common.Address{0x01}, big.NewInt(1000), vars.TxGas, price, nil), signer, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	for _, decimal := range []bool{false, true} {
+		traces, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{IncludeEffectiveGasPrice: true, DecimalValues: decimal})
+		if err != nil {
+			t.Fatalf("decimal %v: failed to trace block: %v", decimal, err)
+		}
+		blob, _ := json.Marshal(traces)
+		var decoded []map[string]interface{}
+		if err := json.Unmarshal(blob, &decoded); err != nil {
+			t.Fatalf("decimal %v: failed to decode traces: %v", decimal, err)
+		}
+		for i, trace := range decoded {
+			have, ok := trace["effectiveGasPrice"]
+			if i >= len(prices) {
+				if ok {
+					t.Errorf("decimal %v: reward trace carries an effective gas price: %v", decimal, have)
+				}
+				continue
+			}
+			want := hexutil.EncodeBig(prices[i])
+			if decimal {
+				want = prices[i].String()
+			}
+			if have != want {
+				t.Errorf("decimal %v, trace %d: effective gas price mismatch: have %v, want %v", decimal, i, have, want)
+			}
+		}
+	}
+	// The methods not tracing whole blocks reject the option rather than ignore it
+	config := &TraceConfig{IncludeEffectiveGasPrice: true}
+	if _, err := api.Transaction(context.Background(), eth.blockchain.GetBlockByNumber(1).Transactions()[0].Hash(), config); err != errEffectiveGasPriceUnsupported {
+		t.Errorf("trace_transaction error mismatch: have %v, want %v", err, errEffectiveGasPriceUnsupported)
+	}
+	if _, err := api.Call(context.Background(), ethapi.CallArgs{}, rpc.BlockNumberOrHashWithNumber(1), config); err != errEffectiveGasPriceUnsupported {
+		t.Errorf("trace_call error mismatch: have %v, want %v", err, errEffectiveGasPriceUnsupported)
+	}
+	if _, err := api.CallMany(context.Background(), []ethapi.CallArgs{{}}, rpc.BlockNumberOrHashWithNumber(1), config); err != errEffectiveGasPriceUnsupported {
+		t.Errorf("trace_callMany error mismatch: have %v, want %v", err, errEffectiveGasPriceUnsupported)
+	}
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("trace", api); err != nil {
+		t.Fatalf("failed to register trace API: %v", err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	if _, err := client.Subscribe(context.Background(), "trace", make(chan *blockTraceResult), "filter", TraceFilterArgs{FromBlock: 1, ToBlock: 1}, config); err == nil || err.Error() != errEffectiveGasPriceUnsupported.Error() {
+		t.Errorf("trace_subscribe(\"filter\") error mismatch: have %v, want %v", err, errEffectiveGasPriceUnsupported)
+	}
+}
+
+// Tests that the reward traces follow the chain's configured block reward
+// schedule across reductions, omitting rewards reduced to zero.
+func TestTraceBlockRewardSchedule(t *testing.T) {
+	config := &coregeth.CoreGethChainConfig{
+		NetworkID: 1,
+		ChainID:   big.NewInt(1),
+		Ethash:    new(ctypes.EthashConfig),
+		BlockRewardSchedule: ctypes.Uint64BigMapEncodesHex{
+			0: vars.FrontierBlockReward,
+			2: big.NewInt(vars.Ether),
+			3: new(big.Int),
+		},
+	}
+	eth := newTestTraceBackendWithConfig(t, config, 3, func(i int, block *core.BlockGen) {
+		if i == 2 {
+			uncle := block.PrevBlock(1).Header()
+			uncle.Extra = []byte("uncle")
+			block.AddUncle(uncle)
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	for number, want := range map[int64]*big.Int{1: vars.FrontierBlockReward, 2: big.NewInt(vars.Ether), 3: nil} {
+		traces, err := api.Block(context.Background(), rpc.BlockNumber(number), nil)
+		if err != nil {
+			t.Fatalf("block %d: failed to trace block: %v", number, err)
+		}
+		if want == nil {
+			if len(traces) != 0 {
+				t.Errorf("block %d: unexpected zero reward traces: %v", number, traces)
+			}
+			continue
+		}
+		if len(traces) != 1 {
+			t.Fatalf("block %d: trace count mismatch: have %d, want %d", number, len(traces), 1)
+		}
+		if have := traces[0].(*ParityTrace).Action.Value.ToInt(); have.Cmp(want) != 0 {
+			t.Errorf("block %d: reward mismatch: have %v, want %v", number, have, want)
+		}
+	}
+}
+
+// Tests that the reward traces of an ECIP-1017 chain follow the era length the
+// chain is configured with, not the one of the Ethereum Classic mainnet, across
+// an era boundary, matching the balances credited by the consensus engine.
+func TestTraceBlockRewardECIP1017Eras(t *testing.T) {
+	config := &coregeth.CoreGethChainConfig{
+		NetworkID:         1,
+		ChainID:           big.NewInt(1),
+		Ethash:            new(ctypes.EthashConfig),
+		ECIP1017FBlock:    big.NewInt(0),
+		ECIP1017EraRounds: big.NewInt(3),
+	}
+	var (
+		miner      = common.Address{0xc0}
+		uncleMiner = common.Address{0x0c}
+	)
+	// Blocks #3 and #4, the last of the first era and the first of the second
+	// one, both include an uncle
+	eth := newTestTraceBackendWithConfig(t, config, 5, func(i int, block *core.BlockGen) {
+		block.SetCoinbase(miner)
+		if i == 2 || i == 3 {
+			uncle := block.PrevBlock(i - 1).Header()
+			uncle.Coinbase = uncleMiner
+			uncle.Extra = []byte("uncle")
+			block.AddUncle(uncle)
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	var (
+		eraOne    = vars.FrontierBlockReward                                                 // 5 ether
+		eraTwo    = new(big.Int).Div(new(big.Int).Mul(eraOne, big.NewInt(4)), big.NewInt(5)) // 4 ether
+		thirtyTwo = big.NewInt(32)
+	)
+	tests := []struct {
+		number int64
+		miner  *big.Int
+		uncle  *big.Int
+	}{
+		{2, eraOne, nil},
+		// Era 1: the uncle reward depends on its depth, plus 1/32 to the miner
+		{3, new(big.Int).Add(eraOne, new(big.Int).Div(eraOne, thirtyTwo)), new(big.Int).Div(new(big.Int).Mul(eraOne, big.NewInt(7)), big.NewInt(8))},
+		// Era 2: uncles and miners get 1/32 of the reduced reward per uncle
+		{4, new(big.Int).Add(eraTwo, new(big.Int).Div(eraTwo, thirtyTwo)), new(big.Int).Div(eraTwo, thirtyTwo)},
+		{5, eraTwo, nil},
+	}
+	for _, tt := range tests {
+		traces, err := api.Block(context.Background(), rpc.BlockNumber(tt.number), nil)
+		if err != nil {
+			t.Fatalf("block %d: failed to trace block: %v", tt.number, err)
+		}
+		rewards := make(map[common.Address]*big.Int)
+		for _, trace := range traces {
+			reward := trace.(*ParityTrace)
+			rewards[*reward.Action.Author] = reward.Action.Value.ToInt()
+		}
+		if have := rewards[miner]; have == nil || have.Cmp(tt.miner) != 0 {
+			t.Errorf("block %d: miner reward mismatch: have %v, want %v", tt.number, have, tt.miner)
+		}
+		if have := rewards[uncleMiner]; (have == nil) != (tt.uncle == nil) || (have != nil && have.Cmp(tt.uncle) != 0) {
+			t.Errorf("block %d: uncle reward mismatch: have %v, want %v", tt.number, have, tt.uncle)
+		}
+		// Cross check the rewards with the balances credited during import
+		parent, err := eth.blockchain.StateAt(eth.blockchain.GetBlockByNumber(uint64(tt.number - 1)).Root())
+		if err != nil {
+			t.Fatalf("block %d: failed to open parent state: %v", tt.number, err)
+		}
+		statedb, err := eth.blockchain.StateAt(eth.blockchain.GetBlockByNumber(uint64(tt.number)).Root())
+		if err != nil {
+			t.Fatalf("block %d: failed to open state: %v", tt.number, err)
+		}
+		for _, author := range []common.Address{miner, uncleMiner} {
+			credited := new(big.Int).Sub(statedb.GetBalance(author), parent.GetBalance(author))
+			traced := rewards[author]
+			if traced == nil {
+				traced = new(big.Int)
+			}
+			if credited.Cmp(traced) != 0 {
+				t.Errorf("block %d: %x credited %v, traced %v", tt.number, author, credited, traced)
+			}
+		}
+	}
+}
+
+// Tests that tracing a transaction on top of a partially pruned state, whose
+// root is available but misses trie nodes, falls back to regenerating the state
+// from an older block within the reexec limit.
+func TestTraceTransactionMissingTrieNode(t *testing.T) {
+	var (
+		engine = ethash.NewFaker()
+		db     = rawdb.NewMemoryDatabase()
+		gspec  = &genesisT.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  genesisT.GenesisAlloc{testBank: {Balance: big.NewInt(vars.Ether)}},
+		}
+		genesis = core.MustCommitGenesis(db, gspec)
+	)
+	// Create an archive chain, so that all states are written to disk
+	blockchain, err := core.NewBlockChain(db, &core.CacheConfig{TrieDirtyDisabled: true}, gspec.Config, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	chain, _ := core.GenerateChain(gspec.Config, genesis, engine, db, 3, testTransferBlocks(1))
+	if _, err := blockchain.InsertChain(chain); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	eth := &Ethereum{config: &DefaultConfig, blockchain: blockchain, engine: engine, chainDb: db}
+
+	// Prune the trie nodes introduced by block 2, except for its state root
+	nodes := func(root common.Hash) map[common.Hash]bool {
+		tr, err := state.NewDatabase(db).OpenTrie(root)
+		if err != nil {
+			t.Fatalf("failed to open state trie %x: %v", root, err)
+		}
+		hashes := make(map[common.Hash]bool)
+		for it := tr.NodeIterator(nil); it.Next(true); {
+			if hash := it.Hash(); hash != (common.Hash{}) {
+				hashes[hash] = true
+			}
+		}
+		return hashes
+	}
+	older := nodes(chain[0].Root())
+	for hash := range nodes(chain[1].Root()) {
+		if !older[hash] && hash != chain[1].Root() {
+			db.Delete(hash[:])
+		}
+	}
+	var (
+		tx     = chain[2].Transactions()[0]
+		tracer = "callTracerParity"
+	)
+	// Without any blocks to reexecute, the pruned state fails the trace
+	reexec := uint64(0)
+	if _, err := traceTransaction(context.Background(), eth, tx.Hash(), &TraceConfig{Tracer: &tracer, Reexec: &reexec}); !isMissingTrieNode(err) {
+		t.Fatalf("expected missing trie node failure, got %v", err)
+	}
+	// With reexecution allowed, the state is regenerated from block 1
+	res, err := traceTransaction(context.Background(), eth, tx.Hash(), &TraceConfig{Tracer: &tracer})
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	var traces []map[string]interface{}
+	if err := json.Unmarshal(res.(json.RawMessage), &traces); err != nil {
+		t.Fatalf("failed to decode traces: %v", err)
+	}
+	if len(traces) != 1 || traces[0]["error"] != nil {
+		t.Fatalf("unexpected traces: %v", traces)
+	}
+}
+
+// Tests that a transaction calling a contract self-destructed since is traced
+// with the contract's historical code, even if its code was pruned, by
+// reexecuting the contract's creation.
+func TestTraceTransactionDestructedContract(t *testing.T) {
+	var (
+		engine = ethash.NewFaker()
+		db     = rawdb.NewMemoryDatabase()
+		gspec  = &genesisT.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  genesisT.GenesisAlloc{testBank: {Balance: big.NewInt(vars.Ether)}},
+		}
+		genesis = core.MustCommitGenesis(db, gspec)
+		signer  = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+
+		// A contract storing a flag when called without data, and self-destructing
+		// into the caller otherwise
+		runtime = []byte{
+			0x36, 0x60, 0x0a, 0x57, // JUMPI(10, CALLDATASIZE)
+			0x60, 0x01, 0x60, 0x00, 0x55, 0x00, // SSTORE(0, 1), STOP
+			0x5b, 0x33, 0xff, // JUMPDEST, SELFDESTRUCT(CALLER)
+		}
+		initcode = append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+		contract = crypto.CreateAddress(testBank, 0)
+	)
+	chain, _ := core.GenerateChain(gspec.Config, genesis, engine, db, 3, func(i int, block *core.BlockGen) {
+		var tx *types.Transaction
+		switch i {
+		case 0:
+			tx = types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 100000, big.NewInt(1), initcode)
+		case 1:
+			tx = types.NewTransaction(block.TxNonce(testBank), contract, new(big.Int), 100000, big.NewInt(1), nil)
+		case 2:
+			tx = types.NewTransaction(block.TxNonce(testBank), contract, new(big.Int), 100000, big.NewInt(1), []byte{0x01})
+		}
+		tx, _ = types.SignTx(tx, signer, testBankKey)
+		block.AddTx(tx)
+	})
+	// Create an archive chain, so that all states are written to disk
+	newEth := func() *Ethereum {
+		blockchain, err := core.NewBlockChain(db, &core.CacheConfig{TrieDirtyDisabled: true}, gspec.Config, engine, vm.Config{}, nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create blockchain: %v", err)
+		}
+		return &Ethereum{config: &DefaultConfig, blockchain: blockchain, engine: engine, chainDb: db}
+	}
+	eth := newEth()
+	if _, err := eth.blockchain.InsertChain(chain); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	statedb, _ := eth.blockchain.State()
+	if statedb.Exist(contract) {
+		t.Fatalf("contract still exists after self-destruct")
+	}
+	var (
+		tx     = chain[1].Transactions()[0]
+		tracer = "callTracerParity"
+	)
+	// traces returns the traces of the call, without their (varying) execution time
+	traces := func(eth *Ethereum, reexec *uint64) ([]map[string]interface{}, error) {
+		res, err := traceTransaction(context.Background(), eth, tx.Hash(), &TraceConfig{Tracer: &tracer, Reexec: reexec})
+		if err != nil {
+			return nil, err
+		}
+		var traces []map[string]interface{}
+		if err := json.Unmarshal(res.(json.RawMessage), &traces); err != nil {
+			t.Fatalf("failed to decode traces: %v", err)
+		}
+		for _, trace := range traces {
+			delete(trace, "time")
+		}
+		return traces, nil
+	}
+	want, err := traces(eth, nil)
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	if result := want[0]["result"].(map[string]interface{}); len(want) != 1 || result["gasUsed"] == "0x0" {
+		t.Fatalf("call not executing the contract code: %v", want)
+	}
+	eth.blockchain.Stop()
+
+	// Prune the code of the destructed contract, reopening the chain to drop the
+	// code cached in memory
+	codehash := crypto.Keccak256Hash(runtime)
+	rawdb.DeleteCode(db, codehash)
+	db.Delete(codehash[:]) // legacy scheme
+	eth = newEth()
+	defer eth.blockchain.Stop()
+
+	// Without any blocks to reexecute, the pruned code fails the trace
+	reexec := uint64(0)
+	if _, err := traces(eth, &reexec); !isMissingCode(err) {
+		t.Fatalf("expected missing code failure, got %v", err)
+	}
+	// With reexecution allowed, the code is regenerated along with the state
+	have, err := traces(eth, nil)
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("trace mismatch:\nhave %v\nwant %v", have, want)
+	}
+}
+
+// Tests that trace_filter traces its block range inclusively, allowing single
+// block filters, and retains only the traces matching the address filter.
+func TestTraceFilterSingleBlock(t *testing.T) {
+	eth := newTestTraceBackend(t, 3, testTransferBlocks(2))
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("trace", NewPrivateTraceAPI(eth)); err != nil {
+		t.Fatalf("failed to register trace API: %v", err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	filter := func(args TraceFilterArgs, blocks int) []*blockTraceResult {
+		results := make(chan *blockTraceResult)
+		sub, err := client.Subscribe(context.Background(), "trace", results, "filter", args, nil)
+		if err != nil {
+			t.Fatalf("failed to subscribe: %v", err)
+		}
+		defer sub.Unsubscribe()
+
+		var traced []*blockTraceResult
+		for len(traced) < blocks {
+			select {
+			case result := <-results:
+				traced = append(traced, result)
+			case err := <-sub.Err():
+				t.Fatalf("subscription failed: %v", err)
+			case <-time.After(10 * time.Second):
+				t.Fatalf("timed out waiting for block %d", len(traced))
+			}
+		}
+		return traced
+	}
+	// Filter the traces to a single recipient within a single block
+	to := common.Address{0x02}
+	results := filter(TraceFilterArgs{FromBlock: 2, ToBlock: 2, ToAddress: &to}, 1)
+	if results[0].Block != 2 || len(results[0].Traces) != 2 {
+		t.Fatalf("block result mismatch: have block %d with %d traces", results[0].Block, len(results[0].Traces))
+	}
+	for i, want := range []int{0, 1} {
+		if traces := results[0].Traces[i].Result.([]interface{}); len(traces) != want {
+			t.Errorf("transaction %d trace count mismatch: have %d, want %d", i, len(traces), want)
+		}
+	}
+	// Ensure the starting block of a range is traced too
+	results = filter(TraceFilterArgs{FromBlock: 1, ToBlock: 2}, 2)
+	for i, result := range results {
+		if result.Block != hexutil.Uint64(i+1) || len(result.Traces) != 2 {
+			t.Errorf("block result %d mismatch: have block %d with %d traces", i, result.Block, len(result.Traces))
+		}
+	}
+}
+
+// Tests that the root Parity trace only accounts for the gas available to the
+// EVM like OpenEthereum's, unless the intrinsic gas was requested too.
+func TestTraceTransactionIntrinsicGas(t *testing.T) {
+	signer := types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+	eth := newTestTraceBackend(t, 1, func(i int, block *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), common.Address{0x01}, big.NewInt(1), 50000, big.NewInt(1), []byte{0xff, 0xff, 0xff, 0xff}), signer, testBankKey)
+		block.AddTx(tx)
+	})
+	api := NewPrivateTraceAPI(eth)
+	tx := eth.blockchain.GetBlockByNumber(1).Transactions()[0]
+
+	for _, tt := range []struct {
+		intrinsic    bool
+		gas, gasUsed uint64
+	}{
+		{false, 50000 - 21000 - 4*16, 0},
+		{true, 50000, 21000 + 4*16},
+	} {
+		res, err := api.Transaction(context.Background(), tx.Hash(), &TraceConfig{IncludeIntrinsicGas: tt.intrinsic})
+		if err != nil {
+			t.Fatalf("intrinsic %v: failed to trace transaction: %v", tt.intrinsic, err)
+		}
+		var traces []struct {
+			Action struct {
+				Gas hexutil.Uint64 `json:"gas"`
+			} `json:"action"`
+			Result struct {
+				GasUsed hexutil.Uint64 `json:"gasUsed"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(res.(json.RawMessage), &traces); err != nil {
+			t.Fatalf("intrinsic %v: failed to decode traces: %v", tt.intrinsic, err)
+		}
+		if len(traces) != 1 || uint64(traces[0].Action.Gas) != tt.gas || uint64(traces[0].Result.GasUsed) != tt.gasUsed {
+			t.Errorf("intrinsic %v: gas mismatch: have %+v, want gas %d, gasUsed %d", tt.intrinsic, traces, tt.gas, tt.gasUsed)
+		}
+	}
+}
+
+// Tests that tracing a block whose header is known but whose body isn't fails
+// with a clear error, instead of returning its rewards only.
+func TestTraceBlockMissingBody(t *testing.T) {
+	eth := newTestTraceBackend(t, 3, testTransferBlocks(1))
+	api := NewPrivateTraceAPI(eth)
+
+	// Drop the body of a block below the head, which the chain needs to load
+	block := eth.blockchain.GetBlockByNumber(2)
+	rawdb.DeleteBody(eth.chainDb, block.Hash(), block.NumberU64())
+
+	// Reopen the chain, dropping any body cached from the insertion
+	blockchain, err := core.NewBlockChain(eth.chainDb, nil, params.TestChainConfig, eth.engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to reopen blockchain: %v", err)
+	}
+	eth.blockchain = blockchain
+
+	if _, err := api.Block(context.Background(), rpc.BlockNumber(2), nil); err == nil || !strings.Contains(err.Error(), "block body not available") {
+		t.Errorf("expected missing body failure, got %v", err)
+	}
+	if _, err := api.Block(context.Background(), rpc.BlockNumber(4), nil); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected missing block failure, got %v", err)
+	}
+}
+
+// Tests that the value transfer filter only retains the traces moving ether,
+// flagging the transactions which lost some of their traces as pruned.
+func TestTraceBlockValueTransfers(t *testing.T) {
+	var (
+		signer = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		payee  = common.Address{0xbb}
+
+		// A contract forwarding 1 wei to the payee whenever called
+		runtime  = []byte{0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x01, 0x60, 0xbb, 0x5a, 0xf1, 0x00}
+		initcode = append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+		contract = crypto.CreateAddress(testBank, 0)
+	)
+	eth := newTestTraceBackend(t, 2, func(i int, block *core.BlockGen) {
+		var txs []*types.Transaction
+		if i == 0 {
+			txs = append(txs, types.NewContractCreation(block.TxNonce(testBank), big.NewInt(10), 100000, big.NewInt(1), initcode))
+		} else {
+			txs = append(txs, types.NewTransaction(block.TxNonce(testBank), payee, big.NewInt(1000), vars.TxGas, big.NewInt(1), nil))
+			txs = append(txs, types.NewTransaction(block.TxNonce(testBank)+1, contract, new(big.Int), 100000, big.NewInt(1), nil))
+		}
+		for _, tx := range txs {
+			tx, _ = types.SignTx(tx, signer, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(2), &TraceConfig{ValueTransfersOnly: true})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	type transfer struct {
+		Type     string
+		Position interface{}
+		Address  []interface{}
+		Value    string
+		Pruned   interface{}
+	}
+	var have []transfer
+	for _, trace := range traces {
+		object := trace.(map[string]interface{})
+		action := object["action"].(map[string]interface{})
+		address, _ := object["traceAddress"].([]interface{})
+		have = append(have, transfer{object["type"].(string), object["transactionPosition"], address, action["value"].(string), object["pruned"]})
+	}
+	// The plain transfer is complete, the contract call only keeps its internal
+	// transfer at its original position in the call tree
+	want := []transfer{
+		{"call", float64(0), []interface{}{}, "0x3e8", nil},
+		{"call", float64(1), []interface{}{float64(0)}, "0x1", true},
+		{"reward", nil, []interface{}{}, hexutil.EncodeBig(ctypes.EthashBlockReward(params.TestChainConfig, big.NewInt(2))), nil},
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("transfers mismatch:\nhave %+v\nwant %+v", have, want)
+	}
+	// The pruned flag can be projected like any other field
+	traces, err = api.Block(context.Background(), rpc.BlockNumber(2), &TraceConfig{ValueTransfersOnly: true, Fields: []string{"pruned", "transactionPosition"}})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if pruned := traces[1].(map[string]interface{})["pruned"]; pruned != true {
+		t.Errorf("projected pruned flag mismatch: have %v, want true", pruned)
+	}
+	if _, ok := traces[0].(map[string]interface{})["pruned"]; ok {
+		t.Errorf("projected pruned flag on complete transaction")
+	}
+}
+
+// Tests that trace configs are validated without tracing anything, rejecting
+// unknown tracers, invalid timeouts and conflicting output options.
+func TestTraceValidateConfig(t *testing.T) {
+	api := NewPrivateTraceAPI(newTestTraceBackend(t, 0, nil))
+
+	str := func(s string) *string { return &s }
+	for i, tt := range []struct {
+		config *TraceConfig
+		valid  bool
+	}{
+		{nil, true},
+		{&TraceConfig{Tracer: str("callTracer"), Timeout: str("10s")}, true},
+		{&TraceConfig{Tracer: str("{step: function() {}, fault: function() {}, result: function() { return 1; }}")}, true},
+		{&TraceConfig{Fields: []string{"action.from"}, DecimalValues: true}, true},
+		{&TraceConfig{Tracer: str("unknownTracer")}, false},
+		{&TraceConfig{Timeout: str("10 seconds")}, false},
+		{&TraceConfig{NestedTraceOutput: true}, false},
+		{&TraceConfig{Fields: []string{"action.from"}, Format: traceFormatRows}, false},
+		{&TraceConfig{Format: "csv"}, false},
+		{&TraceConfig{IncludeLogs: true, Format: traceFormatRows}, false},
+		{&TraceConfig{LogConfig: &vm.LogConfig{Limit: 10}}, true},
+		{&TraceConfig{LogConfig: &vm.LogConfig{DisableStack: true}}, false},
+		{&TraceConfig{LogConfig: &vm.LogConfig{DisableMemory: true, DisableStorage: true}}, false},
+		{&TraceConfig{LogConfig: &vm.LogConfig{DisableReturnData: true}}, false},
+	} {
+		valid, err := api.ValidateConfig(context.Background(), tt.config)
+		if valid != tt.valid || (err == nil) != tt.valid {
+			t.Errorf("test %d: validity mismatch: have %v (%v), want %v", i, valid, err, tt.valid)
+		}
+	}
+	// The step data toggles are rejected by the call tracing methods too
+	config := &TraceConfig{LogConfig: &vm.LogConfig{DisableStack: true}}
+	if _, err := api.Call(context.Background(), ethapi.CallArgs{}, rpc.BlockNumberOrHashWithNumber(0), config); err != errStepCaptureUnsupported {
+		t.Errorf("trace_call error mismatch: have %v, want %v", err, errStepCaptureUnsupported)
+	}
+	if _, err := api.CallMany(context.Background(), []ethapi.CallArgs{{}}, rpc.BlockNumberOrHashWithNumber(0), config); err != errStepCaptureUnsupported {
+		t.Errorf("trace_callMany error mismatch: have %v, want %v", err, errStepCaptureUnsupported)
+	}
+}
+
+// Tests that the Parity traces tell precompiled contracts apart using the
+// precompile set of the traced chain and block, rather than the mainnet one.
+func TestTraceTransactionChainPrecompiles(t *testing.T) {
+	// A contract calling the precompile given as its first calldata byte
+	runtime := []byte{
+		0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, // retOffset, retSize, inOffset, inSize
+		0x60, 0x00, 0x35, 0x60, 0xf8, 0x1c, // CALLDATALOAD(0) >> 248
+		0x61, 0x10, 0x00, 0xfa, 0x00, // STATICCALL(0x1000, precompile, ...), STOP
+	}
+	initcode := append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+
+	istanbul := *params.TestChainConfig
+	istanbul.IstanbulBlock = big.NewInt(2)
+	istanbul.MuirGlacierBlock = big.NewInt(2)
+
+	bls := *params.TestChainConfig
+	bls.YoloV2Block = big.NewInt(0)
+
+	for _, tt := range []struct {
+		name       string
+		config     *goethereum.ChainConfig
+		precompile byte
+		subtraces  []int // Number of subtraces of the call in blocks 1 and 2
+	}{
+		// Blake2F is a plain account until Istanbul is activated at block 2
+		{"blake2F", &istanbul, 0x09, []int{1, 0}},
+		// The BLS12-381 precompiles are active on this chain, though not on mainnet
+		{"bls12381G1Add", &bls, 0x0a, []int{0, 0}},
+	} {
+		signer := types.NewEIP155Signer(tt.config.GetChainID())
+		eth := newTestTraceBackendWithConfig(t, tt.config, 2, func(i int, block *core.BlockGen) {
+			if i == 0 {
+				tx, _ := types.SignTx(types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 100000, big.NewInt(1), initcode), signer, testBankKey)
+				block.AddTx(tx)
+			}
+			tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), crypto.CreateAddress(testBank, 0), new(big.Int), 100000, big.NewInt(1), []byte{tt.precompile}), signer, testBankKey)
+			block.AddTx(tx)
+		})
+		api := NewPrivateTraceAPI(eth)
+
+		for number, want := range tt.subtraces {
+			txs := eth.blockchain.GetBlockByNumber(uint64(number + 1)).Transactions()
+			res, err := api.Transaction(context.Background(), txs[len(txs)-1].Hash(), nil)
+			if err != nil {
+				t.Fatalf("%s, block %d: failed to trace transaction: %v", tt.name, number+1, err)
+			}
+			var traces []struct {
+				Subtraces int `json:"subtraces"`
+			}
+			if err := json.Unmarshal(res.(json.RawMessage), &traces); err != nil {
+				t.Fatalf("%s, block %d: failed to decode traces: %v", tt.name, number+1, err)
+			}
+			if len(traces) != want+1 || traces[0].Subtraces != want {
+				t.Errorf("%s, block %d: subtraces mismatch: have %+v, want %d", tt.name, number+1, traces, want)
+			}
+		}
+	}
+}
+
+// Tests that the transaction envelopes known to this chain, i.e. legacy ones
+// signed with or without replay protection, trace with the value and gas the
+// transaction carried. Typed (EIP-2718) envelopes aren't supported yet.
+func TestTraceTransactionEnvelopes(t *testing.T) {
+	var (
+		to      = common.Address{0x01}
+		signers = []types.Signer{types.HomesteadSigner{}, types.NewEIP155Signer(params.TestChainConfig.GetChainID())}
+	)
+	eth := newTestTraceBackend(t, 1, func(i int, block *core.BlockGen) {
+		for j, signer := range signers {
+			nonce := block.TxNonce(testBank)
+			txs := []*types.Transaction{
+				types.NewTransaction(nonce, to, big.NewInt(int64(1000+j)), 50000, big.NewInt(1), nil),
+				types.NewContractCreation(nonce+1, big.NewInt(int64(2000+j)), 100000, big.NewInt(1), nil),
+			}
+			for _, tx := range txs {
+				tx, _ = types.SignTx(tx, signer, testBankKey)
+				block.AddTx(tx)
+			}
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	for i, tx := range eth.blockchain.GetBlockByNumber(1).Transactions() {
+		res, err := api.Transaction(context.Background(), tx.Hash(), &TraceConfig{IncludeIntrinsicGas: true})
+		if err != nil {
+			t.Fatalf("tx %d: failed to trace transaction: %v", i, err)
+		}
+		var traces []struct {
+			Action struct {
+				From  common.Address `json:"from"`
+				Gas   hexutil.Uint64 `json:"gas"`
+				Value *hexutil.Big   `json:"value"`
+			} `json:"action"`
+		}
+		if err := json.Unmarshal(res.(json.RawMessage), &traces); err != nil {
+			t.Fatalf("tx %d: failed to decode traces: %v", i, err)
+		}
+		if len(traces) != 1 {
+			t.Fatalf("tx %d: trace count mismatch: have %d, want %d", i, len(traces), 1)
+		}
+		action := traces[0].Action
+		if action.From != testBank {
+			t.Errorf("tx %d: sender mismatch: have %x, want %x", i, action.From, testBank)
+		}
+		if uint64(action.Gas) != tx.Gas() {
+			t.Errorf("tx %d: gas mismatch: have %d, want %d", i, action.Gas, tx.Gas())
+		}
+		if action.Value == nil || action.Value.ToInt().Cmp(tx.Value()) != 0 {
+			t.Errorf("tx %d: value mismatch: have %v, want %v", i, action.Value, tx.Value())
+		}
+	}
+}
+
+// Tests that the traced gas of transactions accessing accounts matches their
+// receipts across the EIP-2929 activation, the sender, the destination and the
+// precompiled contracts being warmed up ahead of the execution like during block
+// processing.
+func TestTraceTransactionAccessListGas(t *testing.T) {
+	config := *params.TestChainConfig
+	config.YoloV2Block = big.NewInt(2)
+
+	var (
+		signer = types.NewEIP155Signer(config.GetChainID())
+
+		// A contract reading the balances of its caller and of the coinbase
+		runtime  = []byte{0x33, 0x31, 0x50, 0x41, 0x31, 0x50, 0x00}
+		initcode = append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+		contract = crypto.CreateAddress(testBank, 0)
+	)
+	eth := newTestTraceBackendWithConfig(t, &config, 3, func(i int, block *core.BlockGen) {
+		block.SetCoinbase(common.Address{0xc0})
+		if i == 0 {
+			tx, _ := types.SignTx(types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 100000, big.NewInt(1), initcode), signer, testBankKey)
+			block.AddTx(tx)
+		}
+		tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), contract, new(big.Int), 100000, big.NewInt(1), nil), signer, testBankKey)
+		block.AddTx(tx)
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	for number := uint64(1); number <= 3; number++ {
+		block := eth.blockchain.GetBlockByNumber(number)
+		receipts := eth.blockchain.GetReceiptsByHash(block.Hash())
+		for i, tx := range block.Transactions() {
+			res, err := api.Transaction(context.Background(), tx.Hash(), &TraceConfig{IncludeIntrinsicGas: true})
+			if err != nil {
+				t.Fatalf("block %d, tx %d: failed to trace transaction: %v", number, i, err)
+			}
+			var traces []struct {
+				Result struct {
+					GasUsed hexutil.Uint64 `json:"gasUsed"`
+				} `json:"result"`
+			}
+			if err := json.Unmarshal(res.(json.RawMessage), &traces); err != nil {
+				t.Fatalf("block %d, tx %d: failed to decode traces: %v", number, i, err)
+			}
+			if len(traces) != 1 || uint64(traces[0].Result.GasUsed) != receipts[i].GasUsed {
+				t.Errorf("block %d, tx %d: gas used mismatch: have %+v, want %d", number, i, traces, receipts[i].GasUsed)
+			}
+		}
+	}
+}
+
+// Tests that the traced gas of calldata heavy transactions matches their receipts
+// across the EIP-2028 calldata repricing, the intrinsic gas being computed with
+// the rules active at the traced block.
+func TestTraceTransactionCalldataGas(t *testing.T) {
+	config := *params.TestChainConfig
+	config.IstanbulBlock = big.NewInt(2)
+
+	var (
+		signer = types.NewEIP155Signer(config.GetChainID())
+		data   = bytes.Repeat([]byte{0xff}, 2000)
+	)
+	eth := newTestTraceBackendWithConfig(t, &config, 2, func(i int, block *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), common.Address{0x01}, new(big.Int), 200000, big.NewInt(1), data), signer, testBankKey)
+		block.AddTx(tx)
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	var used []uint64
+	for number := uint64(1); number <= 2; number++ {
+		block := eth.blockchain.GetBlockByNumber(number)
+		receipt := eth.blockchain.GetReceiptsByHash(block.Hash())[0]
+
+		res, err := api.Transaction(context.Background(), block.Transactions()[0].Hash(), &TraceConfig{IncludeIntrinsicGas: true})
+		if err != nil {
+			t.Fatalf("block %d: failed to trace transaction: %v", number, err)
+		}
+		var traces []struct {
+			Result struct {
+				GasUsed hexutil.Uint64 `json:"gasUsed"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(res.(json.RawMessage), &traces); err != nil {
+			t.Fatalf("block %d: failed to decode traces: %v", number, err)
+		}
+		if len(traces) != 1 || uint64(traces[0].Result.GasUsed) != receipt.GasUsed {
+			t.Errorf("block %d: gas used mismatch: have %+v, want %d", number, traces, receipt.GasUsed)
+		}
+		used = append(used, receipt.GasUsed)
+	}
+	if want := vars.TxGas + uint64(len(data))*vars.TxDataNonZeroGasFrontier; used[0] != want {
+		t.Errorf("pre-Istanbul gas used mismatch: have %d, want %d", used[0], want)
+	}
+	if want := vars.TxGas + uint64(len(data))*vars.TxDataNonZeroGasEIP2028; used[1] != want {
+		t.Errorf("Istanbul gas used mismatch: have %d, want %d", used[1], want)
+	}
+}
+
+// Tests that every transaction trace of a block, nested calls included, carries
+// the hashes and positions correlating it with its block and transaction, the
+// same ones when the transaction is traced on its own.
+func TestTraceBlockCorrelationFields(t *testing.T) {
+	var (
+		signer = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		leaf   = crypto.CreateAddress(testBank, 0)
+		top    = crypto.CreateAddress(testBank, 1)
+	)
+	// Deploy an empty contract and one calling it, then call both in a block
+	eth := newTestTraceBackend(t, 2, func(i int, block *core.BlockGen) {
+		var txs []*types.Transaction
+		if i == 0 {
+			txs = append(txs, types.NewContractCreation(0, new(big.Int), 100000, big.NewInt(1), []byte{0x60, 0x01, 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, 0x01, 0x60, 0x00, 0xf3, 0x00}))
+			runtime := append([]byte{0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x73}, append(leaf.Bytes(), 0x5a, 0xf1, 0x00)...)
+			txs = append(txs, types.NewContractCreation(1, new(big.Int), 100000, big.NewInt(1), append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)))
+		} else {
+			txs = append(txs, types.NewTransaction(2, common.Address{0x01}, big.NewInt(1000), vars.TxGas, big.NewInt(1), nil))
+			txs = append(txs, types.NewTransaction(3, top, new(big.Int), 100000, big.NewInt(1), nil))
+		}
+		for _, tx := range txs {
+			tx, _ = types.SignTx(tx, signer, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+	block := eth.blockchain.GetBlockByNumber(2)
+
+	type correlation struct {
+		Type                string       `json:"type"`
+		BlockNumber         *uint64      `json:"blockNumber"`
+		BlockHash           *common.Hash `json:"blockHash"`
+		TransactionHash     *common.Hash `json:"transactionHash"`
+		TransactionPosition *uint64      `json:"transactionPosition"`
+	}
+	decode := func(res interface{}) []correlation {
+		blob, err := json.Marshal(res)
+		if err != nil {
+			t.Fatalf("failed to encode traces: %v", err)
+		}
+		var traces []correlation
+		if err := json.Unmarshal(blob, &traces); err != nil {
+			t.Fatalf("failed to decode traces: %v", err)
+		}
+		return traces
+	}
+	check := func(context string, trace correlation) {
+		if trace.TransactionPosition == nil || *trace.TransactionPosition >= uint64(len(block.Transactions())) {
+			t.Fatalf("%s: invalid transaction position: %v", context, trace.TransactionPosition)
+		}
+		tx := block.Transactions()[*trace.TransactionPosition]
+		if trace.TransactionHash == nil || *trace.TransactionHash != tx.Hash() {
+			t.Errorf("%s: transaction hash mismatch: have %v, want %x", context, trace.TransactionHash, tx.Hash())
+		}
+		if trace.BlockHash == nil || *trace.BlockHash != block.Hash() {
+			t.Errorf("%s: block hash mismatch: have %v, want %x", context, trace.BlockHash, block.Hash())
+		}
+		if trace.BlockNumber == nil || *trace.BlockNumber != block.NumberU64() {
+			t.Errorf("%s: block number mismatch: have %v, want %d", context, trace.BlockNumber, block.NumberU64())
+		}
+	}
+	res, err := api.Block(context.Background(), rpc.BlockNumber(2), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	var calls int
+	for i, trace := range decode(res) {
+		if trace.Type == "reward" {
+			continue
+		}
+		calls++
+		check(fmt.Sprintf("block trace %d", i), trace)
+	}
+	if calls != 3 {
+		t.Fatalf("transaction trace count mismatch: have %d, want %d", calls, 3)
+	}
+	for _, tx := range block.Transactions() {
+		res, err := api.Transaction(context.Background(), tx.Hash(), nil)
+		if err != nil {
+			t.Fatalf("failed to trace transaction %x: %v", tx.Hash(), err)
+		}
+		for i, trace := range decode(res) {
+			check(fmt.Sprintf("transaction %x trace %d", tx.Hash(), i), trace)
+			if *trace.TransactionHash != tx.Hash() {
+				t.Errorf("transaction %x trace %d: correlated with another transaction", tx.Hash(), i)
+			}
+		}
+	}
+}
+
+// Tests that the Parity traces report the gas each call had left when returning
+// and the gas its caller retained under EIP-150's 63/64 rule, if requested.
+func TestTraceTransactionGasRemaining(t *testing.T) {
+	// forwarder returns the init code of a contract calling the given address with
+	// all its gas, or the one of an empty contract
+	forwarder := func(to *common.Address) []byte {
+		runtime := []byte{0x00}
+		if to != nil {
+			runtime = append([]byte{0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x73}, append(to.Bytes(), 0x5a, 0xf1, 0x00)...)
+		}
+		return append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+	}
+	var (
+		signer = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		leaf   = crypto.CreateAddress(testBank, 0)
+		middle = crypto.CreateAddress(testBank, 1)
+		top    = crypto.CreateAddress(testBank, 2)
+	)
+	eth := newTestTraceBackend(t, 2, func(i int, block *core.BlockGen) {
+		var txs []*types.Transaction
+		if i == 0 {
+			for j, to := range []*common.Address{nil, &leaf, &middle} {
+				txs = append(txs, types.NewContractCreation(uint64(j), new(big.Int), 100000, big.NewInt(1), forwarder(to)))
+			}
+		} else {
+			txs = append(txs, types.NewTransaction(3, top, new(big.Int), 200000, big.NewInt(1), nil))
+		}
+		for _, tx := range txs {
+			tx, _ = types.SignTx(tx, signer, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+	tx := eth.blockchain.GetBlockByNumber(2).Transactions()[0]
+
+	res, err := api.Transaction(context.Background(), tx.Hash(), &TraceConfig{IncludeGasRemaining: true})
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	var traces []struct {
+		Action struct {
+			Gas hexutil.Uint64 `json:"gas"`
+		} `json:"action"`
+		Result struct {
+			GasUsed      hexutil.Uint64  `json:"gasUsed"`
+			GasRemaining *hexutil.Uint64 `json:"gasRemaining"`
+			GasRetained  *hexutil.Uint64 `json:"gasRetained"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(res.(json.RawMessage), &traces); err != nil {
+		t.Fatalf("failed to decode traces: %v", err)
+	}
+	if len(traces) != 3 {
+		t.Fatalf("trace count mismatch: have %d, want %d", len(traces), 3)
+	}
+	for i, trace := range traces {
+		gas, used := uint64(trace.Action.Gas), uint64(trace.Result.GasUsed)
+		if trace.Result.GasRemaining == nil || uint64(*trace.Result.GasRemaining) != gas-used {
+			t.Errorf("trace %d: gas remaining mismatch: have %v, want %d", i, trace.Result.GasRemaining, gas-used)
+		}
+		if i == 0 {
+			if trace.Result.GasRetained != nil {
+				t.Errorf("trace %d: gas retained reported for the root call", i)
+			}
+			continue
+		}
+		// Calling with all the gas, the callee gets 63/64 of the caller's available gas
+		if trace.Result.GasRetained == nil {
+			t.Fatalf("trace %d: gas retained missing", i)
+		}
+		if retained := uint64(*trace.Result.GasRetained); retained == 0 || retained != (retained+gas)/64 {
+			t.Errorf("trace %d: gas retained mismatch: have %d, want 1/64 of %d", i, retained, retained+gas)
+		}
+	}
+	// Without the option, there are no gas details
+	res, err = api.Transaction(context.Background(), tx.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	if bytes.Contains(res.(json.RawMessage), []byte("gasRemaining")) || bytes.Contains(res.(json.RawMessage), []byte("gasRetained")) {
+		t.Errorf("gas details reported without being requested: %s", res)
+	}
+}
+
+// Tests that the transactions of the DAO hard fork block are traced on the state
+// the fork's balance transfer mutated at the start of the block.
+func TestTraceDAOForkBlock(t *testing.T) {
+	config := *params.TestChainConfig
+	config.DAOForkBlock = big.NewInt(2)
+	config.DAOForkSupport = true
+
+	// Creation code returning the balance of the DAO refund contract
+	probe := append([]byte{0x73}, vars.DAORefundContract.Bytes()...)
+	probe = append(probe,
+		0x31, 0x60, 0x00, 0x52, // MSTORE(0, BALANCE(refund))
+		0x60, 0x20, 0x60, 0x00, 0xf3, // RETURN(0, 32)
+	)
+	// Fund a drained account ahead of the fork, probing the refund after it
+	signer := types.NewEIP155Signer(config.GetChainID())
+	eth := newTestTraceBackendWithConfig(t, &config, 2, func(i int, block *core.BlockGen) {
+		tx := types.NewTransaction(block.TxNonce(testBank), vars.DAODrainList()[0], big.NewInt(1000), vars.TxGas, big.NewInt(1), nil)
+		if i == 1 {
+			tx = types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 100000, big.NewInt(1), probe)
+		}
+		tx, _ = types.SignTx(tx, signer, testBankKey)
+		block.AddTx(tx)
+	})
+	api := NewPrivateTraceAPI(eth)
+	tx := eth.blockchain.GetBlockByNumber(2).Transactions()[0]
+
+	check := func(name string, res interface{}) {
+		blob, _ := json.Marshal(res)
+		var traces []struct {
+			Result struct {
+				Code hexutil.Bytes `json:"code"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(blob, &traces); err != nil || len(traces) == 0 {
+			t.Fatalf("%s: unexpected traces: %s", name, blob)
+		}
+		if balance := new(big.Int).SetBytes(traces[0].Result.Code); balance.Cmp(big.NewInt(1000)) != 0 {
+			t.Errorf("%s: refund balance mismatch: have %v, want %v", name, balance, 1000)
+		}
+	}
+	block, err := api.Block(context.Background(), rpc.BlockNumber(2), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	check("block", block)
+
+	transaction, err := api.Transaction(context.Background(), tx.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	check("transaction", transaction)
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("trace", api); err != nil {
+		t.Fatalf("failed to register trace API: %v", err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	results := make(chan *blockTraceResult)
+	sub, err := client.Subscribe(context.Background(), "trace", results, "filter", TraceFilterArgs{FromBlock: 2, ToBlock: 2}, nil)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	select {
+	case result := <-results:
+		if len(result.Traces) != 1 {
+			t.Fatalf("filter: trace count mismatch: have %d, want 1", len(result.Traces))
+		}
+		check("filter", result.Traces[0].Result)
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timed out waiting for block 2")
+	}
+}
+
+// Tests that the transactions of a block replayed to build the state of a traced
+// one are charged the EIP-2929 costs of block processing, each starting with a
+// fresh access list, so the later transactions are traced on the right state.
+func TestTraceBlockAccessListReplay(t *testing.T) {
+	config := *params.TestChainConfig
+	config.YoloV2Block = big.NewInt(0)
+
+	var (
+		signer = types.NewEIP155Signer(config.GetChainID())
+
+		// A contract reading the balance of the coinbase and returning the one of
+		// its caller
+		runtime  = []byte{0x41, 0x31, 0x50, 0x33, 0x31, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3}
+		initcode = append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+		contract = crypto.CreateAddress(testBank, 0)
+	)
+	eth := newTestTraceBackendWithConfig(t, &config, 2, func(i int, block *core.BlockGen) {
+		block.SetCoinbase(common.Address{0xc0})
+		if i == 0 {
+			tx, _ := types.SignTx(types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 100000, big.NewInt(1), initcode), signer, testBankKey)
+			block.AddTx(tx)
+			return
+		}
+		for j := 0; j < 3; j++ {
+			tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), contract, new(big.Int), 100000, big.NewInt(1), nil), signer, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	var (
+		block    = eth.blockchain.GetBlockByNumber(2)
+		receipts = eth.blockchain.GetReceiptsByHash(block.Hash())
+		parent   = eth.blockchain.GetBlockByNumber(1)
+	)
+	statedb, err := eth.blockchain.StateAt(parent.Root())
+	if err != nil {
+		t.Fatalf("failed to open parent state: %v", err)
+	}
+	// The caller's balance seen by each transaction is the one left by the prior
+	// ones, minus its own upfront gas
+	balance := statedb.GetBalance(testBank)
+	want := make([]*big.Int, len(receipts))
+	for i, tx := range block.Transactions() {
+		want[i] = new(big.Int).Sub(balance, new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas()), tx.GasPrice()))
+		balance = new(big.Int).Sub(balance, new(big.Int).Mul(new(big.Int).SetUint64(receipts[i].GasUsed), tx.GasPrice()))
+	}
+	type callTrace struct {
+		Result struct {
+			GasUsed hexutil.Uint64 `json:"gasUsed"`
+			Output  hexutil.Bytes  `json:"output"`
+		} `json:"result"`
+		TransactionPosition int `json:"transactionPosition"`
+	}
+	check := func(method string, trace callTrace) {
+		i := trace.TransactionPosition
+		if uint64(trace.Result.GasUsed) != receipts[i].GasUsed {
+			t.Errorf("%s, tx %d: gas used mismatch: have %d, want %d", method, i, trace.Result.GasUsed, receipts[i].GasUsed)
+		}
+		if have := new(big.Int).SetBytes(trace.Result.Output); have.Cmp(want[i]) != 0 {
+			t.Errorf("%s, tx %d: caller balance mismatch: have %v, want %v", method, i, have, want[i])
+		}
+	}
+	// Trace the transactions one by one, replaying the prior ones
+	for i, tx := range block.Transactions() {
+		res, err := api.Transaction(context.Background(), tx.Hash(), &TraceConfig{IncludeIntrinsicGas: true})
+		if err != nil {
+			t.Fatalf("tx %d: failed to trace transaction: %v", i, err)
+		}
+		var traces []callTrace
+		if err := json.Unmarshal(res.(json.RawMessage), &traces); err != nil {
+			t.Fatalf("tx %d: failed to decode traces: %v", i, err)
+		}
+		if len(traces) != 1 {
+			t.Fatalf("tx %d: trace count mismatch: have %d, want 1", i, len(traces))
+		}
+		check("trace_transaction", traces[0])
+	}
+	// Trace the whole block, replaying each transaction ahead of its trace
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(2), &TraceConfig{IncludeIntrinsicGas: true})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	blob, _ := json.Marshal(traces)
+	var decoded []callTrace
+	if err := json.Unmarshal(blob, &decoded); err != nil {
+		t.Fatalf("failed to decode traces: %v", err)
+	}
+	if len(decoded) != len(receipts)+1 {
+		t.Fatalf("trace count mismatch: have %d, want %d", len(decoded), len(receipts)+1)
+	}
+	for _, trace := range decoded[:len(receipts)] {
+		check("trace_block", trace)
+	}
+}
+
+// Tests that trace_filter traces each block of a range straddling a fork
+// activation under the rules of that block, rather than a single rule set.
+func TestTraceFilterForkBoundary(t *testing.T) {
+	config := *params.TestChainConfig
+	config.IstanbulBlock = big.NewInt(2)
+
+	// Send a transaction with non-zero calldata into every block, its intrinsic
+	// gas dropping from 68 to 16 gas per calldata byte with EIP-2028 (Istanbul)
+	signer := types.NewEIP155Signer(config.GetChainID())
+	eth := newTestTraceBackendWithConfig(t, &config, 3, func(i int, block *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), common.Address{0x01}, big.NewInt(1), 50000, big.NewInt(1), []byte{0xff, 0xff, 0xff, 0xff}), signer, testBankKey)
+		block.AddTx(tx)
+	})
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("trace", NewPrivateTraceAPI(eth)); err != nil {
+		t.Fatalf("failed to register trace API: %v", err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	results := make(chan *blockTraceResult)
+	sub, err := client.Subscribe(context.Background(), "trace", results, "filter", TraceFilterArgs{FromBlock: 1, ToBlock: 3}, nil)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	for number, want := range []uint64{50000 - 21000 - 4*68, 50000 - 21000 - 4*16, 50000 - 21000 - 4*16} {
+		var result *blockTraceResult
+		select {
+		case result = <-results:
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for block %d", number+1)
+		}
+		if uint64(result.Block) != uint64(number+1) || len(result.Traces) != 1 {
+			t.Fatalf("block result mismatch: have block %d with %d traces", result.Block, len(result.Traces))
+		}
+		traces := result.Traces[0].Result.([]interface{})
+		gas := traces[0].(map[string]interface{})["action"].(map[string]interface{})["gas"].(string)
+		if have, _ := hexutil.DecodeUint64(gas); have != want {
+			t.Errorf("block %d: call gas mismatch: have %d, want %d", result.Block, have, want)
+		}
+	}
+}
+
+// Tests that trace_filter regenerates the pruned state it starts from within the
+// reexec limit, naming the block of the missing state otherwise.
+func TestTraceFilterPrunedStart(t *testing.T) {
+	eth := newTestTraceBackend(t, 4, testTransferBlocks(1))
+
+	// Prune the states of blocks 1 and 2, leaving the genesis state to regenerate
+	// them from. Tracing from block 3 fast-forwards from the state of block 1.
+	for n := uint64(1); n <= 2; n++ {
+		root := eth.blockchain.GetBlockByNumber(n).Root()
+		if err := eth.chainDb.Delete(root[:]); err != nil {
+			t.Fatalf("failed to prune state of block %d: %v", n, err)
+		}
+	}
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("trace", NewPrivateTraceAPI(eth)); err != nil {
+		t.Fatalf("failed to register trace API: %v", err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	reexec := uint64(0)
+	_, err := client.Subscribe(context.Background(), "trace", make(chan *blockTraceResult), "filter", TraceFilterArgs{FromBlock: 3, ToBlock: 4}, &TraceConfig{Reexec: &reexec})
+	if err == nil || !strings.Contains(err.Error(), errHistoricalStateUnavailable.Error()) || !strings.Contains(err.Error(), "block #1 ") {
+		t.Fatalf("error mismatch: have %v, want unavailable state of block #1", err)
+	}
+	reexec = 1
+	results := make(chan *blockTraceResult)
+	sub, err := client.Subscribe(context.Background(), "trace", results, "filter", TraceFilterArgs{FromBlock: 3, ToBlock: 4}, &TraceConfig{Reexec: &reexec})
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	for number := uint64(3); number <= 4; number++ {
+		select {
+		case result := <-results:
+			if uint64(result.Block) != number || len(result.Traces) != 1 || result.Traces[0].Error != "" {
+				t.Fatalf("block result mismatch: have block %d with %d traces", result.Block, len(result.Traces))
+			}
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for block %d", number)
+		}
+	}
+}
+
+// Tests that trace_filter stops streaming once the configured response size is
+// exceeded, notifying the block to resume from.
+func TestTraceFilterSizeLimit(t *testing.T) {
+	eth := newTestTraceBackend(t, 3, testTransferBlocks(2))
+
+	config := DefaultConfig
+	config.Trace.FilterSizeLimit = 1
+	eth.config = &config
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("trace", NewPrivateTraceAPI(eth)); err != nil {
+		t.Fatalf("failed to register trace API: %v", err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	results := make(chan *blockTraceResult)
+	sub, err := client.Subscribe(context.Background(), "trace", results, "filter", TraceFilterArgs{FromBlock: 1, ToBlock: 3}, nil)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	var traced []*blockTraceResult
+	for len(traced) < 2 {
+		select {
+		case result := <-results:
+			traced = append(traced, result)
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for block %d", len(traced))
+		}
+	}
+	// The first block is always delivered, the second one exceeds the limit
+	if traced[0].Block != 1 || traced[0].Truncated || len(traced[0].Traces) != 2 {
+		t.Errorf("first result mismatch: have block %d with %d traces, truncated %v", traced[0].Block, len(traced[0].Traces), traced[0].Truncated)
+	}
+	if traced[1].Block != 2 || !traced[1].Truncated || len(traced[1].Traces) != 0 {
+		t.Errorf("truncation marker mismatch: have block %d with %d traces, truncated %v", traced[1].Block, len(traced[1].Traces), traced[1].Truncated)
+	}
+	if want := eth.blockchain.GetBlockByNumber(2).Hash(); traced[1].Hash != want {
+		t.Errorf("truncation marker hash mismatch: have %x, want %x", traced[1].Hash, want)
+	}
+	select {
+	case result := <-results:
+		t.Errorf("unexpected result after truncation: block %d", result.Block)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// Tests that chain tracing stops at the first block failing to process unless
+// asked to continue, in which case the failed block is flagged with an error and
+// tracing resumes from its state if available.
+func TestTraceChainFailedBlock(t *testing.T) {
+	tests := []struct {
+		config  *TraceConfig
+		root    *common.Hash // Overrides the failed block's state root
+		resumed bool         // Whether block 3 gets traced
+	}{
+		{config: nil},
+		{config: &TraceConfig{ContinueOnFailure: true}, resumed: true},
+		{config: &TraceConfig{ContinueOnFailure: true}, root: &common.Hash{0x01}},
+	}
+	for i, tt := range tests {
+		eth := newTestTraceBackend(t, 3, testTransferBlocks(1))
+
+		// Replace block 2 with one failing to process on a transaction with a
+		// nonce gap, between two valid ones
+		signer := types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		sign := func(nonce uint64) *types.Transaction {
+			tx, _ := types.SignTx(types.NewTransaction(nonce, common.Address{0x02}, big.NewInt(1000), vars.TxGas, big.NewInt(1), nil), signer, testBankKey)
+			return tx
+		}
+		header := types.CopyHeader(eth.blockchain.GetBlockByNumber(2).Header())
+		if tt.root != nil {
+			header.Root = *tt.root
+		}
+		failing := types.NewBlock(header, []*types.Transaction{sign(1), sign(7), sign(2)}, nil, nil, new(trie.Trie))
+		rawdb.WriteBlock(eth.chainDb, failing)
+		rawdb.WriteCanonicalHash(eth.chainDb, failing.Hash(), 2)
+
+		server := rpc.NewServer()
+		if err := server.RegisterName("trace", NewPrivateTraceAPI(eth)); err != nil {
+			t.Fatalf("test %d: failed to register trace API: %v", i, err)
+		}
+		client := rpc.DialInProc(server)
+
+		results := make(chan *blockTraceResult)
+		sub, err := client.Subscribe(context.Background(), "trace", results, "filter", TraceFilterArgs{FromBlock: 1, ToBlock: 3}, tt.config)
+		if err != nil {
+			t.Fatalf("test %d: failed to subscribe: %v", i, err)
+		}
+		var traced []*blockTraceResult
+		for len(traced) < 2 {
+			select {
+			case result := <-results:
+				traced = append(traced, result)
+			case err := <-sub.Err():
+				t.Fatalf("test %d: subscription failed: %v", i, err)
+			case <-time.After(10 * time.Second):
+				t.Fatalf("test %d: timed out waiting for block %d", i, len(traced)+1)
+			}
+		}
+		if traced[0].Block != 1 || traced[0].Error != "" || len(traced[0].Traces) != 1 || traced[0].Traces[0].Error != "" {
+			t.Errorf("test %d: block 1 mismatch: %+v", i, traced[0])
+		}
+		// The failed block is flagged, along with its failed transaction and, if
+		// continuing, the ones after it which weren't traced
+		failed := traced[1]
+		if failed.Block != 2 || failed.Hash != failing.Hash() || failed.Error == "" || len(failed.Traces) != 3 {
+			t.Fatalf("test %d: failed block mismatch: %+v", i, failed)
+		}
+		if failed.Traces[0] == nil || failed.Traces[0].Error != "" || failed.Traces[1] == nil || failed.Traces[1].Error == "" {
+			t.Errorf("test %d: failed block traces mismatch: %+v %+v", i, failed.Traces[0], failed.Traces[1])
+		}
+		switch {
+		case tt.config == nil && failed.Traces[2] != nil:
+			t.Errorf("test %d: transaction after failure traced: %+v", i, failed.Traces[2])
+		case tt.config != nil && (failed.Traces[2] == nil || !strings.Contains(failed.Traces[2].Error, "not traced")):
+			t.Errorf("test %d: transaction after failure not flagged: %+v", i, failed.Traces[2])
+		}
+		if tt.root != nil && !strings.Contains(failed.Error, "unavailable") {
+			t.Errorf("test %d: resume failure not reported: %q", i, failed.Error)
+		}
+		// Tracing only goes on past the failed block if its state is available
+		select {
+		case result := <-results:
+			if !tt.resumed {
+				t.Errorf("test %d: unexpected result after failed block: block %d", i, result.Block)
+			} else if result.Block != 3 || result.Error != "" || len(result.Traces) != 1 || result.Traces[0].Error != "" {
+				t.Errorf("test %d: block 3 mismatch: %+v", i, result)
+			}
+		case <-time.After(time.Second):
+			if tt.resumed {
+				t.Errorf("test %d: timed out waiting for block 3", i)
+			}
+		}
+		sub.Unsubscribe()
+		client.Close()
+		server.Stop()
+	}
+}
+
+// Tests that a trace_filter subscription with the smallest buffer still streams
+// all the blocks of its range in order to a slow client.
+func TestTraceFilterBuffer(t *testing.T) {
+	eth := newTestTraceBackend(t, 4, testTransferBlocks(2))
+
+	config := DefaultConfig
+	config.Trace.FilterBuffer = 1
+	eth.config = &config
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("trace", NewPrivateTraceAPI(eth)); err != nil {
+		t.Fatalf("failed to register trace API: %v", err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	results := make(chan *blockTraceResult)
+	sub, err := client.Subscribe(context.Background(), "trace", results, "filter", TraceFilterArgs{FromBlock: 1, ToBlock: 4}, nil)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	for number := hexutil.Uint64(1); number <= 4; number++ {
+		time.Sleep(20 * time.Millisecond)
+		select {
+		case result := <-results:
+			if result.Block != number || len(result.Traces) != 2 {
+				t.Errorf("result mismatch: have block %d with %d traces, want block %d with %d", result.Block, len(result.Traces), number, 2)
+			}
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for block %d", number)
+		}
+	}
+}
+
+// Tests that the trace subscriptions active at once on a connection are limited,
+// without affecting other connections, and that unsubscribing frees a slot.
+func TestTraceSubscriptionLimit(t *testing.T) {
+	eth := newTestTraceBackend(t, 2, testTransferBlocks(1))
+	eth.traceSubs = newTraceSubLimiter(2)
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("trace", NewPrivateTraceAPI(eth)); err != nil {
+		t.Fatalf("failed to register trace API: %v", err)
+	}
+	subscribe := func(client *rpc.Client) (*rpc.ClientSubscription, error) {
+		return client.Subscribe(context.Background(), "trace", make(chan *blockTraceResult, 2), "filter", TraceFilterArgs{FromBlock: 1, ToBlock: 2}, nil)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	var subs []*rpc.ClientSubscription
+	for i := 0; i < 2; i++ {
+		sub, err := subscribe(client)
+		if err != nil {
+			t.Fatalf("subscription %d: failed to subscribe: %v", i, err)
+		}
+		defer sub.Unsubscribe()
+		subs = append(subs, sub)
+	}
+	if _, err := subscribe(client); err == nil {
+		t.Fatalf("expected subscription beyond the limit to fail")
+	}
+	// Other connections have slots of their own
+	other := rpc.DialInProc(server)
+	defer other.Close()
+
+	sub, err := subscribe(other)
+	if err != nil {
+		t.Fatalf("failed to subscribe on another connection: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	// Unsubscribing frees a slot, once the server processed the request
+	subs[0].Unsubscribe()
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		sub, err := subscribe(client)
+		if err == nil {
+			sub.Unsubscribe()
+			break
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("slot not freed after unsubscribing: %v", err)
+		}
+	}
+}
+
+// Tests that the node-wide redaction policy strips or hashes the configured
+// fields of all the traces returned by trace_block.
+func TestTraceBlockRedaction(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(1))
+	api := NewPrivateTraceAPI(eth)
+
+	config := DefaultConfig
+	config.Trace.Redact = []string{"action.input", "action.author"}
+	eth.config = &config
+
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(1), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	for i, trace := range traces {
+		action := trace.(map[string]interface{})["action"].(map[string]interface{})
+		if _, ok := action["input"]; ok {
+			t.Errorf("trace %d: input not redacted", i)
+		}
+		if _, ok := action["author"]; ok {
+			t.Errorf("trace %d: author not redacted", i)
+		}
+		if _, ok := action["value"]; !ok {
+			t.Errorf("trace %d: value redacted", i)
+		}
+	}
+	config.Trace.RedactHash = true
+
+	traces, err = api.Block(context.Background(), rpc.BlockNumber(1), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if have, want := traces[0].(map[string]interface{})["action"].(map[string]interface{})["input"], crypto.Keccak256Hash(nil); have != want {
+		t.Errorf("input hash mismatch: have %v, want %v", have, want)
+	}
+	coinbase := eth.blockchain.GetBlockByNumber(1).Coinbase()
+	if have, want := traces[1].(map[string]interface{})["action"].(map[string]interface{})["author"], crypto.Keccak256Hash(coinbase.Bytes()); have != want {
+		t.Errorf("author hash mismatch: have %v, want %v", have, want)
+	}
+}
+
+// Tests that the state calls are traced on is resolved from the selected block,
+// either by number or by hash, including non-canonical blocks the state of which
+// needs to be regenerated.
+func TestTraceCallStateAt(t *testing.T) {
+	eth := newTestTraceBackend(t, 2, testTransferBlocks(1))
+
+	// Store a side chain block sending a different amount, without its state so
+	// that it needs to be regenerated
+	side := generateTestTraceChain(1, testSideTransferBlocks)
+	rawdb.WriteBlock(eth.chainDb, side[0])
+	reexec := uint64(0)
+
+	tests := []struct {
+		selector rpc.BlockNumberOrHash
+		config   *TraceConfig
+		balance  int64
+		fail     bool
+	}{
+		{selector: rpc.BlockNumberOrHashWithNumber(1), balance: 1000},
+		{selector: rpc.BlockNumberOrHashWithHash(eth.blockchain.GetBlockByNumber(2).Hash(), true), balance: 2000},
+		{selector: rpc.BlockNumberOrHashWithHash(side[0].Hash(), false), balance: 5000},
+		{selector: rpc.BlockNumberOrHashWithHash(side[0].Hash(), true), fail: true},
+		{selector: rpc.BlockNumberOrHashWithHash(side[0].Hash(), false), config: &TraceConfig{Reexec: &reexec}, fail: true},
+		{selector: rpc.BlockNumberOrHashWithHash(common.Hash{0xff}, false), fail: true},
+	}
+	for i, tt := range tests {
+		statedb, header, err := traceCallStateAt(context.Background(), eth, tt.selector, tt.config)
+		if tt.fail {
+			if err == nil {
+				t.Errorf("test %d: expected failure", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %d: failed to retrieve state: %v", i, err)
+			continue
+		}
+		if balance := statedb.GetBalance(common.Address{0x01}); balance.Cmp(big.NewInt(tt.balance)) != 0 {
+			t.Errorf("test %d: balance mismatch: have %v, want %v", i, balance, tt.balance)
+		}
+		if hash, ok := tt.selector.Hash(); ok && header.Hash() != hash {
+			t.Errorf("test %d: header mismatch: have %x, want %x", i, header.Hash(), hash)
+		}
+	}
+}
+
+// Tests that trace_call simulates on top of the post-state of the selected block,
+// in its block context, whether selected by number or by hash, like eth_call.
+func TestTraceCallBlockState(t *testing.T) {
+	eth := newTestTraceBackend(t, 3, testTransferBlocks(1))
+	api := NewPrivateTraceAPI(eth)
+
+	// Store a side chain block without its state, which needs to be regenerated
+	side := generateTestTraceChain(1, testSideTransferBlocks)
+	rawdb.WriteBlock(eth.chainDb, side[0])
+
+	// Creation code returning the balance of the transfer recipient and the
+	// number of the block it executes in as the deployed code
+	probe := append([]byte{0x73}, common.Address{0x01}.Bytes()...)
+	probe = append(probe,
+		0x31, 0x60, 0x00, 0x52, // MSTORE(0, BALANCE(recipient))
+		0x43, 0x60, 0x20, 0x52, // MSTORE(32, NUMBER)
+		0x60, 0x40, 0x60, 0x00, 0xf3, // RETURN(0, 64)
+	)
+	data := hexutil.Bytes(probe)
+
+	type selector struct {
+		selector rpc.BlockNumberOrHash
+		number   uint64
+		balance  int64
+	}
+	tests := []selector{
+		{selector: rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), number: 3, balance: 3000},
+		{selector: rpc.BlockNumberOrHashWithNumber(rpc.EarliestBlockNumber), number: 0, balance: 0},
+		{selector: rpc.BlockNumberOrHashWithHash(side[0].Hash(), false), number: 1, balance: 5000},
+	}
+	for n := uint64(0); n <= 3; n++ {
+		tests = append(tests,
+			selector{selector: rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(n)), number: n, balance: int64(n) * 1000},
+			selector{selector: rpc.BlockNumberOrHashWithHash(eth.blockchain.GetCanonicalHash(n), true), number: n, balance: int64(n) * 1000},
+		)
+	}
+	for i, tt := range tests {
+		res, err := api.Call(context.Background(), ethapi.CallArgs{Data: &data}, tt.selector, nil)
+		if err != nil {
+			t.Errorf("test %d: failed to trace call: %v", i, err)
+			continue
+		}
+		blob, _ := json.Marshal(res)
+		var traces []struct {
+			Result struct {
+				Code hexutil.Bytes `json:"code"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(blob, &traces); err != nil || len(traces) != 1 || len(traces[0].Result.Code) != 64 {
+			t.Errorf("test %d: unexpected trace: %s", i, blob)
+			continue
+		}
+		code := traces[0].Result.Code
+		if balance := new(big.Int).SetBytes(code[:32]); balance.Cmp(big.NewInt(tt.balance)) != 0 {
+			t.Errorf("test %d: balance mismatch: have %v, want %v", i, balance, tt.balance)
+		}
+		if number := new(big.Int).SetBytes(code[32:]); number.Uint64() != tt.number {
+			t.Errorf("test %d: block number mismatch: have %v, want %v", i, number, tt.number)
+		}
+	}
+}
+
+// Tests that the freeGas option runs traced calls with a zero gas price, so that
+// senders without ether can be simulated with any requested gas price.
+func TestTraceCallFreeGas(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(1))
+	api := NewPrivateTraceAPI(eth)
+
+	// Creation code returning the gas price and the balance of its sender
+	probe := hexutil.Bytes{
+		0x3a, 0x60, 0x00, 0x52, // MSTORE(0, GASPRICE)
+		0x33, 0x31, 0x60, 0x20, 0x52, // MSTORE(32, BALANCE(CALLER))
+		0x60, 0x40, 0x60, 0x00, 0xf3, // RETURN(0, 64)
+	}
+	var (
+		pauper   = common.Address{0xbb}
+		gas      = hexutil.Uint64(100000)
+		gasPrice = (*hexutil.Big)(big.NewInt(vars.GWei))
+		latest   = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	statedb, err := eth.blockchain.State()
+	if err != nil {
+		t.Fatalf("failed to retrieve state: %v", err)
+	}
+	tests := []struct {
+		from    common.Address
+		balance *big.Int
+	}{
+		{pauper, new(big.Int)},
+		{testBank, statedb.GetBalance(testBank)},
+	}
+	for i, tt := range tests {
+		args := ethapi.CallArgs{From: &tt.from, Gas: &gas, GasPrice: gasPrice, Data: &probe}
+
+		call, err := api.Call(context.Background(), args, latest, &TraceConfig{FreeGas: true})
+		if err != nil {
+			t.Fatalf("test %d: failed to trace call: %v", i, err)
+		}
+		many, err := api.CallMany(context.Background(), []ethapi.CallArgs{args}, latest, &TraceConfig{FreeGas: true})
+		if err != nil {
+			t.Fatalf("test %d: failed to trace calls: %v", i, err)
+		}
+		for j, res := range []interface{}{call, many.([]interface{})[0]} {
+			blob, _ := json.Marshal(res)
+			var traces []struct {
+				Result struct {
+					Code hexutil.Bytes `json:"code"`
+				} `json:"result"`
+			}
+			if err := json.Unmarshal(blob, &traces); err != nil || len(traces) != 1 || len(traces[0].Result.Code) != 64 {
+				t.Fatalf("test %d/%d: unexpected trace: %s", i, j, blob)
+			}
+			code := traces[0].Result.Code
+			if price := new(big.Int).SetBytes(code[:32]); price.Sign() != 0 {
+				t.Errorf("test %d/%d: gas price mismatch: have %v, want 0", i, j, price)
+			}
+			if balance := new(big.Int).SetBytes(code[32:]); balance.Cmp(tt.balance) != 0 {
+				t.Errorf("test %d/%d: balance mismatch: have %v, want %v", i, j, balance, tt.balance)
+			}
+		}
+	}
+	// Without the option, the requested gas price is used
+	args := ethapi.CallArgs{From: &testBank, Gas: &gas, GasPrice: gasPrice, Data: &probe}
+	res, err := api.Call(context.Background(), args, latest, nil)
+	if err != nil {
+		t.Fatalf("failed to trace call: %v", err)
+	}
+	blob, _ := json.Marshal(res)
+	var traces []struct {
+		Result struct {
+			Code hexutil.Bytes `json:"code"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(blob, &traces); err != nil || len(traces) != 1 || len(traces[0].Result.Code) != 64 {
+		t.Fatalf("unexpected trace: %s", blob)
+	}
+	if price := new(big.Int).SetBytes(traces[0].Result.Code[:32]); price.Cmp(gasPrice.ToInt()) != 0 {
+		t.Errorf("gas price mismatch: have %v, want %v", price, gasPrice)
+	}
+}
+
+// Tests that trace_call, trace_callMany and debug_traceCall execute the calls on
+// the state overridden like eth_call's.
+func TestTraceCallStateOverrides(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(1))
+	api := NewPrivateTraceAPI(eth)
+
+	// Code returning its storage slot 0 and the balance of the transfer recipient
+	runtime := hexutil.Bytes{0x60, 0x00, 0x54, 0x60, 0x00, 0x52} // MSTORE(0, SLOAD(0))
+	runtime = append(runtime, 0x73)
+	runtime = append(runtime, common.Address{0x01}.Bytes()...)
+	runtime = append(runtime,
+		0x31, 0x60, 0x20, 0x52, // MSTORE(32, BALANCE(recipient))
+		0x60, 0x40, 0x60, 0x00, 0xf3, // RETURN(0, 64)
+	)
+	var (
+		target  = common.Address{0xcc}
+		balance = (*hexutil.Big)(big.NewInt(12345))
+		slots   = map[common.Hash]common.Hash{{}: common.BigToHash(big.NewInt(42))}
+		latest  = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		args    = ethapi.CallArgs{To: &target}
+		want    = append(common.BigToHash(big.NewInt(42)).Bytes(), common.BigToHash(balance.ToInt()).Bytes()...)
+	)
+	overrides := &ethapi.StateOverride{
+		target:               {Code: &runtime, StateDiff: &slots},
+		common.Address{0x01}: {Balance: &balance},
+	}
+	config := &TraceConfig{StateOverrides: overrides}
+
+	call, err := api.Call(context.Background(), args, latest, config)
+	if err != nil {
+		t.Fatalf("failed to trace call: %v", err)
+	}
+	many, err := api.CallMany(context.Background(), []ethapi.CallArgs{args, args}, latest, config)
+	if err != nil {
+		t.Fatalf("failed to trace calls: %v", err)
+	}
+	for i, res := range append([]interface{}{call}, many.([]interface{})...) {
+		blob, _ := json.Marshal(res)
+		var traces []struct {
+			Result struct {
+				Output hexutil.Bytes `json:"output"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(blob, &traces); err != nil || len(traces) != 1 {
+			t.Fatalf("trace %d: unexpected trace: %s", i, blob)
+		}
+		if !bytes.Equal(traces[0].Result.Output, want) {
+			t.Errorf("trace %d: output mismatch: have %x, want %x", i, traces[0].Result.Output, want)
+		}
+	}
+	// The debug namespace shares the override handling
+	res, err := NewPrivateDebugAPI(eth).TraceCall(context.Background(), args, latest, &TraceConfig{StateOverrides: overrides})
+	if err != nil {
+		t.Fatalf("failed to debug trace call: %v", err)
+	}
+	if output := res.(*ethapi.ExecutionResult).ReturnValue; output != common.Bytes2Hex(want) {
+		t.Errorf("debug output mismatch: have %s, want %x", output, want)
+	}
+	// Conflicting overrides are rejected, and so are overrides of blocks
+	conflicting := &ethapi.StateOverride{target: {State: &slots, StateDiff: &slots}}
+	if _, err := api.Call(context.Background(), args, latest, &TraceConfig{StateOverrides: conflicting}); err == nil {
+		t.Errorf("expected error for conflicting storage overrides")
+	}
+	if _, err := api.Block(context.Background(), rpc.BlockNumber(1), config); err == nil {
+		t.Errorf("expected error for state overrides of a block trace")
+	}
+}
+
+// Tests that trace_since traces the canonical blocks following the last processed
+// one and reports the processed blocks rolled back by a reorg.
+func TestTraceSince(t *testing.T) {
+	eth := newTestTraceBackend(t, 2, testTransferBlocks(1))
+	api := NewPrivateTraceAPI(eth)
+
+	result, err := api.Since(context.Background(), TraceSinceArgs{Number: 0}, nil)
+	if err != nil {
+		t.Fatalf("failed to trace new blocks: %v", err)
+	}
+	if len(result.Removed) != 0 || len(result.Blocks) != 2 {
+		t.Fatalf("result mismatch: have %d removed and %d blocks, want 0 and 2", len(result.Removed), len(result.Blocks))
+	}
+	for i, block := range result.Blocks {
+		if block.Number != hexutil.Uint64(i+1) || block.Hash != eth.blockchain.GetCanonicalHash(uint64(i+1)) || len(block.Traces) != 2 {
+			t.Errorf("block %d mismatch: have #%d %x with %d traces", i, block.Number, block.Hash, len(block.Traces))
+		}
+	}
+	last := result.Blocks[1].TraceBlockRef
+
+	// Reorg the processed blocks out with a longer side chain
+	side := generateTestTraceChain(3, testSideTransferBlocks)
+	if _, err := eth.blockchain.InsertChain(side); err != nil {
+		t.Fatalf("failed to insert side chain: %v", err)
+	}
+	count := hexutil.Uint64(2)
+	result, err = api.Since(context.Background(), TraceSinceArgs{Number: last.Number, Hash: &last.Hash, Count: &count}, nil)
+	if err != nil {
+		t.Fatalf("failed to trace new blocks: %v", err)
+	}
+	if len(result.Removed) != 2 || result.Removed[0] != last || result.Removed[1].Number != 1 {
+		t.Errorf("removed blocks mismatch: have %v", result.Removed)
+	}
+	if len(result.Blocks) != 2 || result.Blocks[0].Hash != side[0].Hash() || result.Blocks[1].Hash != side[1].Hash() {
+		t.Fatalf("traced blocks mismatch: have %d blocks", len(result.Blocks))
+	}
+	if _, err := api.Since(context.Background(), TraceSinceArgs{Number: 1, Hash: &last.Hash}, nil); err == nil {
+		t.Errorf("expected error for mismatching block number and hash")
+	}
+}
+
+// Tests that the new block traces subscription notifies the traces of each block
+// imported into the canonical chain, and the removal of reorged out blocks.
+func TestTraceNewBlockTraces(t *testing.T) {
+	eth := newTestTraceBackend(t, 0, nil)
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("trace", NewPrivateTraceAPI(eth)); err != nil {
+		t.Fatalf("failed to register trace API: %v", err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	notifications := make(chan *TraceSinceBlock)
+	sub, err := client.Subscribe(context.Background(), "trace", notifications, "newBlockTraces", nil)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	expect := func(removed bool, blocks ...*types.Block) {
+		for _, block := range blocks {
+			select {
+			case have := <-notifications:
+				if have.Hash != block.Hash() || have.Removed != removed {
+					t.Fatalf("notification mismatch: have #%d %x (removed %v), want #%d %x (removed %v)", have.Number, have.Hash, have.Removed, block.NumberU64(), block.Hash(), removed)
+				}
+				if !removed && len(have.Traces) != len(block.Transactions())+1 {
+					t.Errorf("block #%d trace count mismatch: have %d, want %d", have.Number, len(have.Traces), len(block.Transactions())+1)
+				}
+			case err := <-sub.Err():
+				t.Fatalf("subscription failed: %v", err)
+			case <-time.After(10 * time.Second):
+				t.Fatalf("timed out waiting for block #%d", block.NumberU64())
+			}
+		}
+	}
+	// Import a chain, then reorg it out with a longer one
+	chain := generateTestTraceChain(2, testTransferBlocks(1))
+	if _, err := eth.blockchain.InsertChain(chain); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	expect(false, chain...)
+
+	side := generateTestTraceChain(3, testSideTransferBlocks)
+	if _, err := eth.blockchain.InsertChain(side); err != nil {
+		t.Fatalf("failed to insert side chain: %v", err)
+	}
+	expect(true, chain[1], chain[0])
+	expect(false, side...)
+}
+
+// Tests that the value and gas fields of the traces can be encoded as decimal
+// strings, representing the same quantities as the default hex encoding.
+func TestTraceBlockDecimalValues(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(1))
+	api := NewPrivateTraceAPI(eth)
+
+	hex, err := api.Block(context.Background(), rpc.BlockNumber(1), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	dec, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{DecimalValues: true})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	// Decode both encodings from their JSON form and compare the quantities
+	decode := func(traces []interface{}) []map[string]interface{} {
+		blob, err := json.Marshal(traces)
+		if err != nil {
+			t.Fatalf("failed to encode traces: %v", err)
+		}
+		var objects []map[string]interface{}
+		if err := json.Unmarshal(blob, &objects); err != nil {
+			t.Fatalf("failed to decode traces: %v", err)
+		}
+		return objects
+	}
+	hexTraces, decTraces := decode(hex), decode(dec)
+	if len(hexTraces) != 2 || len(decTraces) != 2 {
+		t.Fatalf("trace count mismatch: have %d and %d, want 2", len(hexTraces), len(decTraces))
+	}
+	checked := 0
+	for i := range hexTraces {
+		for name, fields := range parityTraceQuantities {
+			hexObject, _ := hexTraces[i][name].(map[string]interface{})
+			decObject, _ := decTraces[i][name].(map[string]interface{})
+			for _, field := range fields {
+				value, ok := hexObject[field].(string)
+				if !ok {
+					continue
+				}
+				want := hexutil.MustDecodeBig(value)
+				have, ok := new(big.Int).SetString(decObject[field].(string), 10)
+				if !ok || have.Cmp(want) != 0 {
+					t.Errorf("trace %d %s.%s mismatch: have %v, want %v", i, name, field, decObject[field], want)
+				}
+				checked++
+			}
+		}
+	}
+	// Call value, gas and gas used, reward value
+	if checked != 4 {
+		t.Errorf("checked quantity count mismatch: have %d, want %d", checked, 4)
+	}
+	if value := decTraces[0]["action"].(map[string]interface{})["value"]; value != "1000" {
+		t.Errorf("call value mismatch: have %v, want %v", value, "1000")
+	}
+}
+
+// Tests that trace_transaction traces transactions from the pool which are not
+// yet mined on top of the pending state, marking the traces as pending.
+func TestTraceTransactionPending(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, testTransferBlocks(1))
+	pool := core.DefaultTxPoolConfig
+	pool.Journal = ""
+	eth.txPool = core.NewTxPool(pool, params.TestChainConfig, eth.blockchain)
+	defer eth.txPool.Stop()
+	eth.miner = miner.New(eth, &DefaultConfig.Miner, params.TestChainConfig, new(event.TypeMux), eth.engine, nil)
+	defer eth.miner.Close()
+
+	tx, _ := types.SignTx(types.NewTransaction(1, common.Address{0x02}, big.NewInt(1000), vars.TxGas, big.NewInt(vars.GWei), nil), types.HomesteadSigner{}, testBankKey)
+	if err := eth.txPool.AddLocal(tx); err != nil {
+		t.Fatalf("failed to add transaction to the pool: %v", err)
+	}
+	// Wait for the transaction to make it into the pending block
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		if block := eth.miner.PendingBlock(); block != nil && len(block.Transactions()) == 1 {
+			break
+		}
+		if time.Since(start) > 10*time.Second {
+			t.Fatalf("transaction not included in the pending block")
+		}
+	}
+	res, err := NewPrivateTraceAPI(eth).Transaction(context.Background(), tx.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to trace pending transaction: %v", err)
+	}
+	traces := res.([]interface{})
+	if len(traces) != 1 {
+		t.Fatalf("trace count mismatch: have %d, want %d", len(traces), 1)
+	}
+	trace := traces[0].(map[string]interface{})
+	if trace["pending"] != true || trace["blockNumber"] != float64(2) || trace["transactionPosition"] != float64(0) {
+		t.Errorf("pending trace mismatch: have %v", trace)
+	}
+	if _, ok := trace["blockHash"]; ok {
+		t.Errorf("pending trace has block hash: %v", trace["blockHash"])
+	}
+	if to := trace["action"].(map[string]interface{})["to"]; to != "0x0200000000000000000000000000000000000000" {
+		t.Errorf("recipient mismatch: have %v", to)
+	}
+}
+
+// Tests that calls to the data dependent precompiles are only traced on request,
+// reporting the exact gas charged by the precompile under the active rules.
+func TestTraceTransactionPrecompiles(t *testing.T) {
+	// precompileCaller returns the init code of a contract forwarding its calldata
+	// to the given precompile with a STATICCALL of all its gas.
+	precompileCaller := func(precompile byte) []byte {
+		runtime := []byte{
+			0x36, 0x60, 0x00, 0x60, 0x00, 0x37, // CALLDATACOPY(0, 0, CALLDATASIZE)
+			0x60, 0x00, 0x60, 0x00, 0x36, 0x60, 0x00, 0x60, precompile, 0x5a, 0xfa, // STATICCALL(GAS, precompile, 0, CALLDATASIZE, 0, 0)
+			0x00, // STOP
+		}
+		return append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+	}
+	tests := []struct {
+		name       string
+		precompile byte
+		input      []byte
+	}{
+		{"modexp", 0x05, common.FromHex("00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000002003fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2efffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")},
+		{"bn256Pairing", 0x08, common.FromHex("1c76476f4def4bb94541d57ebba1193381ffa7aa76ada664dd31c16024c43f593034dd2920f673e204fee2811c678745fc819b55d3e9d294e45c9b03a76aef41209dd15ebff5d46c4bd888e51a93cf99a7329636c63514396b4a452003a35bf704bf11ca01483bfa8b34b43561848d28905960114c8ac04049af4b6315a416782bb8324af6cfc93537a2ad1a445cfd0ca2a71acd7ac41fadbf933c2a51be344d120a2a4cf30c1bf9845f20c6fe39e07ea2cce61f0c9bb048165fe5e4de877550111e129f1cf1097710d41c4ac70fcdfa5ba2023c6ff1cbeac322de49d1b6df7c2032c61a830e3c17286de9462bf242fca2883585b93870a73853face6a6bf411198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa")},
+		{"blake2F", 0x09, common.FromHex("0000000c48c9bdf267e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d182e6ad7f520e511f6c3e2b8c68059b6bbd41fbabd9831f79217e1319cde05b61626300000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000300000000000000000000000000000001")},
+	}
+	signer := types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+	eth := newTestTraceBackend(t, 2, func(i int, block *core.BlockGen) {
+		for j, tt := range tests {
+			var tx *types.Transaction
+			if i == 0 {
+				tx = types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 100000, big.NewInt(1), precompileCaller(tt.precompile))
+			} else {
+				tx = types.NewTransaction(block.TxNonce(testBank), crypto.CreateAddress(testBank, uint64(j)), new(big.Int), 300000, big.NewInt(1), tt.input)
+			}
+			tx, _ = types.SignTx(tx, signer, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+	block := eth.blockchain.GetBlockByNumber(2)
+
+	for i, tt := range tests {
+		var (
+			address    = common.BytesToAddress([]byte{tt.precompile})
+			precompile = vm.PrecompiledContractsForConfig(eth.blockchain.Config(), block.Number())[address]
+		)
+		output, err := precompile.Run(tt.input)
+		if err != nil {
+			t.Fatalf("%s: failed to run precompile: %v", tt.name, err)
+		}
+		for _, include := range []bool{false, true} {
+			res, err := api.Transaction(context.Background(), block.Transactions()[i].Hash(), &TraceConfig{IncludePrecompiles: include})
+			if err != nil {
+				t.Fatalf("%s: failed to trace transaction: %v", tt.name, err)
+			}
+			var traces []struct {
+				Action struct {
+					To    common.Address `json:"to"`
+					Input hexutil.Bytes  `json:"input"`
+				} `json:"action"`
+				Result struct {
+					GasUsed hexutil.Uint64 `json:"gasUsed"`
+					Output  hexutil.Bytes  `json:"output"`
+				} `json:"result"`
+				TraceAddress []int `json:"traceAddress"`
+			}
+			if err := json.Unmarshal(res.(json.RawMessage), &traces); err != nil {
+				t.Fatalf("%s: failed to decode traces: %v", tt.name, err)
+			}
+			if !include {
+				if len(traces) != 1 {
+					t.Errorf("%s: precompile traced by default: %d traces", tt.name, len(traces))
+				}
+				continue
+			}
+			if len(traces) != 2 {
+				t.Fatalf("%s: trace count mismatch: have %d, want %d", tt.name, len(traces), 2)
+			}
+			call := traces[1]
+			if call.Action.To != address || !bytes.Equal(call.Action.Input, tt.input) || !reflect.DeepEqual(call.TraceAddress, []int{0}) {
+				t.Errorf("%s: precompile call mismatch: to %x, input %x, trace address %v", tt.name, call.Action.To, call.Action.Input, call.TraceAddress)
+			}
+			if want := precompile.RequiredGas(tt.input); uint64(call.Result.GasUsed) != want {
+				t.Errorf("%s: gas used mismatch: have %d, want %d", tt.name, call.Result.GasUsed, want)
+			}
+			if !bytes.Equal(call.Result.Output, output) {
+				t.Errorf("%s: output mismatch: have %x, want %x", tt.name, call.Result.Output, output)
+			}
+		}
+	}
+}
+
+// Tests that transactions calling a precompile directly are traced as a single
+// top level call, whether precompile calls are included or not, charging the
+// precompile's gas on top of the intrinsic gas.
+func TestTracePrecompileTransaction(t *testing.T) {
+	tests := []struct {
+		name       string
+		precompile byte
+		gas        uint64
+		input      []byte
+		fail       bool
+	}{
+		{"ecrecover", 0x01, 50000, make([]byte, 128), false},
+		{"sha256", 0x02, 50000, []byte("abc"), false},
+		{"sha256OutOfGas", 0x02, vars.TxGas + 3*vars.TxDataNonZeroGasEIP2028 + 10, []byte("abc"), true},
+	}
+	signer := types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+	eth := newTestTraceBackend(t, 1, func(i int, block *core.BlockGen) {
+		for _, tt := range tests {
+			tx := types.NewTransaction(block.TxNonce(testBank), common.BytesToAddress([]byte{tt.precompile}), new(big.Int), tt.gas, big.NewInt(1), tt.input)
+			tx, _ = types.SignTx(tx, signer, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+	block := eth.blockchain.GetBlockByNumber(1)
+	receipts := eth.blockchain.GetReceiptsByHash(block.Hash())
+
+	for i, tt := range tests {
+		var (
+			address    = common.BytesToAddress([]byte{tt.precompile})
+			precompile = vm.PrecompiledContractsForConfig(eth.blockchain.Config(), block.Number())[address]
+		)
+		output, err := precompile.Run(tt.input)
+		if err != nil {
+			t.Fatalf("%s: failed to run precompile: %v", tt.name, err)
+		}
+		intrinsic, err := core.IntrinsicGas(tt.input, false, true, true)
+		if err != nil {
+			t.Fatalf("%s: failed to compute intrinsic gas: %v", tt.name, err)
+		}
+		for _, include := range []bool{false, true} {
+			res, err := api.Transaction(context.Background(), block.Transactions()[i].Hash(), &TraceConfig{IncludePrecompiles: include})
+			if err != nil {
+				t.Fatalf("%s: failed to trace transaction: %v", tt.name, err)
+			}
+			var traces []struct {
+				Type   string `json:"type"`
+				Action struct {
+					To  common.Address `json:"to"`
+					Gas hexutil.Uint64 `json:"gas"`
+				} `json:"action"`
+				Result *struct {
+					GasUsed hexutil.Uint64 `json:"gasUsed"`
+					Output  hexutil.Bytes  `json:"output"`
+				} `json:"result"`
+				Error        string `json:"error"`
+				TraceAddress []int  `json:"traceAddress"`
+				Subtraces    int    `json:"subtraces"`
+			}
+			if err := json.Unmarshal(res.(json.RawMessage), &traces); err != nil {
+				t.Fatalf("%s: failed to decode traces: %v", tt.name, err)
+			}
+			if len(traces) != 1 {
+				t.Fatalf("%s, include %v: trace count mismatch: have %d, want 1", tt.name, include, len(traces))
+			}
+			root := traces[0]
+			if root.Type != "call" || root.Action.To != address || len(root.TraceAddress) != 0 || root.Subtraces != 0 {
+				t.Errorf("%s, include %v: root trace mismatch: type %s, to %x, trace address %v, subtraces %d", tt.name, include, root.Type, root.Action.To, root.TraceAddress, root.Subtraces)
+			}
+			if want := tt.gas - intrinsic; uint64(root.Action.Gas) != want {
+				t.Errorf("%s, include %v: gas mismatch: have %d, want %d", tt.name, include, root.Action.Gas, want)
+			}
+			if tt.fail {
+				if root.Error != "Out of gas" || root.Result != nil {
+					t.Errorf("%s, include %v: failure mismatch: error %q, result %v", tt.name, include, root.Error, root.Result)
+				}
+				continue
+			}
+			if root.Result == nil || root.Error != "" {
+				t.Fatalf("%s, include %v: missing result: error %q", tt.name, include, root.Error)
+			}
+			if want := precompile.RequiredGas(tt.input); uint64(root.Result.GasUsed) != want {
+				t.Errorf("%s, include %v: gas used mismatch: have %d, want %d", tt.name, include, root.Result.GasUsed, want)
+			}
+			if want := receipts[i].GasUsed - intrinsic; uint64(root.Result.GasUsed) != want {
+				t.Errorf("%s, include %v: gas used mismatch with receipt: have %d, want %d", tt.name, include, root.Result.GasUsed, want)
+			}
+			if !bytes.Equal(root.Result.Output, output) {
+				t.Errorf("%s, include %v: output mismatch: have %x, want %x", tt.name, include, root.Result.Output, output)
+			}
+		}
+	}
+}
+
+// Tests that trace_transactionFormats returns matching Parity and Geth traces
+// of a transaction, refusing to bypass the redaction policy.
+func TestTraceTransactionFormats(t *testing.T) {
+	var (
+		signer = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+
+		// A contract returning the word 42
+		runtime  = []byte{0x60, 0x2a, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3}
+		initcode = append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+		contract = crypto.CreateAddress(testBank, 0)
+	)
+	eth := newTestTraceBackend(t, 2, func(i int, block *core.BlockGen) {
+		tx := types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 100000, big.NewInt(1), initcode)
+		if i == 1 {
+			tx = types.NewTransaction(block.TxNonce(testBank), contract, big.NewInt(1), 100000, big.NewInt(1), nil)
+		}
+		tx, _ = types.SignTx(tx, signer, testBankKey)
+		block.AddTx(tx)
+	})
+	api := NewPrivateTraceAPI(eth)
+	tx := eth.blockchain.GetBlockByNumber(2).Transactions()[0]
+
+	res, err := api.TransactionFormats(context.Background(), tx.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	var parity []struct {
+		Action struct {
+			From  common.Address `json:"from"`
+			To    common.Address `json:"to"`
+			Value hexutil.Big    `json:"value"`
+		} `json:"action"`
+		Result struct {
+			GasUsed hexutil.Uint64 `json:"gasUsed"`
+			Output  hexutil.Bytes  `json:"output"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(res.Parity.(json.RawMessage), &parity); err != nil {
+		t.Fatalf("failed to decode Parity trace: %v", err)
+	}
+	var geth struct {
+		Type    string         `json:"type"`
+		From    common.Address `json:"from"`
+		To      common.Address `json:"to"`
+		Value   hexutil.Big    `json:"value"`
+		GasUsed hexutil.Uint64 `json:"gasUsed"`
+		Output  hexutil.Bytes  `json:"output"`
+	}
+	if err := json.Unmarshal(res.Geth.(json.RawMessage), &geth); err != nil {
+		t.Fatalf("failed to decode Geth trace: %v", err)
+	}
+	if len(parity) != 1 {
+		t.Fatalf("Parity trace count mismatch: have %d, want %d", len(parity), 1)
+	}
+	root := parity[0]
+	if geth.Type != "CALL" || geth.From != root.Action.From || geth.To != root.Action.To || geth.Value.ToInt().Cmp(root.Action.Value.ToInt()) != 0 || !bytes.Equal(geth.Output, root.Result.Output) {
+		t.Errorf("format mismatch:\nparity %s\ngeth   %s", res.Parity, res.Geth)
+	}
+	// Neither format accounts for the intrinsic gas
+	if geth.GasUsed != root.Result.GasUsed {
+		t.Errorf("gas used mismatch: geth %d, parity %d", geth.GasUsed, root.Result.GasUsed)
+	}
+	// Only the Parity trace can be redacted
+	config := DefaultConfig
+	config.Trace.Redact = []string{"action.input"}
+	eth.config = &config
+	if _, err := api.TransactionFormats(context.Background(), tx.Hash(), nil); err == nil {
+		t.Errorf("expected redacted trace formats to fail")
+	}
+}
+
+// Tests that the Parity traces are optionally restricted to the failed
+// transactions, the successful ones with failed internal calls being retained
+// only if requested.
+func TestTraceBlockFailedTransactionsOnly(t *testing.T) {
+	var (
+		signer = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+
+		reverter     = []byte{0x60, 0x00, 0x60, 0x00, 0xfd} // REVERT(0, 0)
+		reverterAddr = crypto.CreateAddress(testBank, 0)
+		caller       = crypto.CreateAddress(testBank, 1)
+	)
+	// A contract calling the reverter, then succeeding
+	callerCode := append([]byte{0x60, 0x00, 0x80, 0x80, 0x80, 0x80, 0x73}, reverterAddr.Bytes()...)
+	callerCode = append(callerCode, 0x5a, 0xf1, 0x50, 0x00) // POP(CALL(GAS, reverter, 0, 0, 0, 0, 0)), STOP
+
+	eth := newTestTraceBackend(t, 2, func(i int, block *core.BlockGen) {
+		var txs []*types.Transaction
+		switch i {
+		case 0:
+			for _, runtime := range [][]byte{reverter, callerCode} {
+				initcode := append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+				txs = append(txs, types.NewContractCreation(block.TxNonce(testBank)+uint64(len(txs)), new(big.Int), 200000, big.NewInt(1), initcode))
+			}
+		case 1:
+			nonce := block.TxNonce(testBank)
+			txs = append(txs,
+				types.NewTransaction(nonce, common.Address{0x01}, big.NewInt(1000), 21000, big.NewInt(1), nil),
+				types.NewTransaction(nonce+1, reverterAddr, new(big.Int), 100000, big.NewInt(1), nil),
+				types.NewTransaction(nonce+2, caller, new(big.Int), 100000, big.NewInt(1), nil),
+			)
+		}
+		for _, tx := range txs {
+			tx, _ = types.SignTx(tx, signer, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	tests := []struct {
+		internal  bool
+		positions []float64 // Transaction positions of the retained traces
+	}{
+		{internal: false, positions: []float64{1}},
+		{internal: true, positions: []float64{1, 2, 2}},
+	}
+	for i, tt := range tests {
+		traces, err := api.Block(context.Background(), rpc.BlockNumber(2), &TraceConfig{FailedTransactionsOnly: true, IncludeInternalFailures: tt.internal})
+		if err != nil {
+			t.Fatalf("test %d: failed to trace block: %v", i, err)
+		}
+		blob, _ := json.Marshal(traces)
+		var decoded []map[string]interface{}
+		if err := json.Unmarshal(blob, &decoded); err != nil {
+			t.Fatalf("test %d: failed to decode traces: %v", i, err)
+		}
+		var positions []float64
+		for _, trace := range decoded {
+			position, _ := trace["transactionPosition"].(float64)
+			positions = append(positions, position)
+		}
+		if !reflect.DeepEqual(positions, tt.positions) {
+			t.Errorf("test %d: transaction positions mismatch: have %v, want %v", i, positions, tt.positions)
+		}
+		if decoded[0]["error"] != "Reverted" {
+			t.Errorf("test %d: failed transaction error mismatch: have %v, want Reverted", i, decoded[0]["error"])
+		}
+		// The successful root call of a transaction with an internal failure is kept
+		if tt.internal {
+			if _, ok := decoded[1]["error"]; ok || decoded[2]["error"] != "Reverted" {
+				t.Errorf("test %d: internal failure mismatch: root error %v, subcall error %v", i, decoded[1]["error"], decoded[2]["error"])
+			}
+		}
+	}
+	// Internal failures are only meaningful along with the failed transactions
+	if _, err := api.Block(context.Background(), rpc.BlockNumber(2), &TraceConfig{IncludeInternalFailures: true}); err == nil {
+		t.Errorf("expected error for includeInternalFailures alone")
+	}
+}
+
+// Tests that historical transactions are traced in the context of their own
+// block, a zero gas price accepted back then not failing their retrace.
+func TestTraceHistoricalGasPrice(t *testing.T) {
+	signer := types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+	eth := newTestTraceBackend(t, 2, func(i int, block *core.BlockGen) {
+		price := big.NewInt(int64(i)) // Free in block #1, priced in block #2
+		tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), common.Address{0x01}, big.NewInt(1000), vars.TxGas, price, nil), signer, testBankKey)
+		block.AddTx(tx)
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	tx := eth.blockchain.GetBlockByNumber(1).Transactions()[0]
+	if tx.GasPrice().Sign() != 0 {
+		t.Fatalf("unexpected gas price: %v", tx.GasPrice())
+	}
+	trace, err := api.Transaction(context.Background(), tx.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	blob, _ := json.Marshal(trace)
+	var decoded []struct {
+		Error  string `json:"error"`
+		Action struct {
+			Value hexutil.Big `json:"value"`
+		} `json:"action"`
+	}
+	if err := json.Unmarshal(blob, &decoded); err != nil {
+		t.Fatalf("failed to decode traces: %v", err)
+	}
+	if len(decoded) != 1 || decoded[0].Error != "" || decoded[0].Action.Value.ToInt().Int64() != 1000 {
+		t.Errorf("transaction trace mismatch: %s", blob)
+	}
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(1), &TraceConfig{IncludeStatus: true})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if status := traces[0].(map[string]interface{})["status"]; status != hexutil.Uint64(types.ReceiptStatusSuccessful) {
+		t.Errorf("transaction status mismatch: have %v, want 0x1", status)
+	}
+}
+
+// Tests that the input and output data of the Parity traces can be capped, the
+// truncated traces being marked with the original lengths of their data.
+func TestTraceDataTruncation(t *testing.T) {
+	var (
+		signer  = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		data    = bytes.Repeat([]byte{0xab}, 100)
+		runtime = bytes.Repeat([]byte{0x00}, 40) // STOPs only
+	)
+	initcode := append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+	eth := newTestTraceBackend(t, 1, func(i int, block *core.BlockGen) {
+		// Call the identity precompile, echoing its input, and create a contract
+		call, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), common.BytesToAddress([]byte{0x04}), new(big.Int), 100000, big.NewInt(1), data), signer, testBankKey)
+		block.AddTx(call)
+		create, _ := types.SignTx(types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 200000, big.NewInt(1), initcode), signer, testBankKey)
+		block.AddTx(create)
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	trace := func(config *TraceConfig) []map[string]map[string]interface{} {
+		traces, err := api.Block(context.Background(), rpc.BlockNumber(1), config)
+		if err != nil {
+			t.Fatalf("failed to trace block: %v", err)
+		}
+		blob, _ := json.Marshal(traces[:2])
+		var decoded []map[string]interface{}
+		if err := json.Unmarshal(blob, &decoded); err != nil {
+			t.Fatalf("failed to decode traces: %v", err)
+		}
+		var objects []map[string]map[string]interface{}
+		for _, trace := range decoded {
+			action, _ := trace["action"].(map[string]interface{})
+			result, _ := trace["result"].(map[string]interface{})
+			objects = append(objects, map[string]map[string]interface{}{
+				"trace":  trace,
+				"action": action,
+				"result": result,
+			})
+		}
+		return objects
+	}
+	truncated := trace(&TraceConfig{MaxDataBytes: 10, IncludeInputHash: true})
+	tests := []struct {
+		object, field string
+		full          []byte
+	}{
+		{"action", "input", data},
+		{"result", "output", data},
+		{"action", "init", initcode},
+		{"result", "code", runtime},
+	}
+	for i, tt := range tests {
+		object := truncated[i/2][tt.object]
+		if have, want := object[tt.field], hexutil.Encode(tt.full[:10]); have != want {
+			t.Errorf("%s.%s mismatch: have %v, want %s", tt.object, tt.field, have, want)
+		}
+		if have, want := object[tt.field+"Length"], hexutil.EncodeUint64(uint64(len(tt.full))); have != want {
+			t.Errorf("%s.%sLength mismatch: have %v, want %s", tt.object, tt.field, have, want)
+		}
+	}
+	for i, trace := range truncated {
+		if trace["trace"]["truncated"] != true {
+			t.Errorf("trace %d: truncation not marked", i)
+		}
+	}
+	// The input hash covers the whole input
+	if have, want := truncated[0]["action"]["inputHash"], crypto.Keccak256Hash(data).Hex(); have != want {
+		t.Errorf("input hash mismatch: have %v, want %s", have, want)
+	}
+	// Data within the limit is left untouched and unmarked
+	for i, trace := range trace(&TraceConfig{MaxDataBytes: 100}) {
+		if _, ok := trace["trace"]["truncated"]; ok {
+			t.Errorf("trace %d: marked as truncated within the limit", i)
+		}
+		if i == 0 && trace["action"]["input"] != hexutil.Encode(data) {
+			t.Errorf("input mismatch within the limit: have %v", trace["action"]["input"])
+		}
+	}
+}
+
+// Tests that the transactions of a block whose sender can't be recovered by the
+// chain's signer (e.g. of bad blocks) are reported as clearly marked errored
+// traces without a sender, the rest of the block being traced as usual.
+func TestTraceBlockInvalidSender(t *testing.T) {
+	eth := newTestTraceBackend(t, 1, nil)
+	api := NewPrivateTraceAPI(eth)
+
+	var (
+		signer  = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		foreign = types.NewEIP155Signer(big.NewInt(999)) // Not recoverable by the chain's signer
+		to      = common.Address{0x01}
+	)
+	sign := func(nonce uint64, signer types.Signer) *types.Transaction {
+		tx, _ := types.SignTx(types.NewTransaction(nonce, to, big.NewInt(1000), vars.TxGas, big.NewInt(1), nil), signer, testBankKey)
+		return tx
+	}
+	// The foreign transaction never executes, so the next one reuses its nonce
+	txs := []*types.Transaction{sign(0, signer), sign(1, foreign), sign(1, signer)}
+	block := types.NewBlock(eth.blockchain.GetBlockByNumber(1).Header(), txs, nil, nil, new(trie.Trie))
+
+	results, err := traceBlock(context.Background(), eth, block, setTraceConfigDefaultTracer(nil))
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	for i, result := range results {
+		if failed := result.Error != ""; failed != (i == 1) {
+			t.Errorf("tx %d: error mismatch: have %q", i, result.Error)
+		}
+	}
+	if !strings.Contains(results[1].Error, "invalid transaction sender") {
+		t.Errorf("invalid sender error mismatch: have %q", results[1].Error)
+	}
+	traces, err := api.blockTraces(context.Background(), block, setTraceConfigDefaultTracer(nil))
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	blob, _ := json.Marshal(traces)
+	var decoded []struct {
+		Type   string `json:"type"`
+		Error  string `json:"error"`
+		Action struct {
+			From *common.Address `json:"from"`
+		} `json:"action"`
+		TransactionHash *common.Hash `json:"transactionHash"`
+	}
+	if err := json.Unmarshal(blob, &decoded); err != nil {
+		t.Fatalf("failed to decode traces: %v", err)
+	}
+	if len(decoded) != 4 {
+		t.Fatalf("trace count mismatch: have %d, want 4", len(decoded))
+	}
+	for i, trace := range decoded[:3] {
+		if trace.TransactionHash == nil || *trace.TransactionHash != txs[i].Hash() {
+			t.Errorf("trace %d: transaction hash mismatch: have %x, want %x", i, trace.TransactionHash, txs[i].Hash())
+		}
+		switch {
+		case i == 1 && (trace.Error == "" || trace.Action.From != nil):
+			t.Errorf("trace %d: invalid sender not marked: error %q, sender %v", i, trace.Error, trace.Action.From)
+		case i != 1 && (trace.Error != "" || trace.Action.From == nil || *trace.Action.From != testBank):
+			t.Errorf("trace %d: valid transaction mismatch: error %q, sender %v", i, trace.Error, trace.Action.From)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// errTraceServiceBusy is returned when a trace could not be scheduled on the
+// shared trace workers within the configured queue timeout.
+var errTraceServiceBusy = errors.New("trace service busy")
+
+// tracePool bounds the number of trace executions running concurrently across
+// all trace requests served by the node. Traces exceeding the limit queue up
+// until a worker frees up or the queue timeout elapses.
+type tracePool struct {
+	slots   chan struct{}
+	timeout time.Duration
+}
+
+// newTracePool creates a trace pool allowing up to workers concurrent trace
+// executions. A non-positive worker count disables the limit.
+func newTracePool(workers int, timeout time.Duration) *tracePool {
+	if workers <= 0 {
+		return nil
+	}
+	return &tracePool{
+		slots:   make(chan struct{}, workers),
+		timeout: timeout,
+	}
+}
+
+// acquire blocks until a worker is available, the queue timeout elapses or the
+// context is cancelled. A nil pool never blocks.
+func (p *tracePool) acquire(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+	// Fast path, don't bother with timers if there's a free worker
+	select {
+	case p.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	var expired <-chan time.Time
+	if p.timeout > 0 {
+		timer := time.NewTimer(p.timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case p.slots <- struct{}{}:
+		return nil
+	case <-expired:
+		return errTraceServiceBusy
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release returns a worker previously obtained via acquire to the pool.
+func (p *tracePool) release() {
+	if p == nil {
+		return
+	}
+	<-p.slots
+}
@@ -0,0 +1,62 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Tests that the trace pool queues requests while saturated and fails them
+// with a busy error once the queue timeout elapses.
+func TestTracePoolSaturation(t *testing.T) {
+	pool := newTracePool(1, 50*time.Millisecond)
+
+	if err := pool.acquire(context.Background()); err != nil {
+		t.Fatalf("failed to acquire free worker: %v", err)
+	}
+	if err := pool.acquire(context.Background()); err != errTraceServiceBusy {
+		t.Fatalf("saturated pool error mismatch: have %v, want %v", err, errTraceServiceBusy)
+	}
+	// Queued requests should be served once a worker is released
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		pool.release()
+	}()
+	if err := pool.acquire(context.Background()); err != nil {
+		t.Fatalf("failed to acquire released worker: %v", err)
+	}
+	// Cancelled requests should abort waiting
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := pool.acquire(ctx); err != context.Canceled {
+		t.Fatalf("cancelled acquire error mismatch: have %v, want %v", err, context.Canceled)
+	}
+	pool.release()
+}
+
+// Tests that a disabled trace pool never blocks.
+func TestTracePoolUnlimited(t *testing.T) {
+	pool := newTracePool(0, time.Millisecond)
+	for i := 0; i < 16; i++ {
+		if err := pool.acquire(context.Background()); err != nil {
+			t.Fatalf("acquire %d failed: %v", i, err)
+		}
+	}
+	pool.release()
+}
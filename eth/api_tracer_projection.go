@@ -0,0 +1,119 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// parityTraceFields lists the fields of a Parity formatted trace which may be
+// projected, along with the nested fields of object valued ones.
+var parityTraceFields = map[string][]string{
+	"action":              {"callType", "from", "to", "value", "gas", "input", "init", "creationMethod", "address", "refundAddress", "balance", "author", "rewardType", "uncleNumber", "uncleDepth", "selector", "inputHash", "inputLength", "initLength"},
+	"result":              {"gasUsed", "output", "code", "address", "gasRemaining", "gasRetained", "outputLength", "codeLength"},
+	"error":               nil,
+	"type":                nil,
+	"subtraces":           nil,
+	"traceAddress":        nil,
+	"transactionHash":     nil,
+	"transactionPosition": nil,
+	"blockHash":           nil,
+	"blockNumber":         nil,
+	"time":                nil,
+	"fork":                nil,
+	"chainId":             nil,
+	"status":              nil,
+	"effectiveGasPrice":   nil,
+	"pending":             nil,
+	"logs":                nil,
+	"truncated":           nil,
+	"pruned":              nil,
+}
+
+// validateTraceFields checks that all the requested projection fields, either
+// top level or dot separated nested ones, exist in Parity formatted traces.
+func validateTraceFields(fields []string) error {
+	for _, field := range fields {
+		parts := strings.Split(field, ".")
+		nested, ok := parityTraceFields[parts[0]]
+		if !ok || len(parts) > 2 {
+			return fmt.Errorf("unknown trace field %q", field)
+		}
+		if len(parts) == 2 {
+			known := false
+			for _, name := range nested {
+				if name == parts[1] {
+					known = true
+					break
+				}
+			}
+			if !known {
+				return fmt.Errorf("unknown trace field %q", field)
+			}
+		}
+	}
+	return nil
+}
+
+// projectTrace returns a copy of the trace retaining only the requested fields.
+func projectTrace(trace map[string]interface{}, fields []string) map[string]interface{} {
+	projected := make(map[string]interface{})
+	for _, field := range fields {
+		parts := strings.SplitN(field, ".", 2)
+		value, ok := trace[parts[0]]
+		if !ok {
+			continue
+		}
+		if len(parts) == 1 {
+			projected[parts[0]] = value
+			continue
+		}
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if value, ok := object[parts[1]]; ok {
+			if _, ok := projected[parts[0]].(map[string]interface{}); !ok {
+				projected[parts[0]] = make(map[string]interface{})
+			}
+			projected[parts[0]].(map[string]interface{})[parts[1]] = value
+		}
+	}
+	return projected
+}
+
+// projectTraces restricts each of the given Parity formatted traces to the
+// requested fields. Typed traces are converted to their generic JSON form.
+func projectTraces(traces []interface{}, fields []string) ([]interface{}, error) {
+	results := make([]interface{}, len(traces))
+	for i, trace := range traces {
+		object, ok := trace.(map[string]interface{})
+		if !ok {
+			blob, err := json.Marshal(trace)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(blob, &object); err != nil {
+				return nil, err
+			}
+		}
+		results[i] = projectTrace(object, fields)
+	}
+	return results, nil
+}
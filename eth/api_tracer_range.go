@@ -0,0 +1,130 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// TraceRangeBlock holds the Parity traces of a block streamed by a block range
+// subscription, as trace_block would return them. A block failing to trace ends
+// the stream, carrying the error instead.
+type TraceRangeBlock struct {
+	TraceBlockRef
+	Traces []interface{} `json:"traces"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// BlockRange sends a notification with the complete Parity traces of each block
+// of the given inclusive range, rewards included, in order. Unlike the filter
+// subscription, the traces are grouped per block rather than flattened. The
+// subscription remains active after the last block until unsubscribed.
+func (api *PrivateTraceAPI) BlockRange(ctx context.Context, fromBlock, toBlock rpc.BlockNumber, config *TraceConfig) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	config = setTraceConfigDefaultTracer(config)
+	if err := validateParityTraceConfig(config); err != nil {
+		return nil, err
+	}
+	if config.CancelToken != "" {
+		return nil, errCancelTokenUnsupported
+	}
+	config = setTraceConfigRedaction(config, &api.eth.config.Trace)
+
+	start, err := api.rangeBlockNumber(fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	end, err := api.rangeBlockNumber(toBlock)
+	if err != nil {
+		return nil, err
+	}
+	if end < start {
+		return nil, fmt.Errorf("end block (#%d) precedes start block (#%d)", end, start)
+	}
+	if err := api.eth.traceSubs.acquire(notifier); err != nil {
+		return nil, err
+	}
+	rpcSub := notifier.CreateSubscription()
+	api.eth.traceSubs.track(notifier, rpcSub)
+
+	// Abort tracing once the client unsubscribes or goes away
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-rpcSub.Err():
+		case <-notifier.Closed():
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+	go func() {
+		defer cancel()
+
+		for number := start; number <= end; number++ {
+			// Trace the very block notified, which a reorg could replace meanwhile
+			result := &TraceRangeBlock{
+				TraceBlockRef: TraceBlockRef{Number: hexutil.Uint64(number)},
+			}
+			var (
+				traces []interface{}
+				err    error
+			)
+			if block := blockByNumber(api.eth, rpc.BlockNumber(number)); block == nil {
+				err = missingBlockError(api.eth, rpc.BlockNumber(number))
+			} else {
+				result.Hash = block.Hash()
+				traces, err = api.blockTraces(ctx, block, config)
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			result.Traces = traces
+			if err != nil {
+				log.Warn("Block range tracing failed", "block", number, "err", err)
+				result.Error = err.Error()
+				notifier.Notify(rpcSub.ID, result)
+				return
+			}
+			notifier.Notify(rpcSub.ID, result)
+		}
+	}()
+	return rpcSub, nil
+}
+
+// rangeBlockNumber resolves a bound of a block range to a block number, failing
+// if the block doesn't exist yet.
+func (api *PrivateTraceAPI) rangeBlockNumber(number rpc.BlockNumber) (uint64, error) {
+	switch number {
+	case rpc.PendingBlockNumber:
+		return 0, errors.New("block ranges can't include the pending block")
+	case rpc.LatestBlockNumber:
+		return api.eth.blockchain.CurrentBlock().NumberU64(), nil
+	}
+	if api.eth.blockchain.GetHeaderByNumber(uint64(number)) == nil {
+		return 0, missingBlockError(api.eth, number)
+	}
+	return uint64(number), nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Tests that the block range subscription streams the complete traces of each
+// block of the range, grouped per block and in order.
+func TestTraceBlockRange(t *testing.T) {
+	eth := newTestTraceBackend(t, 4, testTransferBlocks(2))
+	api := NewPrivateTraceAPI(eth)
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("trace", api); err != nil {
+		t.Fatalf("failed to register trace API: %v", err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	notifications := make(chan *TraceRangeBlock)
+	sub, err := client.Subscribe(context.Background(), "trace", notifications, "blockRange", "0x2", "latest", nil)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	for number := uint64(2); number <= 4; number++ {
+		select {
+		case have := <-notifications:
+			if uint64(have.Number) != number || have.Hash != eth.blockchain.GetCanonicalHash(number) || have.Error != "" {
+				t.Fatalf("notification mismatch: have #%d %x (error %q), want #%d", have.Number, have.Hash, have.Error, number)
+			}
+			want, err := api.Block(context.Background(), rpc.BlockNumber(number), nil)
+			if err != nil {
+				t.Fatalf("failed to trace block #%d: %v", number, err)
+			}
+			// Two transfers and the block reward
+			if len(have.Traces) != 3 || len(have.Traces) != len(want) {
+				t.Errorf("block #%d trace count mismatch: have %d, want %d", number, len(have.Traces), len(want))
+			}
+			// The traces are the ones of the block notified
+			for i, trace := range have.Traces {
+				if hash, _ := trace.(map[string]interface{})["blockHash"].(string); hash != have.Hash.Hex() {
+					t.Errorf("block #%d trace %d: block hash mismatch: have %s, want %x", number, i, hash, have.Hash)
+				}
+			}
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for block #%d", number)
+		}
+	}
+	select {
+	case have := <-notifications:
+		t.Fatalf("unexpected notification past the range: #%d", have.Number)
+	case <-time.After(100 * time.Millisecond):
+	}
+	// Inverted ranges and missing blocks are rejected
+	for _, bounds := range [][2]string{{"0x3", "0x2"}, {"0x1", "0x5"}, {"0x1", "pending"}} {
+		if _, err := client.Subscribe(context.Background(), "trace", make(chan *TraceRangeBlock), "blockRange", bounds[0], bounds[1], nil); err == nil {
+			t.Errorf("expected error for range %s-%s", bounds[0], bounds[1])
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// redactValue returns the keccak256 hash replacing a redacted trace value. Hex
+// encoded values are hashed over their decoded bytes, others over their JSON.
+func redactValue(value interface{}) interface{} {
+	if s, ok := value.(string); ok {
+		if data, err := hexutil.Decode(s); err == nil {
+			return crypto.Keccak256Hash(data)
+		}
+	}
+	blob, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return crypto.Keccak256Hash(blob)
+}
+
+// redactTrace strips (or hashes if requested) the given top level or dot
+// separated nested fields of the trace in place.
+func redactTrace(trace map[string]interface{}, fields []string, hash bool) {
+	for _, field := range fields {
+		object, name := trace, field
+		if parts := strings.SplitN(field, ".", 2); len(parts) == 2 {
+			nested, ok := trace[parts[0]].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			object, name = nested, parts[1]
+		}
+		value, ok := object[name]
+		if !ok {
+			continue
+		}
+		if hash {
+			object[name] = redactValue(value)
+		} else {
+			delete(object, name)
+		}
+	}
+}
+
+// redactTraces applies the node-wide redaction policy onto each of the given
+// Parity formatted traces. Typed traces are converted to their generic JSON form.
+func redactTraces(traces []interface{}, policy *TraceAPIConfig) ([]interface{}, error) {
+	for i, trace := range traces {
+		object, ok := trace.(map[string]interface{})
+		if !ok {
+			blob, err := json.Marshal(trace)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(blob, &object); err != nil {
+				return nil, err
+			}
+			traces[i] = object
+		}
+		redactTrace(object, policy.Redact, policy.RedactHash)
+	}
+	return traces, nil
+}
+
+// redactTraceResult applies the node-wide redaction policy onto a raw trace
+// result of the Parity tracer. Results of other tracers are returned as is.
+func redactTraceResult(res interface{}, policy *TraceAPIConfig) (interface{}, error) {
+	raw, ok := res.(json.RawMessage)
+	if !ok {
+		return res, nil
+	}
+	var traces []interface{}
+	if err := json.Unmarshal(raw, &traces); err != nil {
+		return res, nil // Not a Parity trace list (e.g. a stateDiff)
+	}
+	return redactTraces(traces, policy)
+}
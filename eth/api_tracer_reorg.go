@@ -0,0 +1,163 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TraceComparedBlock holds the Parity traces of one side of a block comparison.
+type TraceComparedBlock struct {
+	TraceBlockRef
+	Traces []interface{} `json:"traces"`
+}
+
+// TraceBlockComparison is the result of trace_compareBlocks. Removed holds the
+// traces of the orphaned block missing from the canonical block at the same
+// height, Added those of the canonical block missing from the orphaned one. The
+// canonical side is nil if the canonical chain doesn't reach the height (any
+// more).
+type TraceBlockComparison struct {
+	Number  hexutil.Uint64      `json:"number"`
+	Removed *TraceComparedBlock `json:"removed"`
+	Added   *TraceComparedBlock `json:"added"`
+}
+
+// CompareBlocks traces the given orphaned block and the canonical block which
+// replaced it at the same height, returning the traces which differ between the
+// two. Traces are compared regardless of the block they're in, so a transaction
+// included in both blocks at the same position only shows up if its execution
+// changed.
+//
+// The orphaned block's body and the state of its parent are needed, which nodes
+// may have never stored (e.g. side chains not imported after a snap sync) or
+// already pruned.
+func (api *PrivateTraceAPI) CompareBlocks(ctx context.Context, hash common.Hash, config *TraceConfig) (*TraceBlockComparison, error) {
+	header := api.eth.blockchain.GetHeaderByHash(hash)
+	if header == nil {
+		return nil, fmt.Errorf("block %#x not found", hash)
+	}
+	number := header.Number.Uint64()
+	if api.eth.blockchain.GetCanonicalHash(number) == hash {
+		return nil, fmt.Errorf("block %#x is canonical, not orphaned", hash)
+	}
+	orphan := api.eth.blockchain.GetBlock(hash, number)
+	if orphan == nil {
+		return nil, fmt.Errorf("body of orphaned block %#x not available", hash)
+	}
+	config = setTraceConfigDefaultTracer(config)
+	if err := validateParityTraceConfig(config); err != nil {
+		return nil, err
+	}
+	if config.CancelToken != "" {
+		return nil, errCancelTokenUnsupported
+	}
+	config = setTraceConfigRedaction(config, &api.eth.config.Trace)
+
+	removed, err := api.blockTraces(ctx, orphan, config)
+	if err != nil {
+		if errors.Is(err, errHistoricalStateUnavailable) {
+			return nil, fmt.Errorf("state of orphaned block %#x not available, it may have been pruned: %w", hash, err)
+		}
+		return nil, fmt.Errorf("failed to trace orphaned block %#x: %w", hash, err)
+	}
+	result := &TraceBlockComparison{
+		Number: hexutil.Uint64(number),
+		Removed: &TraceComparedBlock{
+			TraceBlockRef: TraceBlockRef{Number: hexutil.Uint64(number), Hash: hash},
+		},
+	}
+	var added []interface{}
+	if canonical := api.eth.blockchain.GetBlockByNumber(number); canonical != nil {
+		if added, err = api.blockTraces(ctx, canonical, config); err != nil {
+			return nil, fmt.Errorf("failed to trace canonical block %#x: %w", canonical.Hash(), err)
+		}
+		result.Added = &TraceComparedBlock{
+			TraceBlockRef: TraceBlockRef{Number: hexutil.Uint64(number), Hash: canonical.Hash()},
+		}
+	}
+	if removed, added, err = diffBlockTraces(removed, added); err != nil {
+		return nil, err
+	}
+	result.Removed.Traces = removed
+	if result.Added != nil {
+		result.Added.Traces = added
+	}
+	return result, nil
+}
+
+// diffBlockTraces drops the traces present in both lists, matching each trace at
+// most once, and returns the remaining ones of either in their original order.
+func diffBlockTraces(old, new []interface{}) ([]interface{}, []interface{}, error) {
+	oldKeys, err := blockTraceKeys(old)
+	if err != nil {
+		return nil, nil, err
+	}
+	newKeys, err := blockTraceKeys(new)
+	if err != nil {
+		return nil, nil, err
+	}
+	unmatched := func(traces []interface{}, keys []string, others []string) []interface{} {
+		counts := make(map[string]int)
+		for _, key := range others {
+			counts[key]++
+		}
+		results := []interface{}{}
+		for i, key := range keys {
+			if counts[key] > 0 {
+				counts[key]--
+				continue
+			}
+			results = append(results, traces[i])
+		}
+		return results
+	}
+	return unmatched(old, oldKeys, newKeys), unmatched(new, newKeys, oldKeys), nil
+}
+
+// blockTraceKeys returns the canonical JSON encoding of each trace, leaving out
+// the fields identifying the block it's in and the execution time, which varies
+// from run to run.
+func blockTraceKeys(traces []interface{}) ([]string, error) {
+	keys := make([]string, len(traces))
+	for i, trace := range traces {
+		blob, err := json.Marshal(trace)
+		if err != nil {
+			return nil, err
+		}
+		var fields interface{}
+		if err := json.Unmarshal(blob, &fields); err != nil {
+			return nil, err
+		}
+		if fields, ok := fields.(map[string]interface{}); ok {
+			delete(fields, "blockHash")
+			delete(fields, "blockNumber")
+			delete(fields, "time")
+		}
+		if blob, err = json.Marshal(fields); err != nil {
+			return nil, err
+		}
+		keys[i] = string(blob)
+	}
+	return keys, nil
+}
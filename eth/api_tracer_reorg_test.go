@@ -0,0 +1,118 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+// Tests that trace_compareBlocks returns the traces differing between a block
+// orphaned by a reorg and the canonical block replacing it.
+func TestTraceCompareBlocks(t *testing.T) {
+	eth := newTestTraceBackend(t, 2, testTransferBlocks(1))
+	api := NewPrivateTraceAPI(eth)
+	orphan := eth.blockchain.GetBlockByNumber(1)
+
+	// Reorg the blocks out with a longer side chain
+	side := generateTestTraceChain(3, testSideTransferBlocks)
+	if _, err := eth.blockchain.InsertChain(side); err != nil {
+		t.Fatalf("failed to insert side chain: %v", err)
+	}
+	result, err := api.CompareBlocks(context.Background(), orphan.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to compare blocks: %v", err)
+	}
+	if result.Number != 1 || result.Removed.Hash != orphan.Hash() || result.Added.Hash != side[0].Hash() {
+		t.Fatalf("compared blocks mismatch: have #%d %x and %x", result.Number, result.Removed.Hash, result.Added.Hash)
+	}
+	// The transfers differ, but the block rewards are the same
+	if len(result.Removed.Traces) != 1 || len(result.Added.Traces) != 1 {
+		t.Fatalf("differing traces mismatch: have %d removed and %d added, want 1 and 1", len(result.Removed.Traces), len(result.Added.Traces))
+	}
+	if have := result.Removed.Traces[0].(map[string]interface{})["transactionHash"]; have != orphan.Transactions()[0].Hash().Hex() {
+		t.Errorf("removed trace mismatch: have transaction %v, want %x", have, orphan.Transactions()[0].Hash())
+	}
+	if have := result.Added.Traces[0].(map[string]interface{})["transactionHash"]; have != side[0].Transactions()[0].Hash().Hex() {
+		t.Errorf("added trace mismatch: have transaction %v, want %x", have, side[0].Transactions()[0].Hash())
+	}
+	// Canonical and unknown blocks can't be compared
+	if _, err := api.CompareBlocks(context.Background(), side[0].Hash(), nil); err == nil {
+		t.Errorf("expected error for canonical block")
+	}
+	if _, err := api.CompareBlocks(context.Background(), common.Hash{0xff}, nil); err == nil {
+		t.Errorf("expected error for unknown block")
+	}
+}
+
+// Tests that trace_compareBlocks leaves out the traces of the transactions the
+// orphaned and canonical blocks share.
+func TestTraceCompareBlocksSharedTransaction(t *testing.T) {
+	eth := newTestTraceBackend(t, 2, testTransferBlocks(1))
+	api := NewPrivateTraceAPI(eth)
+	orphan := eth.blockchain.GetBlockByNumber(1)
+
+	// Reorg the blocks out with a side chain including the same transfers, each
+	// followed by another one
+	side := generateTestTraceChain(3, func(i int, block *core.BlockGen) {
+		testTransferBlocks(1)(i, block)
+		testSideTransferBlocks(i, block)
+	})
+	if _, err := eth.blockchain.InsertChain(side); err != nil {
+		t.Fatalf("failed to insert side chain: %v", err)
+	}
+	if side[0].Transactions()[0].Hash() != orphan.Transactions()[0].Hash() {
+		t.Fatalf("shared transaction mismatch: have %x, want %x", side[0].Transactions()[0].Hash(), orphan.Transactions()[0].Hash())
+	}
+	result, err := api.CompareBlocks(context.Background(), orphan.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to compare blocks: %v", err)
+	}
+	if len(result.Removed.Traces) != 0 || len(result.Added.Traces) != 1 {
+		t.Fatalf("differing traces mismatch: have %d removed and %d added, want 0 and 1", len(result.Removed.Traces), len(result.Added.Traces))
+	}
+	if have := result.Added.Traces[0].(map[string]interface{})["transactionHash"]; have != side[0].Transactions()[1].Hash().Hex() {
+		t.Errorf("added trace mismatch: have transaction %v, want %x", have, side[0].Transactions()[1].Hash())
+	}
+}
+
+// Tests that trace_compareBlocks reports orphaned blocks of which the parent
+// state is not available.
+func TestTraceCompareBlocksMissingState(t *testing.T) {
+	eth := newTestTraceBackend(t, 2, testTransferBlocks(1))
+	api := NewPrivateTraceAPI(eth)
+
+	// Store side blocks without processing them, leaving their states missing
+	side := generateTestTraceChain(2, testSideTransferBlocks)
+	for _, block := range side {
+		rawdb.WriteBlock(eth.chainDb, block)
+	}
+	reexec := uint64(0)
+	_, err := api.CompareBlocks(context.Background(), side[1].Hash(), &TraceConfig{Reexec: &reexec})
+	if !errors.Is(err, errHistoricalStateUnavailable) {
+		t.Fatalf("error mismatch: have %v, want %v", err, errHistoricalStateUnavailable)
+	}
+	// Regenerating the state from the common ancestor works
+	if _, err := api.CompareBlocks(context.Background(), side[1].Hash(), nil); err != nil {
+		t.Fatalf("failed to compare blocks: %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// traceFormatNested is the default output format, returning the Parity
+	// traces with their nested action and result objects.
+	traceFormatNested = ""
+
+	// traceFormatRows returns each Parity trace as a single flat row, suitable
+	// for loading into relational databases.
+	traceFormatRows = "rows"
+)
+
+// ParityTraceRow is the flattened representation of a Parity formatted trace.
+type ParityTraceRow struct {
+	BlockNumber         interface{} `json:"block"`
+	TransactionHash     interface{} `json:"txHash"`
+	TransactionPosition interface{} `json:"txPos"`
+	TraceAddress        string      `json:"traceAddress"`
+	Type                string      `json:"type"`
+	From                string      `json:"from"`
+	To                  string      `json:"to"`
+	Value               string      `json:"value"`
+	Gas                 string      `json:"gas"`
+	GasUsed             string      `json:"gasUsed"`
+	Error               string      `json:"error"`
+	Status              string      `json:"status,omitempty"`
+}
+
+// validateTraceFormat checks that the requested output format is supported.
+func validateTraceFormat(format string) error {
+	switch format {
+	case traceFormatNested, traceFormatRows:
+		return nil
+	default:
+		return fmt.Errorf("unknown trace format %q", format)
+	}
+}
+
+// flattenTrace converts a Parity formatted trace into a single flat row.
+func flattenTrace(trace map[string]interface{}) *ParityTraceRow {
+	str := func(object map[string]interface{}, field string) string {
+		s, _ := object[field].(string)
+		return s
+	}
+	action, _ := trace["action"].(map[string]interface{})
+	result, _ := trace["result"].(map[string]interface{})
+
+	row := &ParityTraceRow{
+		BlockNumber:         trace["blockNumber"],
+		TransactionHash:     trace["transactionHash"],
+		TransactionPosition: trace["transactionPosition"],
+		Type:                str(trace, "type"),
+		From:                str(action, "from"),
+		To:                  str(action, "to"),
+		Value:               str(action, "value"),
+		Gas:                 str(action, "gas"),
+		GasUsed:             str(result, "gasUsed"),
+		Error:               str(trace, "error"),
+	}
+	if status, ok := trace["status"].(hexutil.Uint64); ok {
+		row.Status = status.String()
+	} else {
+		row.Status = str(trace, "status")
+	}
+	if address, ok := trace["traceAddress"].([]interface{}); ok {
+		path := make([]string, len(address))
+		for i, index := range address {
+			path[i] = fmt.Sprint(index)
+		}
+		row.TraceAddress = strings.Join(path, ".")
+	}
+	switch row.Type {
+	case "create":
+		row.To = str(result, "address")
+	case "suicide":
+		row.From, row.To, row.Value = str(action, "address"), str(action, "refundAddress"), str(action, "balance")
+	case "reward":
+		row.To = str(action, "author")
+	}
+	return row
+}
+
+// flattenTraces converts each of the given Parity formatted traces into a flat
+// row. Typed traces are converted through their generic JSON form.
+func flattenTraces(traces []interface{}) ([]interface{}, error) {
+	rows := make([]interface{}, len(traces))
+	for i, trace := range traces {
+		object, ok := trace.(map[string]interface{})
+		if !ok {
+			blob, err := json.Marshal(trace)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(blob, &object); err != nil {
+				return nil, err
+			}
+		}
+		rows[i] = flattenTrace(object)
+	}
+	return rows, nil
+}
@@ -0,0 +1,226 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// defaultTraceSinceBlocks is the number of blocks traced by a single
+	// trace_since call if not requested otherwise.
+	defaultTraceSinceBlocks = 16
+
+	// maxTraceSinceBlocks is the maximum number of blocks a single trace_since
+	// call traces, callers need to resume from the last returned block.
+	maxTraceSinceBlocks = 128
+)
+
+// TraceBlockRef identifies a block by its number and hash.
+type TraceBlockRef struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+}
+
+// TraceSinceArgs represents the arguments of trace_since: the last block the
+// caller processed and the maximum number of new blocks to trace.
+type TraceSinceArgs struct {
+	Number hexutil.Uint64  `json:"number"`          // Last processed block number
+	Hash   *common.Hash    `json:"hash,omitempty"`  // Last processed block hash, enables reorg detection
+	Count  *hexutil.Uint64 `json:"count,omitempty"` // Maximum number of blocks to trace
+}
+
+// TraceSinceBlock holds the Parity traces of a newly traced canonical block. In
+// trace subscriptions, it may also mark a previously notified block as removed
+// by a reorg, in which case its traces are not repeated.
+type TraceSinceBlock struct {
+	TraceBlockRef
+	ParentHash common.Hash   `json:"parentHash"`
+	Traces     []interface{} `json:"traces"`
+	Removed    bool          `json:"removed,omitempty"`
+}
+
+// TraceSinceResult is the result of trace_since. Removed lists the previously
+// processed blocks which were rolled back by a reorg, newest first, which the
+// caller should discard before ingesting the new blocks in order.
+type TraceSinceResult struct {
+	Removed []TraceBlockRef    `json:"removed"`
+	Blocks  []*TraceSinceBlock `json:"blocks"`
+}
+
+// Since traces the canonical blocks following the last block processed by the
+// caller, up to the current head, allowing trace ingestion to be resumed. If
+// the hash of the last processed block is given and it was reorged out of the
+// canonical chain, the rolled back blocks are reported and tracing resumes from
+// the common ancestor.
+func (api *PrivateTraceAPI) Since(ctx context.Context, args TraceSinceArgs, config *TraceConfig) (*TraceSinceResult, error) {
+	count := uint64(defaultTraceSinceBlocks)
+	if args.Count != nil {
+		count = uint64(*args.Count)
+	}
+	if count == 0 || count > maxTraceSinceBlocks {
+		return nil, fmt.Errorf("block count must be between 1 and %d", maxTraceSinceBlocks)
+	}
+	if config != nil && config.CancelToken != "" {
+		return nil, errCancelTokenUnsupported
+	}
+	result, _, err := api.traceSince(ctx, uint64(args.Number), args.Hash, count, config)
+	return result, err
+}
+
+// traceSince traces up to count canonical blocks following the given last
+// processed block, rolling back the blocks reorged out if its hash is given. The
+// block tracing resumed from is returned along with the result.
+func (api *PrivateTraceAPI) traceSince(ctx context.Context, last uint64, hash *common.Hash, count uint64, config *TraceConfig) (*TraceSinceResult, TraceBlockRef, error) {
+	result := &TraceSinceResult{
+		Removed: []TraceBlockRef{},
+		Blocks:  []*TraceSinceBlock{},
+	}
+	// Roll back the processed blocks not on the canonical chain anymore
+	base := TraceBlockRef{Number: hexutil.Uint64(last), Hash: api.eth.blockchain.GetCanonicalHash(last)}
+	if hash != nil {
+		header := api.eth.blockchain.GetHeaderByHash(*hash)
+		if header == nil {
+			return nil, base, fmt.Errorf("block %#x not found", *hash)
+		}
+		if header.Number.Uint64() != last {
+			return nil, base, fmt.Errorf("block %#x is #%d, not #%d", *hash, header.Number.Uint64(), last)
+		}
+		for header.Hash() != api.eth.blockchain.GetCanonicalHash(header.Number.Uint64()) {
+			result.Removed = append(result.Removed, TraceBlockRef{Number: hexutil.Uint64(header.Number.Uint64()), Hash: header.Hash()})
+			if header = api.eth.blockchain.GetHeader(header.ParentHash, header.Number.Uint64()-1); header == nil {
+				return nil, base, fmt.Errorf("ancestor of block %#x not found", *hash)
+			}
+		}
+		last = header.Number.Uint64()
+		base = TraceBlockRef{Number: hexutil.Uint64(last), Hash: header.Hash()}
+	}
+	// Trace the new canonical blocks in order
+	head := api.eth.blockchain.CurrentBlock().NumberU64()
+	for number := last + 1; number <= head && uint64(len(result.Blocks)) < count; number++ {
+		block := api.eth.blockchain.GetBlockByNumber(number)
+		if block == nil {
+			break
+		}
+		traces, err := api.blockTracesByNumber(ctx, rpc.BlockNumber(number), config)
+		if err != nil {
+			return nil, base, err
+		}
+		// Stop if the block was reorged out while tracing, the next call will
+		// report it as removed if it was returned
+		if api.eth.blockchain.GetCanonicalHash(number) != block.Hash() {
+			break
+		}
+		result.Blocks = append(result.Blocks, &TraceSinceBlock{
+			TraceBlockRef: TraceBlockRef{Number: hexutil.Uint64(number), Hash: block.Hash()},
+			ParentHash:    block.ParentHash(),
+			Traces:        traces,
+		})
+	}
+	return result, base, nil
+}
+
+// NewBlockTraces sends a notification with the Parity traces of each new block
+// imported into the canonical chain. Mirroring the logs subscription, blocks of
+// which the traces were notified and which are reorged out of the canonical
+// chain are notified again as removed, before the blocks replacing them.
+func (api *PrivateTraceAPI) NewBlockTraces(ctx context.Context, config *TraceConfig) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	if err := validateParityTraceConfig(setTraceConfigDefaultTracer(config)); err != nil {
+		return nil, err
+	}
+	if config != nil && config.CancelToken != "" {
+		return nil, errCancelTokenUnsupported
+	}
+	if err := api.eth.traceSubs.acquire(notifier); err != nil {
+		return nil, err
+	}
+	rpcSub := notifier.CreateSubscription()
+	api.eth.traceSubs.track(notifier, rpcSub)
+
+	// Start tracing from the current head, only blocks imported afterwards are notified
+	head := api.eth.blockchain.CurrentBlock()
+	last := TraceBlockRef{Number: hexutil.Uint64(head.NumberU64()), Hash: head.Hash()}
+
+	heads := make(chan core.ChainHeadEvent, 16)
+	headsSub := api.eth.blockchain.SubscribeChainHeadEvent(heads)
+
+	// Drain the head events without ever blocking the chain import, merely
+	// flagging that there are new blocks to trace
+	ctx, cancel := context.WithCancel(context.Background())
+	wake := make(chan struct{}, 1)
+	go func() {
+		defer cancel()
+		defer headsSub.Unsubscribe()
+		for {
+			select {
+			case <-heads:
+				select {
+				case wake <- struct{}{}:
+				default:
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	go func() {
+		for {
+			select {
+			case <-wake:
+				// Notify the rolled back and new blocks until caught up with the head
+				for {
+					result, base, err := api.traceSince(ctx, uint64(last.Number), &last.Hash, maxTraceSinceBlocks, config)
+					if err != nil {
+						log.Warn("Failed to trace new blocks", "last", last.Number, "err", err)
+						break
+					}
+					for _, removed := range result.Removed {
+						block := &TraceSinceBlock{TraceBlockRef: removed, Removed: true}
+						if header := api.eth.blockchain.GetHeaderByHash(removed.Hash); header != nil {
+							block.ParentHash = header.ParentHash
+						}
+						notifier.Notify(rpcSub.ID, block)
+					}
+					last = base
+					for _, block := range result.Blocks {
+						notifier.Notify(rpcSub.ID, block)
+						last = block.TraceBlockRef
+					}
+					if len(result.Blocks) < maxTraceSinceBlocks {
+						break
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
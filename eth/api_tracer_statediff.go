@@ -0,0 +1,268 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+// TraceStateDiffRangeArgs represents the arguments of trace_stateDiffRange: an
+// inclusive block range, optionally pinned to the chain of its end block.
+type TraceStateDiffRangeArgs struct {
+	FromBlock hexutil.Uint64 `json:"fromBlock"`        // First block of the range
+	ToBlock   hexutil.Uint64 `json:"toBlock"`          // Last block of the range
+	ToHash    *common.Hash   `json:"toHash,omitempty"` // Hash of the last block, pins the range to its chain
+}
+
+// TraceStateDiffAccount is the net change of an account over a block range, in
+// the format of the stateDiffTracer: every field is either "=" if unchanged, or
+// {"+": to} if born, {"-": from} if died and {"*": {"from", "to"}} if changed.
+type TraceStateDiffAccount struct {
+	Balance interface{}                 `json:"balance"`
+	Nonce   interface{}                 `json:"nonce"`
+	Code    interface{}                 `json:"code"`
+	Storage map[common.Hash]interface{} `json:"storage"`
+}
+
+// TraceStateDiffRangeResult is the result of trace_stateDiffRange, holding the
+// net state changes between the parent state of the first block and the state
+// after the last block, along with the (hash pinned) blocks it covers.
+type TraceStateDiffRangeResult struct {
+	From      TraceBlockRef                             `json:"from"`
+	To        TraceBlockRef                             `json:"to"`
+	StateDiff map[common.Address]*TraceStateDiffAccount `json:"stateDiff"`
+}
+
+// StateDiffRange runs the stateDiffTracer over all the transactions of a block
+// range and aggregates their diffs into the net changes of the range, block
+// and uncle rewards included. Accounts and storage slots changed back and forth
+// within the range are netted out.
+//
+// The blocks are retrieved by walking back from the last one, so the diff always
+// covers a single chain. Unless pinned by hash, the range must still be canonical
+// once the export finishes, otherwise it fails and should be retried.
+func (api *PrivateTraceAPI) StateDiffRange(ctx context.Context, args TraceStateDiffRangeArgs, config *TraceConfig) (*TraceStateDiffRangeResult, error) {
+	start, end := uint64(args.FromBlock), uint64(args.ToBlock)
+	if end < start {
+		return nil, fmt.Errorf("end block (#%d) precedes start block (#%d)", end, start)
+	}
+	if end-start >= maxTraceFilterBlocks {
+		return nil, fmt.Errorf("block range of %d blocks exceeds the limit of %d", end-start+1, maxTraceFilterBlocks)
+	}
+	// Pin the blocks of the range to the chain of its last block
+	var last *types.Block
+	if args.ToHash != nil {
+		if last = api.eth.blockchain.GetBlockByHash(*args.ToHash); last == nil {
+			return nil, fmt.Errorf("end block %#x not found", *args.ToHash)
+		}
+		if last.NumberU64() != end {
+			return nil, fmt.Errorf("end block %#x is #%d, not #%d", *args.ToHash, last.NumberU64(), end)
+		}
+	} else if last = api.eth.blockchain.GetBlockByNumber(end); last == nil {
+		return nil, fmt.Errorf("end block #%d not found", end)
+	}
+	blocks := make([]*types.Block, end-start+1)
+	for i := len(blocks) - 1; ; i-- {
+		blocks[i] = last
+		if i == 0 {
+			break
+		}
+		if last = api.eth.blockchain.GetBlock(last.ParentHash(), last.NumberU64()-1); last == nil {
+			return nil, fmt.Errorf("block #%d not found", start+uint64(i)-1)
+		}
+	}
+	result := &TraceStateDiffRangeResult{
+		From:      TraceBlockRef{Number: hexutil.Uint64(start), Hash: blocks[0].Hash()},
+		To:        TraceBlockRef{Number: hexutil.Uint64(end), Hash: blocks[len(blocks)-1].Hash()},
+		StateDiff: make(map[common.Address]*TraceStateDiffAccount),
+	}
+	// The genesis block has no transactions to diff, start from its state instead
+	parent := blocks[0]
+	if start > 0 {
+		if parent = api.eth.blockchain.GetBlock(parent.ParentHash(), start-1); parent == nil {
+			return nil, fmt.Errorf("parent block #%d not found", start-1)
+		}
+	} else {
+		blocks = blocks[1:]
+	}
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	statedb, err := computeStateDB(api.eth, parent, reexec)
+	if err != nil {
+		return nil, err
+	}
+	initial := statedb.Copy()
+
+	touched, err := api.traceStateDiffRange(ctx, blocks, statedb, config)
+	if err != nil {
+		return nil, err
+	}
+	if args.ToHash == nil && api.eth.blockchain.GetCanonicalHash(end) != result.To.Hash {
+		return nil, fmt.Errorf("block #%d was reorged during the export", end)
+	}
+	// Net the changes out by comparing the touched accounts and slots between the
+	// states before and after the range. Nothing was committed, so the initial
+	// state is still readable.
+	for addr, slots := range touched {
+		if diff := netStateDiffAccount(initial, statedb, addr, slots); diff != nil {
+			result.StateDiff[addr] = diff
+		}
+	}
+	return result, nil
+}
+
+// traceStateDiffRange applies the given blocks on top of statedb, running the
+// stateDiffTracer over their transactions. It returns the accounts and storage
+// slots touched by the range, including the beneficiaries of block rewards.
+func (api *PrivateTraceAPI) traceStateDiffRange(ctx context.Context, blocks []*types.Block, statedb *state.StateDB, config *TraceConfig) (map[common.Address]map[common.Hash]struct{}, error) {
+	var (
+		chainConfig = api.eth.blockchain.Config()
+		tracer      = "stateDiffTracer"
+		diffConfig  = &TraceConfig{Tracer: &tracer}
+		touched     = make(map[common.Address]map[common.Hash]struct{})
+	)
+	if config != nil {
+		diffConfig.Timeout = config.Timeout
+	}
+	touch := func(addr common.Address) map[common.Hash]struct{} {
+		if touched[addr] == nil {
+			touched[addr] = make(map[common.Hash]struct{})
+		}
+		return touched[addr]
+	}
+	for _, block := range blocks {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// Mutate the state according to any hard-fork specs, like block processing
+		if chainConfig.IsEnabled(chainConfig.GetEthashEIP779Transition, block.Number()) {
+			if dao := chainConfig.GetEthashEIP779Transition(); dao != nil && *dao == block.NumberU64() {
+				misc.ApplyDAOHardFork(statedb)
+				touch(vars.DAORefundContract)
+				for _, addr := range vars.DAODrainList() {
+					touch(addr)
+				}
+			}
+		}
+		signer := types.MakeSigner(chainConfig, block.Number())
+		for i, tx := range block.Transactions() {
+			msg, _ := tx.AsMessage(signer)
+			vmctx := core.NewEVMContext(msg, block.Header(), api.eth.blockchain, nil)
+
+			// The stateDiffTracer needs to know whether the sender can pay for the
+			// transaction, or it reports no changes at all
+			var (
+				gasCost   = new(big.Int).Mul(new(big.Int).SetUint64(msg.Gas()), msg.GasPrice())
+				totalCost = new(big.Int).Add(gasCost, msg.Value())
+				extra     = map[string]interface{}{
+					"hasFromSufficientBalanceForValueAndGasCost": vmctx.CanTransfer(statedb, msg.From(), totalCost),
+					"hasFromSufficientBalanceForGasCost":         vmctx.CanTransfer(statedb, msg.From(), gasCost),
+				}
+			)
+			statedb.Prepare(tx.Hash(), block.Hash(), i)
+			res, err := traceTx(ctx, api.eth, msg, vmctx, statedb, extra, diffConfig)
+			if err != nil {
+				return nil, fmt.Errorf("tracing transaction %#x failed: %v", tx.Hash(), err)
+			}
+			statedb.Finalise(chainConfig.IsEnabled(chainConfig.GetEIP161dTransition, block.Number()))
+
+			var diff map[common.Address]struct {
+				Storage map[common.Hash]json.RawMessage `json:"storage"`
+			}
+			if err := json.Unmarshal(res.(json.RawMessage), &diff); err != nil {
+				return nil, fmt.Errorf("failed to decode state diff of transaction %#x: %v", tx.Hash(), err)
+			}
+			for addr, account := range diff {
+				slots := touch(addr)
+				for slot := range account.Storage {
+					slots[slot] = struct{}{}
+				}
+			}
+		}
+		// Apply the block and uncle rewards, which aren't part of any transaction
+		touch(block.Coinbase())
+		for _, uncle := range block.Uncles() {
+			touch(uncle.Coinbase)
+		}
+		api.eth.engine.Finalize(api.eth.blockchain, block.Header(), statedb, block.Transactions(), block.Uncles())
+		if err := statedb.Error(); err != nil {
+			return nil, fmt.Errorf("processing block %d failed: %w", block.NumberU64(), err)
+		}
+	}
+	return touched, nil
+}
+
+// netStateDiffAccount returns the net change of an account and the given storage
+// slots between two states, or nil if there is none.
+func netStateDiffAccount(from, to *state.StateDB, addr common.Address, slots map[common.Hash]struct{}) *TraceStateDiffAccount {
+	existed, exists := from.Exist(addr), to.Exist(addr)
+	if !existed && !exists {
+		return nil
+	}
+	diff := &TraceStateDiffAccount{
+		Balance: netStateDiffValue(existed, exists, hexutil.EncodeBig(from.GetBalance(addr)), hexutil.EncodeBig(to.GetBalance(addr))),
+		Nonce:   netStateDiffValue(existed, exists, hexutil.EncodeUint64(from.GetNonce(addr)), hexutil.EncodeUint64(to.GetNonce(addr))),
+		Code:    netStateDiffValue(existed, exists, hexutil.Encode(from.GetCode(addr)), hexutil.Encode(to.GetCode(addr))),
+		Storage: make(map[common.Hash]interface{}),
+	}
+	for slot := range slots {
+		var before, after common.Hash
+		if existed {
+			before = from.GetState(addr, slot)
+		}
+		if exists {
+			after = to.GetState(addr, slot)
+		}
+		// Storage of born and died accounts only lists their non-empty slots
+		if before == after || (!existed && after == (common.Hash{})) || (!exists && before == (common.Hash{})) {
+			continue
+		}
+		diff.Storage[slot] = netStateDiffValue(existed, exists, before.Hex(), after.Hex())
+	}
+	if diff.Balance == "=" && diff.Nonce == "=" && diff.Code == "=" && len(diff.Storage) == 0 {
+		return nil
+	}
+	return diff
+}
+
+// netStateDiffValue formats the net change of an account field or storage slot
+// with the markers of the stateDiffTracer.
+func netStateDiffValue(existed, exists bool, from, to string) interface{} {
+	switch {
+	case !existed:
+		return map[string]string{"+": to}
+	case !exists:
+		return map[string]string{"-": from}
+	case from == to:
+		return "="
+	default:
+		return map[string]map[string]string{"*": {"from": from, "to": to}}
+	}
+}
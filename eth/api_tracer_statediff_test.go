@@ -0,0 +1,240 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/vars"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Tests that trace_stateDiffRange nets the state changes of a block range out,
+// including the block rewards and the storage slots reverted within the range.
+func TestTraceStateDiffRange(t *testing.T) {
+	var (
+		signer   = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+		coinbase = common.Address{0xc0}
+		payee    = common.Address{0xbb}
+
+		// A contract storing the second word of its calldata in the slot of the first
+		runtime  = []byte{0x60, 0x20, 0x35, 0x60, 0x00, 0x35, 0x55, 0x00}
+		initcode = append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+		contract = crypto.CreateAddress(testBank, 0)
+	)
+	store := func(block *core.BlockGen, slot, value byte) {
+		input := append(common.LeftPadBytes([]byte{slot}, 32), common.LeftPadBytes([]byte{value}, 32)...)
+		tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), contract, new(big.Int), 100000, big.NewInt(1), input), signer, testBankKey)
+		block.AddTx(tx)
+	}
+	eth := newTestTraceBackend(t, 3, func(i int, block *core.BlockGen) {
+		block.SetCoinbase(coinbase)
+		switch i {
+		case 0:
+			tx, _ := types.SignTx(types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 100000, big.NewInt(1), initcode), signer, testBankKey)
+			block.AddTx(tx)
+		case 1:
+			store(block, 1, 7)
+			store(block, 2, 9)
+		case 2:
+			store(block, 1, 0)
+			tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), payee, big.NewInt(500), vars.TxGas, big.NewInt(1), nil), signer, testBankKey)
+			block.AddTx(tx)
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	before, _ := eth.blockchain.StateAt(eth.blockchain.GetBlockByNumber(1).Root())
+	after, _ := eth.blockchain.StateAt(eth.blockchain.GetBlockByNumber(3).Root())
+	changed := func(from, to string) interface{} {
+		return map[string]interface{}{"*": map[string]interface{}{"from": from, "to": to}}
+	}
+	slot := func(n byte) string {
+		return common.BytesToHash([]byte{n}).Hex()
+	}
+	want := map[string]interface{}{
+		"from": map[string]interface{}{"number": "0x2", "hash": eth.blockchain.GetBlockByNumber(2).Hash().Hex()},
+		"to":   map[string]interface{}{"number": "0x3", "hash": eth.blockchain.GetBlockByNumber(3).Hash().Hex()},
+		"stateDiff": map[string]interface{}{
+			// The sender paid for four transactions and a transfer
+			hexutil.Encode(testBank[:]): map[string]interface{}{
+				"balance": changed(hexutil.EncodeBig(before.GetBalance(testBank)), hexutil.EncodeBig(after.GetBalance(testBank))),
+				"nonce":   changed("0x1", "0x5"),
+				"code":    "=",
+				"storage": map[string]interface{}{},
+			},
+			// The miner collected the fees and two block rewards
+			hexutil.Encode(coinbase[:]): map[string]interface{}{
+				"balance": changed(hexutil.EncodeBig(before.GetBalance(coinbase)), hexutil.EncodeBig(after.GetBalance(coinbase))),
+				"nonce":   "=",
+				"code":    "=",
+				"storage": map[string]interface{}{},
+			},
+			// Slot 1 was set and cleared within the range, netting out
+			hexutil.Encode(contract[:]): map[string]interface{}{
+				"balance": "=",
+				"nonce":   "=",
+				"code":    "=",
+				"storage": map[string]interface{}{
+					slot(2): changed(slot(0), slot(9)),
+				},
+			},
+			hexutil.Encode(payee[:]): map[string]interface{}{
+				"balance": map[string]interface{}{"+": "0x1f4"},
+				"nonce":   map[string]interface{}{"+": "0x0"},
+				"code":    map[string]interface{}{"+": "0x"},
+				"storage": map[string]interface{}{},
+			},
+		},
+	}
+	res, err := api.StateDiffRange(context.Background(), TraceStateDiffRangeArgs{FromBlock: 2, ToBlock: 3}, nil)
+	if err != nil {
+		t.Fatalf("failed to export state diff: %v", err)
+	}
+	blob, _ := json.Marshal(res)
+	var have map[string]interface{}
+	if err := json.Unmarshal(blob, &have); err != nil {
+		t.Fatalf("failed to decode state diff: %v", err)
+	}
+	if !reflect.DeepEqual(have, want) {
+		wantBlob, _ := json.Marshal(want)
+		t.Errorf("state diff mismatch:\nhave %s\nwant %s", blob, wantBlob)
+	}
+	// Over the whole chain, the contract is born with its non-empty slots only
+	res, err = api.StateDiffRange(context.Background(), TraceStateDiffRangeArgs{FromBlock: 0, ToBlock: 3}, nil)
+	if err != nil {
+		t.Fatalf("failed to export state diff: %v", err)
+	}
+	born := res.StateDiff[contract]
+	if born == nil {
+		t.Fatalf("contract missing from state diff")
+	}
+	if code := born.Code.(map[string]string)["+"]; code != hexutil.Encode(runtime) {
+		t.Errorf("contract code mismatch: have %s, want %x", code, runtime)
+	}
+	if len(born.Storage) != 1 || !reflect.DeepEqual(born.Storage[common.BytesToHash([]byte{2})], map[string]string{"+": slot(9)}) {
+		t.Errorf("contract storage mismatch: have %v", born.Storage)
+	}
+	// Ranges pinned to a hash not matching their end block are rejected
+	hash := eth.blockchain.GetBlockByNumber(2).Hash()
+	if _, err := api.StateDiffRange(context.Background(), TraceStateDiffRangeArgs{FromBlock: 2, ToBlock: 3, ToHash: &hash}, nil); err == nil {
+		t.Errorf("expected mismatching end block hash to fail")
+	}
+}
+
+// Tests that the stateDiffTracer reports the net code change of a metamorphic
+// contract, self-destructed and recreated by CREATE2 at the same address with
+// different code. The recreation attempted in the destroying transaction itself
+// collides, as the destroyed contract only goes away at the end of it, and must
+// not hide its destruction. The next transaction recreates it.
+func TestTraceStateDiffMetamorphic(t *testing.T) {
+	var (
+		signer = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+
+		// Init code of a child whose code holds the value it was endowed with, and
+		// self-destructs into its caller when called
+		child = []byte{
+			0x64, 0x60, 0x00, 0x50, 0x33, 0xff, 0x60, 0x00, 0x52, // MSTORE(0, PUSH1 0 POP CALLER SELFDESTRUCT)
+			0x34, 0x60, 0x1c, 0x53, // MSTORE8(28, CALLVALUE)
+			0x60, 0x05, 0x60, 0x1b, 0xf3, // RETURN(27, 5)
+		}
+		// A factory destroying its current child, if any, and recreating it with
+		// its call value, storing the address of the child in slot 0
+		runtime = append([]byte{
+			0x60, 0x00, 0x54, // SLOAD(0)
+			0x80, 0x3b, 0x15, 0x60, 0x13, 0x57, // JUMPI(19, ISZERO(EXTCODESIZE(child)))
+			0x60, 0x00, 0x80, 0x80, 0x80, 0x80, 0x85, 0x5a, 0xf1, 0x50, // POP(CALL(GAS, child, 0, 0, 0, 0, 0))
+			0x5b, 0x50, // JUMPDEST, POP
+			0x60, byte(len(child)), 0x60, 0x28, 0x60, 0x00, 0x39, // CODECOPY(0, 40, len)
+			0x60, 0x00, 0x60, byte(len(child)), 0x60, 0x00, 0x34, 0xf5, // CREATE2(CALLVALUE, 0, len, 0)
+			0x60, 0x00, 0x55, 0x00, // SSTORE(0, child), STOP
+		}, child...)
+		initcode = append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+		factory  = crypto.CreateAddress(testBank, 0)
+		created  = crypto.CreateAddress2(factory, common.Hash{}, crypto.Keccak256(child))
+	)
+	recreate := func(block *core.BlockGen, value int64) {
+		tx, _ := types.SignTx(types.NewTransaction(block.TxNonce(testBank), factory, big.NewInt(value), 1000000, big.NewInt(1), nil), signer, testBankKey)
+		block.AddTx(tx)
+	}
+	eth := newTestTraceBackend(t, 3, func(i int, block *core.BlockGen) {
+		switch i {
+		case 0:
+			tx, _ := types.SignTx(types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 200000, big.NewInt(1), initcode), signer, testBankKey)
+			block.AddTx(tx)
+		case 1:
+			recreate(block, 1)
+		case 2:
+			recreate(block, 2) // Destroys the child, failing to recreate it
+			recreate(block, 2) // Recreates the child
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	var (
+		oldCode = "0x60015033ff"
+		newCode = "0x60025033ff"
+	)
+	account := func(marker, balance, nonce, code string) map[string]interface{} {
+		return map[string]interface{}{
+			"balance": map[string]interface{}{marker: balance},
+			"nonce":   map[string]interface{}{marker: nonce},
+			"code":    map[string]interface{}{marker: code},
+			"storage": map[string]interface{}{},
+		}
+	}
+	res, err := api.ReplayBlockTransactions(context.Background(), rpc.BlockNumber(3), []string{"stateDiff"})
+	if err != nil {
+		t.Fatalf("failed to replay block: %v", err)
+	}
+	for i, want := range []map[string]interface{}{
+		account("-", "0x1", "0x1", oldCode),
+		account("+", "0x2", "0x1", newCode),
+	} {
+		blob, _ := json.Marshal(res[i].StateDiff)
+		var diff map[string]interface{}
+		if err := json.Unmarshal(blob, &diff); err != nil {
+			t.Fatalf("transaction %d: failed to decode state diff: %v", i, err)
+		}
+		if have := diff[hexutil.Encode(created[:])]; !reflect.DeepEqual(have, want) {
+			t.Errorf("transaction %d: child diff mismatch:\nhave %v\nwant %v", i, have, want)
+		}
+	}
+	// Over the block, the child's code is replaced
+	rng, err := api.StateDiffRange(context.Background(), TraceStateDiffRangeArgs{FromBlock: 3, ToBlock: 3}, nil)
+	if err != nil {
+		t.Fatalf("failed to export state diff: %v", err)
+	}
+	diff := rng.StateDiff[created]
+	if diff == nil {
+		t.Fatalf("child missing from state diff")
+	}
+	want := map[string]map[string]string{"*": {"from": oldCode, "to": newCode}}
+	if !reflect.DeepEqual(diff.Code, want) || diff.Nonce != "=" {
+		t.Errorf("child diff mismatch: have code %v, nonce %v", diff.Code, diff.Nonce)
+	}
+}
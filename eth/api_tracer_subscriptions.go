@@ -0,0 +1,88 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// traceSubLimiter bounds the number of trace subscriptions active at once on
+// any single RPC connection, so that a client can't exhaust the node by opening
+// subscriptions without bounds.
+type traceSubLimiter struct {
+	limit  int
+	active map[interface{}]int
+	lock   sync.Mutex
+}
+
+// newTraceSubLimiter creates a limiter allowing up to limit active trace
+// subscriptions per connection. A non-positive limit disables it.
+func newTraceSubLimiter(limit int) *traceSubLimiter {
+	if limit <= 0 {
+		return nil
+	}
+	return &traceSubLimiter{
+		limit:  limit,
+		active: make(map[interface{}]int),
+	}
+}
+
+// acquire reserves a subscription slot on the connection of the notifier, or
+// fails if all of them are taken. A nil limiter never fails.
+func (l *traceSubLimiter) acquire(notifier *rpc.Notifier) error {
+	if l == nil {
+		return nil
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	conn := notifier.Conn()
+	if l.active[conn] >= l.limit {
+		return fmt.Errorf("too many trace subscriptions on this connection (limit %d)", l.limit)
+	}
+	l.active[conn]++
+	return nil
+}
+
+// release frees a subscription slot previously reserved via acquire.
+func (l *traceSubLimiter) release(notifier *rpc.Notifier) {
+	if l == nil {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	conn := notifier.Conn()
+	if l.active[conn]--; l.active[conn] <= 0 {
+		delete(l.active, conn)
+	}
+}
+
+// track holds a subscription slot reserved via acquire until the subscription
+// ends, i.e. the client unsubscribes or its connection is closed.
+func (l *traceSubLimiter) track(notifier *rpc.Notifier, sub *rpc.Subscription) {
+	if l == nil {
+		return
+	}
+	go func() {
+		<-sub.Err()
+		l.release(notifier)
+	}()
+}
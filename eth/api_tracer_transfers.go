@@ -0,0 +1,178 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// movesValue reports whether the Parity formatted trace moves ether: a call or
+// creation endowed with value, a suicide sweeping a balance or a reward.
+func movesValue(trace map[string]interface{}) bool {
+	value := transferredValue(trace)
+	return value != nil && value.Sign() > 0
+}
+
+// transferredValue returns the amount of wei the Parity formatted trace moves,
+// or nil if it doesn't move any. The value of a delegatecall is the one of its
+// caller, which it doesn't move again.
+func transferredValue(trace map[string]interface{}) *big.Int {
+	action, _ := trace["action"].(map[string]interface{})
+	field := "value"
+	switch trace["type"] {
+	case "suicide":
+		field = "balance"
+	case "call":
+		if callType := action["callType"]; callType == "delegatecall" || callType == "staticcall" {
+			return nil
+		}
+	}
+	value, ok := action[field].(string)
+	if !ok {
+		return nil
+	}
+	return parseTraceQuantity(value)
+}
+
+// parseTraceQuantity decodes a quantity of a Parity formatted trace, either hex
+// encoded or, with decimalValues, decimal. Nil is returned for anything else,
+// e.g. redacted fields.
+func parseTraceQuantity(value string) *big.Int {
+	if strings.HasPrefix(value, "0x") {
+		amount, err := hexutil.DecodeBig(value)
+		if err != nil {
+			return nil
+		}
+		return amount
+	}
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil
+	}
+	return amount
+}
+
+// filterValueTransfers retains the Parity formatted traces moving ether. The
+// remaining traces of a transaction which lost some of its traces are flagged
+// as pruned, their traceAddress and subtraces still referring to the original
+// call tree. Typed traces are converted to their generic JSON form.
+func filterValueTransfers(traces []interface{}) ([]interface{}, error) {
+	var (
+		objects = make([]map[string]interface{}, len(traces))
+		pruned  = make(map[string]bool)
+	)
+	txKey := func(trace map[string]interface{}) string {
+		return fmt.Sprintf("%v:%v", trace["transactionHash"], trace["transactionPosition"])
+	}
+	for i, trace := range traces {
+		object, ok := trace.(map[string]interface{})
+		if !ok {
+			blob, err := json.Marshal(trace)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(blob, &object); err != nil {
+				return nil, err
+			}
+		}
+		objects[i] = object
+		if !movesValue(object) {
+			pruned[txKey(object)] = true
+		}
+	}
+	results := traces[:0]
+	for _, object := range objects {
+		if !movesValue(object) {
+			continue
+		}
+		if object["type"] != "reward" && pruned[txKey(object)] {
+			object["pruned"] = true
+		}
+		results = append(results, object)
+	}
+	return results, nil
+}
+
+// valueSortedTraces buffers the Parity formatted traces of a trace_filter range,
+// keeping them ordered by the value they move, largest first. Traces moving no
+// value follow, ties keeping their chain order.
+type valueSortedTraces struct {
+	entries   []*valueSortedTrace
+	limit     int // Maximum number of traces retained, 0 = unlimited
+	size      int // Encoded size in bytes of the retained traces
+	sizeLimit int // Maximum size in bytes of the retained traces, 0 = unlimited
+}
+
+// valueSortedTrace is a buffered trace along with its sorting key.
+type valueSortedTrace struct {
+	trace interface{}
+	value *big.Int
+	size  int
+}
+
+// add buffers the given traces, dropping those not among the largest limit ones
+// any more. It fails if the retained traces exceed the size limit.
+func (s *valueSortedTraces) add(traces []interface{}) error {
+	for _, trace := range traces {
+		blob, err := json.Marshal(trace)
+		if err != nil {
+			return err
+		}
+		var object map[string]interface{}
+		if err := json.Unmarshal(blob, &object); err != nil {
+			return err
+		}
+		// Rows carry the value at the top level, nested traces in their action
+		var value *big.Int
+		if _, ok := object["action"]; ok {
+			value = transferredValue(object)
+		} else if field, ok := object["value"].(string); ok {
+			value = parseTraceQuantity(field)
+		}
+		s.entries = append(s.entries, &valueSortedTrace{trace: trace, value: value, size: len(blob)})
+		s.size += len(blob)
+	}
+	sort.SliceStable(s.entries, func(i, j int) bool {
+		a, b := s.entries[i].value, s.entries[j].value
+		return a != nil && (b == nil || a.Cmp(b) > 0)
+	})
+	if s.limit > 0 && len(s.entries) > s.limit {
+		for _, entry := range s.entries[s.limit:] {
+			s.size -= entry.size
+		}
+		s.entries = s.entries[:s.limit]
+	}
+	if s.sizeLimit > 0 && s.size > s.sizeLimit {
+		return fmt.Errorf("traces sorted by value exceed the size limit of %d bytes, narrow the range or lower the count", s.sizeLimit)
+	}
+	return nil
+}
+
+// traces returns the retained traces, largest value first.
+func (s *valueSortedTraces) traces() []interface{} {
+	traces := make([]interface{}, len(s.entries))
+	for i, entry := range s.entries {
+		traces[i] = entry.trace
+	}
+	return traces
+}
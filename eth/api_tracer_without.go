@@ -0,0 +1,53 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// BlockWithout traces the given block as if the transaction at the given index
+// had not been included, executing the other ones in order and returning their
+// Parity traces the way trace_block does. The transactions following the left
+// out one may execute differently than they did in the chain, or not at all
+// (e.g. a later nonce of the same sender), in which case their root trace
+// carries the error.
+func (api *PrivateTraceAPI) BlockWithout(ctx context.Context, number rpc.BlockNumber, index uint64, config *TraceConfig) ([]interface{}, error) {
+	block := blockByNumber(api.eth, number)
+	if block == nil {
+		return nil, missingBlockError(api.eth, number)
+	}
+	config = setTraceConfigDefaultTracer(config)
+	if err := validateParityTraceConfig(config); err != nil {
+		return nil, err
+	}
+	if config.CancelToken != "" {
+		return nil, errCancelTokenUnsupported
+	}
+	// The block's summary is the one of its actual execution
+	if config.IncludeBlockSummary {
+		return nil, errors.New("includeBlockSummary is not supported by trace_blockWithout")
+	}
+	config = setTraceConfigRedaction(config, &api.eth.config.Trace)
+
+	excluded := *config
+	excluded.excluded = &index
+	return api.blockTraces(ctx, block, &excluded)
+}
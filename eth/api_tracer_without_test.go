@@ -0,0 +1,138 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Tests that trace_blockWithout traces a block leaving one of its transactions
+// out, the following ones executing on the state it didn't alter.
+func TestTraceBlockWithout(t *testing.T) {
+	var (
+		signer = types.NewEIP155Signer(params.TestChainConfig.GetChainID())
+
+		// A counter contract returning its incremented count
+		runtime = []byte{
+			0x60, 0x00, 0x54, 0x60, 0x01, 0x01, // SLOAD(0) + 1
+			0x80, 0x60, 0x00, 0x55, // SSTORE(0, count)
+			0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3, // RETURN(MSTORE(0, count))
+		}
+		counter = crypto.CreateAddress(testBank, 0)
+
+		otherKey, _ = crypto.GenerateKey()
+		other       = crypto.PubkeyToAddress(otherKey.PublicKey)
+	)
+	eth := newTestTraceBackend(t, 2, func(i int, block *core.BlockGen) {
+		add := func(key *ecdsa.PrivateKey, tx *types.Transaction) {
+			tx, _ = types.SignTx(tx, signer, key)
+			block.AddTx(tx)
+		}
+		switch i {
+		case 0:
+			initcode := append([]byte{0x60, byte(len(runtime)), 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, byte(len(runtime)), 0x60, 0x00, 0xf3}, runtime...)
+			add(testBankKey, types.NewContractCreation(block.TxNonce(testBank), new(big.Int), 200000, big.NewInt(1), initcode))
+			add(testBankKey, types.NewTransaction(block.TxNonce(testBank), other, big.NewInt(1000000000), 21000, big.NewInt(1), nil))
+		case 1:
+			add(testBankKey, types.NewTransaction(block.TxNonce(testBank), counter, new(big.Int), 100000, big.NewInt(1), nil))
+			add(otherKey, types.NewTransaction(block.TxNonce(other), counter, new(big.Int), 100000, big.NewInt(1), nil))
+			add(testBankKey, types.NewTransaction(block.TxNonce(testBank), counter, new(big.Int), 100000, big.NewInt(1), nil))
+		}
+	})
+	api := NewPrivateTraceAPI(eth)
+
+	// summarize returns the transaction positions of the transaction traces along
+	// with the counts they returned, or "error" for failed ones
+	summarize := func(traces []interface{}) ([]uint64, []string) {
+		blob, _ := json.Marshal(traces)
+		var decoded []struct {
+			Type   string `json:"type"`
+			Error  string `json:"error"`
+			Result *struct {
+				Output string `json:"output"`
+			} `json:"result"`
+			TransactionPosition uint64 `json:"transactionPosition"`
+		}
+		if err := json.Unmarshal(blob, &decoded); err != nil {
+			t.Fatalf("failed to decode traces: %v", err)
+		}
+		var (
+			positions []uint64
+			outcomes  []string
+		)
+		for _, trace := range decoded {
+			if trace.Type == "reward" {
+				continue
+			}
+			positions = append(positions, trace.TransactionPosition)
+			switch {
+			case trace.Error != "":
+				outcomes = append(outcomes, "error")
+			case trace.Result != nil:
+				outcomes = append(outcomes, common.HexToHash(trace.Result.Output).Big().String())
+			}
+		}
+		return positions, outcomes
+	}
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(2), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if positions, outcomes := summarize(traces); !reflect.DeepEqual(positions, []uint64{0, 1, 2}) || !reflect.DeepEqual(outcomes, []string{"1", "2", "3"}) {
+		t.Fatalf("block trace mismatch: positions %v, outcomes %v", positions, outcomes)
+	}
+	tests := []struct {
+		index     uint64
+		positions []uint64
+		outcomes  []string
+	}{
+		// Without the first call, the second sender counts first and the
+		// bank's next transaction has a nonce too high
+		{index: 0, positions: []uint64{1, 2}, outcomes: []string{"1", "error"}},
+		{index: 1, positions: []uint64{0, 2}, outcomes: []string{"1", "2"}},
+		{index: 2, positions: []uint64{0, 1}, outcomes: []string{"1", "2"}},
+	}
+	for _, tt := range tests {
+		traces, err := api.BlockWithout(context.Background(), rpc.BlockNumber(2), tt.index, nil)
+		if err != nil {
+			t.Fatalf("index %d: failed to trace block: %v", tt.index, err)
+		}
+		positions, outcomes := summarize(traces)
+		if !reflect.DeepEqual(positions, tt.positions) || !reflect.DeepEqual(outcomes, tt.outcomes) {
+			t.Errorf("index %d: trace mismatch: have positions %v, outcomes %v, want %v, %v", tt.index, positions, outcomes, tt.positions, tt.outcomes)
+		}
+	}
+	// Invalid exclusions are rejected
+	if _, err := api.BlockWithout(context.Background(), rpc.BlockNumber(2), 3, nil); err == nil {
+		t.Errorf("expected error for out of range index")
+	}
+	if _, err := api.BlockWithout(context.Background(), rpc.BlockNumber(2), 1, &TraceConfig{Transactions: []uint64{1}}); err == nil {
+		t.Errorf("expected error for selecting the excluded transaction")
+	}
+}
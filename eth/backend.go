@@ -90,6 +90,11 @@ type Ethereum struct {
 
 	p2pServer *p2p.Server
 
+	tracePool *tracePool       // Shared workers bounding concurrent trace executions
+	traceSubs *traceSubLimiter // Limit of the trace subscriptions active per connection
+	traceIdx  *traceIndexer    // Indexer of the trace addresses of imported blocks, nil if disabled
+	traceJobs *traceJobs       // In-flight traces cancellable via trace_cancel
+
 	lock sync.RWMutex // Protects the variadic fields (e.g. gas price and etherbase)
 }
 
@@ -111,6 +116,9 @@ func New(stack *node.Node, config *Config) (*Ethereum, error) {
 		log.Warn("Sanitizing invalid miner gas price", "provided", config.Miner.GasPrice, "updated", DefaultConfig.Miner.GasPrice)
 		config.Miner.GasPrice = new(big.Int).Set(DefaultConfig.Miner.GasPrice)
 	}
+	if err := validateTraceFields(config.Trace.Redact); err != nil {
+		return nil, fmt.Errorf("invalid trace redaction: %v", err)
+	}
 	if config.NoPruning && config.TrieDirtyCache > 0 {
 		if config.SnapshotCache > 0 {
 			config.TrieCleanCache += config.TrieDirtyCache * 3 / 5
@@ -150,6 +158,9 @@ func New(stack *node.Node, config *Config) (*Ethereum, error) {
 		bloomRequests:     make(chan chan *bloombits.Retrieval),
 		bloomIndexer:      NewBloomIndexer(chainDb, vars.BloomBitsBlocks, vars.BloomConfirms),
 		p2pServer:         stack.Server(),
+		tracePool:         newTracePool(config.Trace.Workers, config.Trace.QueueTimeout),
+		traceSubs:         newTraceSubLimiter(config.Trace.MaxSubscriptions),
+		traceJobs:         newTraceJobs(),
 	}
 
 	bcVersion := rawdb.ReadDatabaseVersion(chainDb)
@@ -354,6 +365,10 @@ func (s *Ethereum) APIs() []rpc.API {
 			Namespace: "trace",
 			Version:   "1.0",
 			Service:   NewPrivateTraceAPI(s),
+		}, {
+			Namespace: "trace",
+			Version:   "1.0",
+			Service:   NewPrivateTraceCompatAPI(s),
 		}, {
 			Namespace: "net",
 			Version:   "1.0",
@@ -560,6 +575,12 @@ func (s *Ethereum) Start() error {
 	}
 	// Start the networking layer and the light server if requested
 	s.protocolManager.Start(maxPeers)
+
+	// Start indexing the traces of the imported blocks if requested
+	if s.config.Trace.Index {
+		s.traceIdx = newTraceIndexer(s)
+		s.traceIdx.start()
+	}
 	return nil
 }
 
@@ -570,6 +591,9 @@ func (s *Ethereum) Stop() error {
 	s.protocolManager.Stop()
 
 	// Then stop everything else.
+	if s.traceIdx != nil {
+		s.traceIdx.stop()
+	}
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
 	s.txPool.Stop()
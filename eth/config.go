@@ -49,6 +49,23 @@ var DefaultLightGPOConfig = gasprice.Config{
 	MaxPrice:   gasprice.DefaultMaxPrice,
 }
 
+// TraceAPIConfig holds the node-wide settings of the trace API.
+type TraceAPIConfig struct {
+	Workers      int           // Maximum number of traces executing concurrently across all requests (0 = unlimited)
+	QueueTimeout time.Duration // Maximum time a trace waits for a free worker before failing (0 = wait indefinitely)
+	Redact       []string      // Parity trace fields (dot separated if nested) stripped from all trace outputs
+	RedactHash   bool          // Replaces the redacted fields with their keccak256 hash instead of stripping them
+
+	FilterSizeLimit int // Maximum size in bytes of the block traces streamed by a single trace_filter (0 = unlimited)
+	FilterBuffer    int // Number of blocks a trace_filter subscription traces ahead of its client (0 = number of CPUs)
+
+	MaxSubscriptions int // Maximum number of trace subscriptions active at once on a single RPC connection (0 = unlimited)
+	CallManyLimit    int // Maximum number of calls traced by a single trace_callMany request (0 = unlimited)
+
+	Index          bool   // Traces the imported blocks to index the addresses of their traces, speeding up address filtered trace_filter calls
+	IndexRetention uint64 // Number of recent blocks kept in the trace index, older ones being pruned (0 = unlimited)
+}
+
 // DefaultConfig contains default settings for use on the Ethereum main net.
 var DefaultConfig = Config{
 	SyncMode: downloader.FastSync,
@@ -81,6 +98,13 @@ var DefaultConfig = Config{
 	RPCGasCap:   25000000,
 	GPO:         DefaultFullGPOConfig,
 	RPCTxFeeCap: 1, // 1 ether
+	Trace: TraceAPIConfig{
+		Workers:      runtime.NumCPU(),
+		QueueTimeout: 30 * time.Second,
+
+		MaxSubscriptions: 16,
+		CallManyLimit:    1000,
+	},
 }
 
 func init() {
@@ -185,6 +209,9 @@ type Config struct {
 	// send-transction variants. The unit is ether.
 	RPCTxFeeCap float64 `toml:",omitempty"`
 
+	// Trace API options
+	Trace TraceAPIConfig
+
 	// Checkpoint is a hardcoded checkpoint which can be nil.
 	Checkpoint *ctypes.TrustedCheckpoint `toml:",omitempty"`
 
@@ -147,7 +147,7 @@ func call_tracerJs() (*asset, error) {
 	return a, nil
 }
 
-var _call_tracer_parityJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdc\x3b\xef\x6f\x1b\x37\xb2\x9f\xad\xbf\x62\xce\x1f\xae\x12\xa2\x48\x72\xd2\xcb\x03\xe4\x73\x0e\xae\xe3\xb4\xc6\xb9\x71\x60\x3b\x2d\x8a\xc0\x78\x47\xed\xce\x4a\x8c\x57\xe4\x1e\xc9\xb5\xac\xa6\xfe\xdf\x1f\x66\x48\xee\x0f\x69\xe5\xb8\xbd\xe0\xbd\xe2\xe5\x4b\xb4\x24\x67\x38\x33\x9c\xdf\xa4\xc7\x63\x38\xd1\xc5\xda\xc8\xf9\xc2\xc1\x8b\xc9\xc1\x7f\xc1\xf5\x02\x61\xae\x9f\xa3\x5b\xa0\xc1\x72\x09\xc7\xa5\x5b\x68\x63\x7b\xe3\x31\x5c\x2f\xa4\x85\x4c\xe6\x08\xd2\x42\x21\x8c\x03\x9d\x81\xdb\x58\x9f\xcb\x99\x11\x66\x3d\xea\x8d\xc7\x1e\xa6\x73\x9a\x30\x64\x06\x11\xac\xce\xdc\x4a\x18\x9c\xc2\x5a\x97\x90\x08\x05\x06\x53\x69\x9d\x91\xb3\xd2\x21\x48\x07\x42\xa5\x63\x6d\x60\xa9\x53\x99\xad\x09\xa5\x74\x50\xaa\x14\x0d\x6f\xed\xd0\x2c\x6d\xa4\xe3\xfb\x77\x1f\xe0\x1c\xad\x45\x03\xdf\xa3\x42\x23\x72\x78\x5f\xce\x72\x99\xc0\xb9\x4c\x50\x59\x04\x61\xa1\xa0\x11\xbb\xc0\x14\x66\x8c\x8e\x00\xdf\x12\x29\x57\x81\x14\x78\xab\x4b\x95\x0a\x27\xb5\x1a\x02\x4a\xa2\x1c\xee\xd0\x58\xa9\x15\xbc\x8c\x5b\x05\x84\x43\xd0\x86\x90\xf4\x85\x23\x06\x0c\xe8\x82\xe0\x06\x20\xd4\x1a\x72\xe1\x6a\xd0\x27\x08\xa4\xe6\x3b\x05\xa9\x78\x9b\x85\x2e\x10\xdc\x42\x38\xe2\x7a\x25\xf3\x1c\x66\x08\xa5\xc5\xac\xcc\x87\x84\x6d\x56\x3a\xf8\xf9\xec\xfa\x87\x8b\x0f\xd7\x70\xfc\xee\x17\xf8\xf9\xf8\xf2\xf2\xf8\xdd\xf5\x2f\x87\xb0\x92\x6e\xa1\x4b\x07\x78\x87\x1e\x95\x5c\x16\xb9\xc4\x14\x56\xc2\x18\xa1\xdc\x1a\x74\x46\x18\x7e\x3c\xbd\x3c\xf9\xe1\xf8\xdd\xf5\xf1\x77\x67\xe7\x67\xd7\xbf\x80\x36\xf0\xf6\xec\xfa\xdd\xe9\xd5\x15\xbc\xbd\xb8\x84\x63\x78\x7f\x7c\x79\x7d\x76\xf2\xe1\xfc\xf8\x12\xde\x7f\xb8\x7c\x7f\x71\x75\x3a\x82\x2b\x24\xaa\x90\xe0\xbf\x2c\xf3\x8c\x4f\xcf\x20\xa4\xe8\x84\xcc\x6d\x94\xc4\x2f\xba\x04\xbb\xd0\x65\x9e\xc2\x42\xdc\x21\x18\x4c\x50\xde\x61\x0a\x02\x12\x5d\xac\x9f\x7c\xa8\x84\x4b\xe4\x5a\xcd\x99\xe7\x9d\x0a\x09\x67\x19\x28\xed\x86\x60\x11\xe1\xef\x0b\xe7\x8a\xe9\x78\xbc\x5a\xad\x46\x73\x55\x8e\xb4\x99\x8f\x73\x8f\xce\x8e\x5f\x8f\x7a\x84\x33\x11\x79\x7e\x6d\x44\x82\x86\x0e\x47\x40\x56\x92\xf8\x73\xbd\x52\xe0\x8c\x50\x56\x24\x74\xd4\xf4\x3b\x61\x65\x14\x0e\xf0\x9e\xbe\x9c\x25\xa5\x05\x83\x85\x36\xf4\x3b\xcf\xa3\x9e\x49\xe5\xd0\x28\x91\x33\x6e\x0b\x4b\x91\x22\xcc\xd6\x20\x9a\x08\x87\x4d\x66\x48\x8d\xfc\x71\x83\x54\x99\x36\x4b\x56\xcb\x51\xef\x73\x6f\x2f\x50\x68\x9d\x48\x6e\x89\x40\xc2\x9f\x94\xc6\xa0\x72\x24\xca\xd2\x58\x79\x87\xbc\x04\xfc\x9a\x20\xcf\xd3\x9f\x7e\x04\xbc\xc7\xa4\xf4\x98\xf6\x2a\x24\x53\xf8\xf8\xf9\xe1\x66\xd8\x63\xd4\x73\x74\x27\x71\xe2\x1c\xd5\xdc\x2d\xa0\xef\x75\x5b\xe4\x03\xda\xae\xb4\x98\xf2\xd1\xd2\xe8\x52\x5a\x26\x0c\x0c\x0a\xab\x95\x1d\x42\xb2\xc0\xe4\x56\xaa\x39\x64\x46\x2f\x99\x17\xa9\x60\xae\x19\xb7\xf4\x84\xfc\xcb\x3a\x2c\xfe\x05\x4b\x74\x0b\x4d\x2a\x60\xc1\x69\x52\x6f\x22\x28\xe0\x16\xf0\xd3\x8f\xa0\x8b\x44\xa7\x38\xea\xed\x6d\xd3\x34\x85\xac\x54\x2c\xb5\xfe\x00\x3e\x1b\x74\xa5\x21\x65\x97\x76\x54\x71\x35\xca\x79\xe5\xe1\x43\x60\x2c\x45\x9b\xa0\x4a\x31\xe5\x83\xbb\xb5\xb0\x5a\xb0\xaa\xc0\x0a\xbf\xb9\x43\xf8\x54\x5a\xd7\x58\xc3\xd4\x0b\x05\xba\x24\x53\x6e\x1e\xbb\x54\xce\x73\x23\xe8\xb7\x42\xc3\x74\x8f\x7a\x7b\x15\xf0\x14\x32\x91\x5b\xa4\x7d\x0b\x61\xa4\x5b\x9f\x1a\xa3\xcd\x8f\xa2\x28\xa4\x9a\x4f\xe1\x73\x6f\x6f\x6f\x3f\xd1\x8a\x35\x06\x12\x83\x5e\x82\xc4\x2b\x58\xa7\x8d\x98\x23\x6d\x4b\xc7\x36\x17\x76\x7f\x0a\xfb\x17\xf5\xd7\x90\x80\x1f\x9f\x9d\x0b\x0b\xa5\x54\xee\xd5\xb7\xa0\xef\xd0\x64\xb9\x5e\x75\x2d\x5b\x8a\xfb\xb0\xa7\xfc\x15\x01\xef\x13\xc4\x14\xd3\xae\x95\x52\xdd\x89\x5c\xa6\xf0\xa9\x5c\x16\x24\x22\x27\x15\x93\x4c\x6b\xbf\x13\x1d\xe3\x0c\x55\xa9\x1a\x18\xbc\x43\xe3\x3c\xee\xcb\xf8\x9b\xd7\x84\x83\x4b\x85\x13\x91\xe5\x19\xf9\xe0\x26\x5f\x61\x80\xd7\x7b\x7d\xce\xe5\x52\x92\xaa\x8b\x84\x9c\xf9\xc1\xe4\xc5\xb7\xd0\x3f\x98\xbc\x78\x39\x68\x40\xf1\x4a\x0f\x54\x18\x4c\xf4\xb2\x90\xac\x5b\x82\xfe\x63\xc2\x4b\x99\xbb\xe7\x52\xc5\xa1\x61\x6f\xef\xa1\xfb\xc4\xae\x9c\x30\x4e\xaa\xf9\xcf\x92\xf4\xee\x73\x53\x22\x5e\x43\xa7\x51\x10\x52\x59\x67\xca\xa4\x96\x81\xa7\x97\x83\x56\x3c\x86\xab\x8d\xa1\xf6\xbe\x57\xb7\xb2\x60\xd7\x63\xdf\x6a\xc3\x44\xd8\x29\x7c\xf4\x5b\xda\x32\xcb\x64\x22\xc9\xcc\x67\x22\x17\x2a\xf1\x1e\x96\x75\x33\x43\xb3\xdf\xdb\x63\x1b\x96\xf6\x62\xf6\x09\x13\x77\xba\x2c\xdc\xba\x61\x27\x7a\xf6\x69\xc0\xd4\x13\x50\xff\x4e\x18\xb8\xa7\x60\xe3\x87\x21\x9c\x04\x2b\xee\x21\x3c\xf4\xf6\xf6\xa2\x51\x99\x12\x0f\x03\x8d\xe3\x31\x90\xe9\x92\x17\x90\xea\x4e\xdf\x06\x63\xa5\x23\x5d\x07\x59\x78\x77\x48\x56\x5e\x79\x1b\xb4\xa3\xde\x1e\xc1\x35\x88\xc9\xf5\x7c\x08\xe9\xcc\x13\x44\xb9\x88\x28\x5c\x69\x90\xdd\x1e\x32\xdb\x20\x97\x4b\x4c\xa5\x70\x98\xaf\x7b\x7b\x7b\x44\x2f\x4f\xc0\x11\xe4\x7a\x3e\x9a\xa3\x63\xf1\xf4\x07\x87\xbd\xbd\x3d\x99\x41\xdf\xcf\xfe\xe5\xe8\x88\x85\x9b\x49\x85\xa9\x47\xbf\xc7\x7e\x21\x13\x65\xee\xaa\x7d\x09\x28\x70\x48\x3f\x1f\x3c\x15\x3f\x23\x68\x95\xaf\x21\xa1\x64\x40\xcc\x48\x23\xed\xda\x3a\x5c\x06\xe6\xec\x10\x32\x61\xc9\x21\xc8\x0c\x56\x08\x85\xc1\xe7\xec\xef\x40\xab\x04\x03\x95\x76\x6d\xd9\xf7\x1e\x01\xed\x36\xd2\xc5\xc8\xe9\x77\xe5\x72\x86\xa6\x3f\x80\xbf\xc2\xe4\x3e\x9b\x0c\xe0\xe8\x88\x7f\x44\xda\x03\x4c\xa0\x97\xb0\xe8\x22\x30\xca\xf0\x57\xce\x48\x35\xf7\xbc\x06\x5a\xcf\x32\x10\xa0\x70\x05\x95\x27\x91\x16\x66\x48\x9e\x97\x5d\x0a\xa6\x43\x10\x69\x4a\xae\x95\x03\x44\x15\x0e\xda\x5b\xc2\x5f\xff\x4a\xfe\x9d\x08\xda\x3f\xb9\x3c\x3d\xbe\x3e\xdd\x87\xdf\x7e\x83\xd6\xc8\x8b\xfd\x41\x83\x32\xa9\x2e\xb2\x2c\x10\xe7\x1d\x6d\x81\x78\xdb\x3f\x18\x8c\xee\x44\x5e\xe2\x45\xe6\xc9\x0c\x6b\x4f\x55\x0a\x47\x01\xe6\xd9\x26\xcc\x8b\x16\x0c\x01\x8d\xc7\x70\x6c\x2d\x2e\x67\x39\x6e\xc7\xcd\x10\x58\x39\xc6\x92\xa3\xf4\xfa\x4f\xb6\x9d\x23\x69\x55\xdc\x35\x88\x9f\x29\xde\x73\xeb\x02\xa7\x00\x00\xba\x18\xf2\x00\x79\x76\x1e\x70\xfa\x07\xbc\xe7\x33\x8a\x22\x24\xad\x3a\x4e\x53\x83\xd6\xf6\x07\x03\xbf\x5c\xaa\xa2\x74\xd3\xd6\xf2\x25\x2e\xb5\x59\x8f\x2c\xe5\x0d\x7d\x66\x6d\xe8\x39\x8d\x30\x73\x61\x79\x87\xa8\xa9\xc7\x77\x42\xe6\x62\x96\xe3\xf7\xc2\xf6\xeb\x35\x67\x6a\x5a\xaf\x69\x4f\x9d\x68\xeb\xa6\x71\x8a\x3e\xe2\x1c\xcb\x8b\xc0\xf6\x27\xf7\xfb\xdb\x12\x9d\x0c\x6a\x6d\x39\x78\x35\x20\x90\x87\xc3\xca\x06\xea\xd8\x58\x94\x76\xd1\x67\x95\xab\x67\xeb\xe0\x77\x14\xad\xbe\xc3\x46\x58\xef\xb6\x75\xce\x62\x9e\x71\x0c\x20\xff\x47\xba\x37\x17\x1c\x5c\xd9\x1d\x08\xca\xa2\x6c\x39\xe3\x83\x71\x5a\x7b\x4c\xef\x2e\xae\x4f\xa7\xf0\x4f\x24\x87\xe2\xc8\xdc\xee\xfc\x99\x6f\x10\x23\x33\x9f\x53\x6c\xeb\x6d\x50\xd2\xab\xd3\xf3\xb7\x6f\x4e\xaf\xae\x2f\x3f\x9c\x5c\xef\x37\x14\x35\xc7\xcc\x11\x2b\x9d\x59\x01\x2d\x22\x74\xed\xd9\x8f\x04\xf3\xfc\xe0\xc6\x8f\xc0\x51\x87\x33\xd9\x7b\x1c\x02\x3e\xde\x30\xee\x87\x6d\xa1\xb7\x97\xfa\x23\xf8\x3a\x3a\xea\xb4\x57\xb7\xb0\xdc\xe9\xb8\xe0\x71\xed\x18\x7c\x5d\x55\x4c\x67\xb4\xe2\x3b\x1f\x9a\x1e\xa1\x79\x5b\x43\x77\xb8\xe3\xca\xc5\x85\x4c\x91\x62\x4e\xe2\x93\xa5\x4a\xef\x52\xad\xf0\xf7\x3b\xba\xe3\xf3\xf3\x96\x9b\x3b\x3e\x3f\x3f\xb9\x78\xd3\x72\x7d\x6f\x4e\xcf\x4f\xbf\x3f\xbe\x3e\xdd\x5c\x7b\x75\x7d\x7c\x7d\x76\xc2\xa3\x4d\xaf\xe8\x34\xa9\xda\x2e\xc1\x1f\x6c\x08\xbe\x72\x76\x14\xef\x39\xe8\x71\x28\xf1\x59\x4a\x83\x4f\x3b\x04\xb7\xd0\x54\xbe\x9a\x90\xa1\x66\x42\x25\x31\xd6\xda\xa8\xc4\xd2\xbe\xaf\x73\x9c\xbe\xd3\x83\xc7\x98\xed\x60\xa0\x21\x7a\xaf\xb8\x1c\x81\xd8\xcb\xf7\x9f\x2e\x0e\xf8\x07\x4c\x60\x0a\x07\x81\xbb\x47\x62\xc5\x0b\x78\x46\xe8\xff\x40\xc4\x78\xd9\x01\xf9\xe7\x8c\x1b\x5b\x36\xf9\xe7\x8c\x27\xba\x74\x17\x59\x36\x85\x4d\x41\x7f\xbb\x25\xe8\x6a\xfd\x39\xaa\xed\xf5\x7f\xdb\x5a\x1f\x62\x4f\xd4\xd1\x2f\x99\x5e\x54\x45\x3a\x08\xc6\xd1\xa1\x36\x5e\x4d\xb8\x70\x1d\xc5\x35\xc1\xf9\xf0\x67\xcb\xc8\xfc\xd6\xac\x19\x29\x9d\xbb\x2c\x50\xa5\xd0\xe7\x04\x8f\x76\xfd\x2d\x6e\x4d\x85\xa0\x0a\x7b\xbe\x86\xc9\x20\x82\x5d\x5f\xbc\xb9\x98\x52\x99\x91\x92\xa3\xa1\xaa\x8a\x8b\x42\x85\xf7\x2e\x26\xbb\xd2\x82\x15\x99\xcf\x07\xe3\x0e\x1e\x51\xb2\x10\x6a\xee\x2d\x94\xd9\xaf\xd1\x07\x3e\x3d\x17\x84\xf5\x08\x66\x72\x7e\xa6\x5c\xbf\x1a\x79\x06\x2f\x5e\x4e\x26\x81\x5b\x36\xc8\x07\xc0\xdc\x22\x34\x04\xd9\x34\xe3\x80\x72\x53\x2e\x93\xfd\x60\xd1\x5f\x3b\x01\xe8\xac\x98\xa9\x2e\x6e\xd7\xc4\x43\xaa\x2a\x8c\xc4\x3b\x04\xe9\xbe\xb1\x8c\x13\x44\x9e\xeb\x15\x45\x88\x11\xfc\x8c\x1e\xa3\x42\x64\xf7\x1d\x9a\x28\xc4\x65\xb3\x79\x50\x79\x75\xc1\xbd\x0e\x83\xb0\x14\x6b\x98\x21\x55\x13\xb7\x6b\x3e\x98\x74\xad\xc4\x52\x26\xd6\xe3\xe3\x06\x8a\xc1\xb9\x30\x8c\xd6\xe0\xbf\x4b\xb4\x0e\x53\x76\x00\x22\x71\xa5\xc8\xf3\x35\xcc\xe5\x1d\x2a\x86\xee\x93\xb4\xe3\xf9\x0d\xe1\xd5\xcb\xf1\xab\x6f\xc1\x94\x39\x0e\x46\xbd\x46\x96\x50\xb1\x1a\xe4\x4d\x13\xc1\xa2\xde\x60\xe1\x16\xfd\x01\xbc\xde\x91\x6e\x34\x95\x3b\x78\x99\x8d\xdc\xa0\x13\x0c\x9e\xc3\x81\x4f\x27\x78\xb3\x5a\x63\xba\xf2\x92\xa6\x42\x35\x7d\xc0\xb6\x16\x7d\x6e\x6a\x78\xff\x56\x18\x91\x8b\x19\x0e\xa6\xdc\xa2\x64\xf2\x56\x22\xf4\xa8\xe8\x48\xa1\xc8\x85\x54\x20\x92\x44\x97\xca\xd1\xb1\xc5\x76\x53\xbe\xa6\xf8\xfb\x8d\x8b\xf8\xb8\x9b\x27\x92\x04\xad\x8d\xe1\x98\xcf\x9c\x88\x12\x4b\x82\xa6\x12\x59\xa6\xd8\x38\x53\xf2\xc9\x9a\x43\x60\x58\xb1\x92\x79\x1e\x11\x2e\xb5\xa5\x4d\x66\x08\x2b\xa3\x29\xcb\x94\x54\xf5\x4a\x52\x3b\x3a\x2b\x0b\x5a\x81\x80\x5c\x73\xc9\xcf\x9e\x15\x84\x99\xdb\x91\x8f\xab\x6c\xb2\xda\x80\xd2\xab\x51\x3b\x27\x6b\x6a\xba\x2f\x79\x83\x7e\x77\x67\x98\x97\xa7\x3f\x9d\x5e\x56\xb9\xe5\x93\x4f\x6e\x14\x0b\xd6\xae\x5e\x48\x15\xb7\xf8\x10\x7e\x95\x7a\x2e\x6c\xb2\x30\x03\xef\x71\x58\x40\xba\x74\xc4\x11\xdb\x82\xef\x24\x08\x4b\xcc\x53\xd8\x11\x52\xf9\xce\x9f\xdf\xa3\x10\xd6\xc6\xae\x15\xcb\x36\x26\xe8\x29\xde\x61\xae\x0b\x34\xdb\xb6\xbc\x8b\xd7\xeb\x0f\x97\xef\xf6\x77\xeb\xf8\xd1\x13\x74\xdc\x47\x95\x6d\x0f\x3e\xd9\x0c\xf9\x71\xf5\x39\xaa\x27\x94\x94\xbf\x43\xf4\x41\x76\x47\xbb\xc2\xac\xa7\x70\x18\x29\x7d\x16\x88\x18\x0c\xea\x24\x68\x5b\x5a\x4f\x94\x04\x51\x10\xa4\x31\x1e\xc3\x7b\x5d\x70\x2e\x45\xc7\x92\x0b\xeb\x6a\xbd\x9f\xa3\xef\x94\x34\xb5\xc3\x96\xb9\xb3\xbd\xc7\x5c\xc5\xa8\xd0\x45\x4c\x7b\x2a\xaf\x40\xd9\xca\x66\x0d\xdf\x35\xf1\xa2\x0a\x16\xde\x93\xbb\xa6\xc5\x0b\xf0\x8b\x1a\x7e\xbb\xa5\x4b\xc2\xa7\x38\x4c\x7b\x90\x2f\x45\xc1\x5e\xd3\xf9\x7c\xb0\x6c\x54\x21\x2a\x6f\x06\xb6\xe7\x95\x0c\xd9\x35\xd1\x77\x9c\x3b\x53\xf0\x1c\xe2\x07\x65\x28\x83\x8d\x4a\xc1\x6b\x40\x8a\x39\x3a\x84\x1a\xea\x10\x36\x86\x08\x36\xc4\x7e\x92\xa1\x41\xd7\xa5\x87\xb5\x57\xfd\x8b\x41\x37\xc2\x7f\x97\x22\xb7\xfd\xc9\xa0\xed\x4d\x9d\xe6\xb4\xeb\x68\xab\xb0\x22\x98\x76\x29\x75\xd8\x00\xdb\x50\x3e\x5f\x18\x9d\xe8\x14\x1f\xc5\x10\x3d\x75\x1d\xea\x19\x59\x70\x22\x9d\x2e\xdf\x77\x8a\x4e\xdb\x7d\xb1\x13\x91\xe7\x8d\xde\x58\x95\x7f\x9d\xee\x6c\x90\x35\x6b\xe1\x9d\x7d\xc8\x91\x54\x29\xde\x5f\x64\x11\xd3\x00\x5e\xc3\xf3\x83\x1a\x43\xb3\x88\x88\x26\x14\x05\x12\x1d\x61\x00\x0d\x6b\x5a\xe1\xa8\x6a\x09\x78\x5f\xe8\x5d\xe1\x0a\xe3\x45\x11\x77\x7b\xd9\x12\x3c\x90\x50\xeb\xa5\x36\xd8\xb5\xc9\x7e\x95\xfc\x67\x42\xe6\xa5\xc1\xfd\x43\xe8\x08\x76\xb6\x34\x99\x48\x58\xc5\x2d\x02\xb7\x07\x2d\x58\xbd\xc4\x85\x5e\xf5\x3a\x38\x7a\xd8\x1d\x47\xb7\x0d\xa9\x6e\xef\xb7\xf3\x20\xbe\xa3\x12\x16\x4a\x2b\xe6\xd8\x30\xa4\xed\x18\xdf\x7d\x4e\x4f\x32\xb3\x2d\x53\x82\x67\xd0\x30\xc1\xa6\x05\x76\x99\xd8\xc3\xff\xae\xa1\x55\x5c\x47\xab\x69\x32\x5e\xf9\xb1\xc6\x24\x31\x5d\xab\x5d\x97\xc1\x05\x0e\x2f\xf9\xf8\xde\x08\x27\xfa\x95\x7d\x3e\xfc\x7f\xb4\xb1\xae\x1e\x40\xf4\x33\xc1\x8f\x0d\x06\x3e\xc6\xd7\x04\x36\x6f\x91\x1a\x3b\xb4\x4d\xa9\xe3\xee\x84\x8d\xe9\x3d\x73\xc0\x35\xb4\x70\x72\x96\x47\x43\xdc\x30\xe9\xc7\xac\x3f\x52\xff\x67\xf7\x02\xd1\xee\xb7\xac\xc2\xa7\x0e\x6d\xb3\xf0\x59\x44\x9d\x43\x7c\xd9\xa4\x1b\x59\xfb\x37\x93\xfb\x6f\xb6\xad\xb9\xc3\x44\x1f\x62\xee\x78\xa6\x3e\x61\xe2\x6a\xe7\xc3\x35\x18\x7d\x15\x06\xef\xa4\x2e\x29\x41\xc6\x27\xf7\x43\xc3\x02\xfe\xef\x35\x4c\xe0\x1f\xe0\x3b\x96\x30\xe5\x1f\x8f\xf5\x4c\x7f\x6f\xc7\xf4\xc9\xfd\xd2\x56\xb7\xb4\xaa\x57\x1f\xea\x0b\x29\x3e\xb2\xe6\x8d\x14\x57\xf3\x24\x03\x5f\xe9\x35\xb2\x2b\x9d\xf1\x8d\xae\x2f\xdd\x33\xff\x22\x61\x8f\xe1\x1f\xb9\x99\x0a\xbe\xdd\xe9\x82\x8a\x91\x90\xbc\xe5\x94\xa3\xaf\xab\x64\x7e\xe8\xcb\x20\x58\x08\x95\x86\x06\x94\x48\x53\xe9\x2f\xcd\x03\x85\x62\x2e\xa4\xea\x75\x0a\xf0\x8b\x15\x44\x97\xe2\x6c\xd5\xe5\xcd\x3c\x33\xb4\x0a\xd9\x6c\x09\x71\xef\x09\xf9\xe4\x86\xfd\x6c\x5e\xb2\xf5\x9e\xe6\x09\xbf\xe4\x06\xff\x53\x1f\xb8\xd9\xa9\xdc\xe5\x60\xd8\x44\xf8\x91\x93\xb2\xe5\x92\xdb\x0e\x20\x62\xdb\xcc\x17\xa4\x2a\x85\x24\x47\xa1\xfc\x93\x1d\xcc\x9c\xbe\x43\x63\x7b\x4f\x30\xda\x3f\x62\xb3\x1b\x91\x3b\x7e\xf6\xda\x0e\x70\x3c\x86\xcb\x98\x2b\xd0\x06\xed\x96\x49\x5d\xdf\xb5\x1e\x1e\xc4\xbb\xcb\xaf\xda\x47\xf9\xfa\x8d\x94\xdd\x62\x7b\x3c\x23\xe1\xa3\xec\x08\xd5\xcd\x00\x46\xc1\xed\xd1\xf6\x48\x63\xef\xaa\x33\x46\x0a\xf4\xd4\x34\xe7\xe9\xae\xdf\xeb\xdd\xdb\x5c\x38\x17\x1c\x51\xc3\x10\xbd\x87\x96\x8e\x9f\xd1\xa1\x72\xbd\xa7\xb9\x66\x2e\x3e\x83\x5b\xde\x34\xa4\xff\xbb\xcb\xab\xba\x7d\xb8\xe5\x8c\xce\xab\x42\x37\x30\xef\xb4\x1e\x42\x8e\x82\x7b\x81\xf1\x91\x5b\xbc\xa6\x79\xac\x37\x19\xfd\xbc\x2f\x8d\xb7\x1c\x3d\xdf\x28\x2e\x30\xde\x88\xf8\x16\xd4\x0c\x51\x81\x74\x68\x04\x29\x2b\x99\x75\x78\x97\x45\x54\x5a\x46\xc7\xe7\x22\xc9\x3d\x07\xc4\xe1\x91\x14\x59\x8e\x54\xf3\x51\x6f\xcf\x8f\x37\x22\x43\xe2\xee\xeb\xc8\xe0\x33\x5e\x86\x0c\x37\x06\xb3\x5c\x27\xb7\x53\x00\x48\xdc\xfd\x88\x3f\xb8\x63\x5e\xdd\x23\xd0\x30\x7d\xf0\xe8\xc6\x65\x02\xcd\xd1\x90\x6f\xb2\x6f\x5c\x1d\x30\x60\xb8\x3e\xa8\xee\xdc\x82\x01\xd1\xdc\x76\xeb\x9b\x97\x56\x97\x06\x1b\x2e\xca\xdd\x6f\x7b\xa8\x08\x40\xce\x69\xda\x0d\x40\x53\x1d\x40\x1b\xd7\x19\xb4\x98\x87\xfc\xac\xcf\xcb\xa7\xcd\x59\x3f\x14\x18\x95\xcb\x86\x6c\xe4\x92\x65\xc3\xf7\xd5\xfc\xdc\x83\xdc\xd8\x89\xbb\x6f\x09\xf8\x07\x61\x17\xd3\x5a\xc4\xf4\x39\xac\x26\xfd\x33\x8b\xc6\xb4\x1f\xf0\x7b\xd5\x4f\xb8\x6a\x1c\x1b\x83\x9b\x0b\xdf\x6b\xcb\x41\x7c\x6b\x71\x9c\xa8\xe8\x25\x67\xe9\xf3\x8e\x56\x77\xd1\xe0\xd2\x37\xea\xd8\x8d\xab\x14\x32\x69\xac\x23\xd5\x5c\x92\x0d\x24\xcd\x67\x7c\x42\x01\x2e\x0b\xb7\x06\xcd\xcf\x77\x3c\xd2\xd4\xe8\x22\xe8\x6a\x04\x1c\xf2\xab\x1d\xc3\xef\x60\x75\x4c\x39\x30\x9d\x93\x1b\xb2\x68\x6b\xdb\xc2\xa2\x3f\x80\x5c\xeb\x62\xe4\x71\xe1\xbd\x58\x16\xcd\xb5\xd3\xc6\x3d\xbe\x92\xbe\x99\x43\xde\xf1\xd5\xe4\x6f\xe2\xd5\x64\x32\xf9\xdb\xcb\x57\x93\xc9\x01\xfd\xa2\xff\xb3\x49\x96\x4d\x26\xfb\x43\xb0\x28\x4c\xb2\xe0\x7d\xd0\xba\x54\x38\xd1\xd9\x25\x27\x97\xdc\x9d\xd9\xbc\x86\x83\x6a\xb2\xf5\x5a\x69\xd3\xa1\x4d\x6e\x06\x9d\x3d\xd7\x91\x5d\xc8\xcc\xd5\xcf\x61\x3a\x7c\xe1\x24\x3a\xb5\xee\xdc\x89\x0c\xb7\xf2\x7a\x3b\x40\x1f\xc7\xfe\x58\x66\xc6\xd8\x63\x52\xb2\x03\xf4\xb0\xd7\x2e\x43\xdd\xfd\xd3\x51\x56\x8b\x9b\x24\xb6\xd6\xb4\x90\xf0\x6d\xf0\xd6\x74\x57\x53\x9a\xca\xed\xb0\xb0\x2e\xb8\xb9\xde\x0e\x84\x84\x80\xd7\x5a\x13\x89\x68\xbe\xc2\x64\xd7\x2a\x7f\xc5\xb0\xed\xb0\x32\xe6\xa6\x4b\x8f\x8b\xc0\xa0\x7f\xb2\xc4\x9d\x13\xf2\xe8\xde\x04\xa0\xb4\x52\xcd\x1b\xae\x3a\x45\x2b\x0d\x55\xa2\x12\xf3\x34\xd8\x40\xa6\x0d\x7c\xb2\x5a\xf9\xd7\x69\x68\x24\xa1\xf4\x8f\x65\xfd\xbb\x75\x7e\xc2\xab\x64\x82\x6e\x0d\x19\x0a\x7e\x66\xe6\x34\x77\xcc\x61\x89\x42\x49\x35\xcf\x4a\xca\x63\x18\x1f\x1b\x6d\x68\xc2\x52\x98\xd0\x50\x5a\x34\x16\x56\x0b\x1d\x92\x7c\xae\x2b\x0b\x83\x64\x8d\xc3\x70\x09\x26\x6d\x91\x8b\x35\x48\x47\x05\x45\xe0\xaa\x19\x39\xb8\x09\x14\x45\x30\xf4\x2f\x87\x43\x81\x5e\x87\x13\xb2\xa4\xc3\xde\x7f\xd2\xd4\xe5\x77\x94\x51\xe3\x58\xa2\x97\xcc\x4b\x0c\xa6\x21\xad\x2b\x8b\x54\x38\x04\x91\xb9\x90\x43\xfa\x55\x7c\x0f\xc3\x17\x0c\x22\xcb\x30\x71\xd6\x3f\x86\x23\xf1\x1b\xad\x1d\xd0\xae\x55\x2a\xe5\x49\xa8\x48\xdb\xd4\xe6\x16\x95\x5d\x0f\x74\x5a\x48\xae\x3e\x9c\x9d\x9c\xbd\xf1\x58\x5a\x4c\xd8\x52\x26\x32\xdd\xe0\xa2\x9d\x31\xb7\x78\xae\x78\xf9\xba\x1c\x77\x9c\x48\xf3\xc5\x48\x7b\x6a\xeb\xa5\xc4\x86\x30\x76\xdc\xdb\x56\x02\xa5\x99\x2a\x0b\xe3\xdc\xb7\xa9\x2e\x7c\x35\xdb\xf8\xfc\xed\xb7\x90\xb4\xf1\xd3\x43\x4d\x56\x1c\xe3\xa5\xcf\x3c\x2a\xe4\x23\xa7\xcf\xf5\x0a\xcd\x89\xb0\x18\x6e\xf3\x7d\x30\x9b\xb2\xe6\x8d\xc2\x23\xf4\xda\xdd\x84\xf1\x60\xc1\x34\xce\xce\x23\xa0\xe4\xdf\x31\x60\x56\xf4\x4c\x5b\xd4\xf1\xb4\x2d\x67\x3c\x66\xa7\x30\xd9\x1d\x60\xa3\x71\xec\x8a\xb2\xdb\xf1\xbb\x0b\x62\x47\x3a\x40\xf4\xf2\x08\x89\xab\x82\xdb\xcc\x10\x1a\xf9\x45\x7b\x4d\x9d\x1a\x70\xbe\xe2\x25\x1a\xb3\x95\x58\x9c\x78\xd9\x3f\xe2\xc3\x37\x2a\xdf\xe6\x0b\xe3\xd1\x42\xd8\x8b\x95\x7a\x6f\x74\x81\xc6\xad\x5b\xb8\xaa\x46\x69\x6b\x83\xa0\xf0\xdb\xa8\x3e\x36\x97\xdd\xb4\xee\x4c\xc2\x8c\x3f\xcf\xc3\xad\x3e\x76\xf5\x2c\xd8\xc7\xf7\x7f\xe2\xda\xa7\x12\x9d\xdb\x34\xdf\x44\xb7\x3a\xb9\x4f\x58\xbf\xc5\x6d\xdc\x8f\x5b\x94\x4d\xfa\xab\x56\x40\x63\x49\xbb\x21\xfa\x34\xa1\x34\x77\xff\x58\xe1\xba\x89\x8d\xc8\x9d\xf2\x69\x37\xfd\xe3\x69\x57\x82\xba\xf5\x22\xf2\x70\xcd\x53\x5e\x17\xa8\xb3\x30\xfe\xf1\x16\xd7\x37\xa1\x5e\xe5\xa8\x56\x99\x7d\x85\x47\x71\x51\xfd\xdf\x2d\x74\x0c\xd6\x92\x6c\x63\xfc\x63\x0d\x71\xb3\xa3\x6b\xdd\x66\x6a\x0b\x6a\xe7\x9d\xc6\xc6\x4e\xdd\xd8\xb7\x71\xb7\x1d\x56\xec\x35\xd9\xd8\xe8\xa8\xf2\xa9\xe8\x49\xbb\xf3\xb2\x80\x70\xbf\x72\x19\xfb\x37\x01\x83\x6d\x14\xc3\xd5\x16\x21\x50\x53\xdd\xea\x21\x6f\x0e\x7b\x5f\xdc\xa3\x92\xba\x3c\x9a\x1c\x82\xfc\x7b\x0b\x3b\xc8\x67\xcf\x5a\x6f\x35\x16\x32\x4f\x4f\x7c\xd7\x8c\x17\x7e\x94\x37\xf5\xbb\xa2\x37\x98\xe3\x5c\x38\xe4\x94\xa5\xa4\xdc\x9b\x22\x87\x7f\x80\xc3\x8d\x97\xaa\xc6\xf7\x44\xf5\x2b\x74\x8f\x45\x8b\xed\x35\xad\x90\x41\x89\xb3\xd7\xaf\x7a\x65\x78\xf3\x43\x6b\x2b\x76\xeb\x9e\xfe\xd6\x3a\x7f\x26\xfc\x71\xd8\x6c\x6e\xd7\xf2\x0c\xbf\x46\x89\x56\x89\x70\xfd\x76\x4a\x57\xe1\xdb\x95\xd2\x44\xb0\x8f\xf2\x66\xd0\xb8\x50\x6f\xe4\x88\x01\x7f\xcc\x05\x9b\xb9\xca\x46\xda\xe4\xb9\x08\x60\x9f\x9b\x41\x2b\x18\x51\x2c\xa4\xc3\x3f\x66\x8d\x06\x87\xb0\xf1\x6f\x3c\x86\x2b\x2a\x4c\x4c\x38\xdd\x50\x48\x37\xe1\x78\x70\x13\x70\x3c\x86\x9f\x68\x9c\xc1\xaa\xa2\xba\x09\x36\x17\x76\x6b\x37\x02\xfb\x5e\x84\xf7\x5f\x4a\xba\x2d\x1a\xb9\x4a\xee\xd8\xeb\x4c\x49\x57\xe7\xb0\x8d\x0b\xf6\xf0\x77\x42\x3f\xf2\x5b\xd8\xdd\x71\x9d\x91\x9c\xb0\x40\xe1\x3a\xe4\x2f\x0f\xad\xa8\xfe\x39\x72\xe2\xab\x7d\x68\x76\x26\x87\x91\x70\xfe\x33\x2f\xbf\xb1\x4e\x83\xa0\x1a\x57\x6d\x43\x4f\xec\x49\xa4\x4e\xc4\xf0\x1f\xe8\xd2\xc3\x9a\xa3\x63\x6b\xe5\x9c\xca\x90\xb0\xa8\xa1\x0f\xfe\xf4\xab\x7c\xec\x8f\x9f\xfd\xce\x63\x6f\x9f\x7a\xd5\x59\xd9\xa2\xb3\x05\x71\x89\x89\x2c\x64\x34\xdd\x86\xaa\xec\xd4\x92\xf1\x18\xae\xc3\xdf\xe1\x60\xda\xad\x2f\x3b\x55\xa5\xa5\x29\xa1\x9b\xf2\x88\x92\xb0\x8e\x50\x69\x16\x2a\x6f\x9f\x3f\x5e\xfb\x36\xd3\x4e\xad\x08\x7f\x80\xca\x3e\x45\xd7\xd7\xd5\x5f\xd4\x8e\x2f\x28\x47\xd5\xe0\xd9\xd6\x8d\x0b\x5f\x40\xce\xd6\x0e\xb7\x8e\xbc\x95\xd7\xff\xce\x53\xaf\x55\xad\xe3\xe8\xfd\x6b\xcf\xa8\x65\x7b\x06\xb3\x52\xa5\xc7\xdd\xca\xc9\x07\x4d\xf3\x4d\xc5\xdc\x0b\x7f\x5a\xd5\x46\x1f\x8f\x7c\x3c\x86\xf0\xbe\x7d\x5b\x70\xaa\x64\x71\x12\x8b\xbd\x87\xde\xff\x04\x00\x00\xff\xff\x21\xf8\xaf\x37\x60\x3d\x00\x00")
+var _call_tracer_parityJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdc\x7c\x6d\x73\x1b\x37\xf2\xe7\x6b\xf2\x53\x74\xf4\xc2\x21\xcb\x14\x49\x39\x89\xaf\x8a\x5a\x7a\x4b\x2b\xcb\x8e\xea\x14\xcb\x25\xcb\x49\xa5\x5c\xae\x5b\x70\xa6\x87\x44\x34\x1c\xcc\x02\x18\x49\x5c\xaf\xbe\xfb\x55\x37\x80\x79\xa6\xac\xcd\xba\xae\xf6\xfe\xc9\x0b\x4b\x33\xe8\x46\xa3\xd1\x0f\xbf\x6e\x60\x34\x9b\xc1\xa9\xca\x77\x5a\xae\x37\x16\x5e\xcc\x8f\xfe\x17\x5c\x6f\x10\xd6\xea\x10\xed\x06\x35\x16\x5b\x38\x29\xec\x46\x69\x33\x9c\xcd\xe0\x7a\x23\x0d\x24\x32\x45\x90\x06\x72\xa1\x2d\xa8\x04\x6c\x6b\x7c\x2a\x57\x5a\xe8\xdd\x74\x38\x9b\x39\x9a\xde\xd7\xc4\x21\xd1\x88\x60\x54\x62\xef\x84\xc6\x05\xec\x54\x01\x91\xc8\x40\x63\x2c\x8d\xd5\x72\x55\x58\x04\x69\x41\x64\xf1\x4c\x69\xd8\xaa\x58\x26\x3b\x62\x29\x2d\x14\x59\x8c\x9a\xa7\xb6\xa8\xb7\x26\xc8\xf1\xf6\xdd\x47\xb8\x40\x63\x50\xc3\x5b\xcc\x50\x8b\x14\xde\x17\xab\x54\x46\x70\x21\x23\xcc\x0c\x82\x30\x90\xd3\x13\xb3\xc1\x18\x56\xcc\x8e\x08\xdf\x90\x28\x1f\xbc\x28\xf0\x46\x15\x59\x2c\xac\x54\xd9\x04\x50\x92\xe4\x70\x8b\xda\x48\x95\xc1\x0f\x61\x2a\xcf\x70\x02\x4a\x13\x93\x91\xb0\xb4\x00\x0d\x2a\x27\xba\x31\x88\x6c\x07\xa9\xb0\x15\xe9\x13\x14\x52\xad\x3b\x06\x99\xf1\xf2\x36\x2a\x47\xb0\x1b\x61\x49\x13\x77\x32\x4d\x61\x85\x50\x18\x4c\x8a\x74\x42\xdc\x56\x85\x85\xdf\xce\xaf\x7f\xbe\xfc\x78\x0d\x27\xef\x7e\x87\xdf\x4e\xae\xae\x4e\xde\x5d\xff\x7e\x0c\x77\xd2\x6e\x54\x61\x01\x6f\xd1\xb1\x92\xdb\x3c\x95\x18\xc3\x9d\xd0\x5a\x64\x76\x07\x2a\x21\x0e\xbf\x9c\x5d\x9d\xfe\x7c\xf2\xee\xfa\xe4\x6f\xe7\x17\xe7\xd7\xbf\x83\xd2\xf0\xe6\xfc\xfa\xdd\xd9\x87\x0f\xf0\xe6\xf2\x0a\x4e\xe0\xfd\xc9\xd5\xf5\xf9\xe9\xc7\x8b\x93\x2b\x78\xff\xf1\xea\xfd\xe5\x87\xb3\x29\x7c\x40\x92\x0a\x89\xfe\xeb\x3a\x4f\x78\xf7\x34\x42\x8c\x56\xc8\xd4\x04\x4d\xfc\xae\x0a\x30\x1b\x55\xa4\x31\x6c\xc4\x2d\x82\xc6\x08\xe5\x2d\xc6\x20\x20\x52\xf9\xee\xc9\x9b\x4a\xbc\x44\xaa\xb2\x35\xaf\x79\xaf\x41\xc2\x79\x02\x99\xb2\x13\x30\x88\xf0\x97\x8d\xb5\xf9\x62\x36\xbb\xbb\xbb\x9b\xae\xb3\x62\xaa\xf4\x7a\x96\x3a\x76\x66\xf6\x6a\x3a\x24\x9e\x91\x48\xd3\x6b\x2d\x22\xd4\x64\xad\x02\x92\x82\xd4\x9f\xaa\xbb\x0c\xac\x16\x99\x11\x11\x6d\x35\xfd\x4c\x43\x78\x93\xf0\x9e\x7e\xb3\x86\x8c\x16\x34\xe6\x4a\xd3\xcf\x69\x1a\xec\x4c\x66\x16\x75\x26\x52\xe6\x6d\x60\x2b\x62\x84\xd5\x0e\x44\x9d\xe1\xa4\xbe\x18\x32\x23\xb7\xdd\x20\xb3\x44\xe9\x2d\x9b\xe5\x74\xf8\x65\x38\xf0\x12\x1a\x2b\xa2\x1b\x12\x90\xf8\x47\x85\xd6\x98\x59\x52\x65\xa1\x8d\xbc\x45\x1e\x02\x6e\x8c\xd7\xe7\xd9\xaf\xbf\x00\xde\x63\x54\x38\x4e\x83\x92\xc9\x02\x3e\x7d\x79\xf8\x3c\x19\x32\xeb\x35\xda\xd3\xf0\xe2\x02\xb3\xb5\xdd\xc0\xc8\xd9\xb6\x48\xc7\x34\x5d\x61\x30\xe6\xad\xa5\xa7\x5b\x69\x58\x30\xd0\x28\x8c\xca\xcc\x04\xa2\x0d\x46\x37\x32\x5b\x43\xa2\xd5\x96\xd7\x22\x33\x58\x2b\xe6\x2d\x9d\x20\x7f\x37\x16\xf3\xbf\xc3\x16\xed\x46\x91\x09\x18\xb0\x8a\xcc\x9b\x04\xf2\xbc\x05\xfc\xfa\x0b\xa8\x3c\x52\x31\x4e\x87\x83\xae\x4c\x0b\x48\x8a\x8c\xb7\x61\x34\x86\x2f\x1a\x6d\xa1\xc9\xd8\xa5\x99\x96\xab\x9a\xa6\x2c\xfd\xf1\x83\x5f\x58\x8c\x26\xc2\x2c\xc6\x98\x74\x1e\xdd\x18\xb8\xdb\xb0\xa9\xc0\x1d\x7e\x7f\x8b\xf0\x47\x61\x6c\x6d\x0c\x4b\x2f\x32\x50\x05\xb9\x72\x7d\xdb\x65\x66\xdd\x6a\x04\xfd\x9c\xa1\x66\x55\x4f\x87\x83\x92\x78\x01\x89\x48\x0d\xfa\x79\x65\x16\xa5\x45\x8c\xef\x35\x46\x6a\x9b\xcb\x14\x4d\x69\x20\xa4\x0c\x22\x66\x05\xe4\xe5\x80\x18\x22\x95\x79\x7b\xb2\x4a\x4d\xe0\x6e\x23\xa3\x0d\x08\x8d\xcc\xd0\xdc\xc8\x3c\xe7\x28\x06\x31\x26\xa2\x48\x2d\xa4\xf2\x06\xe1\x32\xc7\xec\x2c\x18\x7f\xac\xd0\x4c\x87\x83\xee\xe4\xbd\xc2\xbd\x15\xe6\x0a\xb7\x42\x66\xb4\x71\x75\xe9\xd6\xc2\x00\x8a\x68\x03\x89\x16\x5b\x84\x8d\x88\x21\xc5\xc4\x92\xee\x32\x8a\x4c\x4e\xf3\x18\x4f\x86\x83\x1e\x67\x14\x06\xa4\x35\xac\x1f\xd4\xa0\x29\x0a\x64\x18\xc3\x16\x45\x76\xb7\xa1\x74\x32\x3a\x3b\x7f\x7f\x78\xf4\xd3\xfc\x7b\x03\x47\xb3\x97\x3f\x8e\x2b\x89\xeb\x12\xf5\x8a\x7c\xa1\xd6\x4d\x45\xa6\xf4\x00\xb7\xd2\x52\x14\x8d\xa5\xc6\xc8\xa6\x3b\x52\x52\x25\xff\x04\x8a\x2c\x45\xe3\xc6\xf3\x8a\x58\x6c\xb2\xe5\x0c\x29\xec\x54\xd2\x1a\x48\x04\x6d\xc5\x04\x34\xde\xa2\xb6\xa4\x18\xbb\xc1\x6d\x25\x21\x09\xd0\x2b\xd9\x1b\x26\x3c\xd5\x28\x2c\xbe\x15\x06\x6e\x10\xf3\x4a\x9b\xab\x42\x67\x96\xc4\x72\xfc\x21\xa2\x71\x52\x65\xc6\x47\x7e\xa9\x59\x26\x8d\xa6\x48\x6d\xd8\xfa\xe6\xd6\x6a\x95\x9b\xb6\xae\x35\x1a\xce\xca\xd2\x56\x12\xb6\x04\xa9\x09\x9b\x0b\x2d\xed\xee\xc3\x8d\xcc\x39\xce\x99\x37\x4a\x9f\x69\xad\xb4\x59\xc0\xa7\xe1\x60\x70\x20\x33\x53\x24\x89\x8c\x24\xc5\x94\x95\x48\x45\x16\xb9\x70\xce\x8e\x90\xa0\x3e\x18\x0e\x38\x60\x48\x73\xb9\xfa\x03\x23\x7b\xb6\xcd\xed\xae\xe6\x94\x6a\xf5\xc7\x18\xbe\x0c\x07\x03\x22\x1a\xdd\x0a\x0d\xf7\xb4\x3e\xf7\xd8\x5b\x8d\x13\xe7\x18\x1e\x86\x83\x41\xf0\x60\x5d\xe0\xf1\x70\x10\x5c\x56\x66\xd2\x52\xc8\x91\xd9\xad\xba\x21\x8b\xc7\x44\x69\x2c\x83\x99\xb1\x42\x5b\x33\x81\x5c\xba\x88\x53\xe4\xfc\xca\xc7\x65\x0a\x4f\x2a\x73\x4e\x20\x6d\x4d\xb6\xc8\xde\x4f\x20\x5e\x39\xf9\x38\x66\x74\xbd\x04\x96\x10\xd9\xfb\xde\x17\xcb\x65\x10\xb3\x41\xdc\x70\xa1\x06\x75\xf3\xcd\x1e\x72\xb2\xa6\xe6\xa4\xee\xc9\x9e\xe1\x6d\x1b\x6b\x50\x76\x5e\x2e\x97\x6d\xcd\x52\x04\xae\x6b\x96\xb6\x89\xec\x7c\xe7\x83\xae\x83\x1e\xa4\xcd\x32\x69\x70\x3c\x21\xba\x9a\x2a\x53\xb5\xae\x54\x49\x90\x52\xe4\xb6\xd0\xc8\x20\x08\xd9\xa0\x40\x6e\xb7\x18\x4b\x61\x31\xdd\x0d\x07\x03\xb2\x04\x7e\x01\x4b\x48\xd5\x7a\xba\x46\xcb\x86\x37\x1a\x1f\x0f\x07\x03\x99\xc0\xc8\xbd\xfd\x6e\xb9\x64\xac\x97\xc8\x0c\x63\xc7\xde\xad\x9f\xe3\x5d\x39\x2f\x11\x79\xdb\xa1\x1f\xc9\x92\x66\x33\xf8\x0d\x41\x65\xe9\x0e\x22\xc2\x74\x62\x45\x60\xc8\xec\x8c\xc5\xad\x5f\x9c\x99\x40\x22\x0c\xc5\x75\x99\xc0\x1d\x42\xae\xf1\x90\xd3\x16\xa8\x2c\x42\x2f\xa5\xd9\x19\x8a\x04\xb0\x04\x9a\x6d\xaa\xf2\xa9\x55\xef\x8a\xed\x0a\xf5\x68\x0c\xcf\x60\x7e\x9f\xcc\xc7\xb0\x5c\xf2\x0f\x41\x76\x4f\xe3\xe5\xa5\xb5\xaa\xdc\x2f\x94\xe9\x3f\x58\x2d\xb3\xf5\x68\x5c\x93\xf5\x3c\x01\x01\x19\xde\x95\x21\x9f\x76\x65\x85\x64\xce\x1c\x19\x28\x02\x89\x38\xa6\x04\x11\xb2\x85\xcb\xea\xcd\x29\xe1\xd9\x33\x4a\xd3\x24\xd0\xc1\xe9\xd5\xd9\xc9\xf5\xd9\x01\xfc\xeb\x5f\xd0\x78\xf2\xe2\x60\x5c\x93\x4c\x66\x97\x49\xe2\x85\x63\x86\xd3\x1c\xf1\x66\x74\x34\x9e\xde\x8a\xb4\xc0\xcb\xc4\x89\xe9\xc7\x9e\x65\x31\x2c\x3d\xcd\xf3\x36\xcd\x8b\x06\x0d\x6d\xc9\x6c\x06\x27\xc6\xe0\x76\x95\x62\x17\xfe\xf8\xa8\xcd\x50\xc9\x58\x72\x68\xb2\x3e\x72\xb0\x14\xc9\xaa\xc2\xac\x5e\xfd\x2c\xf1\xc0\xee\x72\x5c\x00\x00\xa8\x7c\xc2\x0f\x28\x41\xf3\x03\xab\x7e\xc6\x7b\xde\xa3\xa0\x42\xb2\xaa\x93\x38\xd6\x68\xcc\x68\x3c\x76\xc3\x65\x96\x17\x76\xd1\x18\xbe\xc5\xad\xd2\xbb\xa9\x21\xf8\x37\xe2\xa5\x4d\xdc\x4a\x03\xcd\x5a\x18\xa2\x80\x60\xa9\x27\xb7\x42\xa6\x62\x95\x52\x54\x1f\x55\x63\xce\xb3\x45\x35\xa6\xf9\xea\x54\x19\xbb\x08\xaf\xe8\x97\xf0\x8e\xf5\x45\x64\x07\xf3\xfb\x83\xae\x46\xe7\xe3\xca\x5a\x8e\x5e\x8e\x89\xdd\xc3\x71\xe9\x03\x15\xc4\xc9\x0b\xb3\x19\xd1\xaf\xe3\xea\x6d\x85\x61\xaa\xd0\xd1\xf5\x11\xb6\xbb\xae\xcd\x19\x4c\x13\x42\x41\x56\x17\x11\xdb\xde\x5a\x10\xa2\x70\xe1\x40\x10\x18\x36\xc5\x8a\x26\x04\xab\x94\xe3\xf4\xee\xf2\xfa\x6c\x01\xff\x1b\x29\xa0\x58\x10\x2b\x75\xeb\xf6\xbc\x25\x8c\x4c\x1c\x34\xec\xda\xad\x37\xd2\x0f\x67\x17\x6f\x5e\x9f\x7d\xb8\xbe\xfa\x78\x7a\x7d\x50\x33\x54\xc6\x1b\xcb\x3d\xe0\x8e\x56\x4d\x9e\xd7\x7c\xfb\x89\x68\x0e\x8f\x3e\xbb\x27\xb0\xec\x09\x26\x83\xc7\x29\xe0\xd3\x67\xd6\xdb\xc3\xf0\x2b\x43\xdd\x16\x7c\x1b\x1b\xb5\x8a\xa9\xc3\x70\xab\xc2\x80\xc7\xad\x63\xfc\x6d\x4d\x31\x5e\x11\xf1\xdf\x5c\xd2\x7f\x44\xe6\xae\x85\xee\x09\xc7\x65\x88\xf3\x80\x9f\x72\x4e\xc4\x98\xa7\xb2\xbb\x58\x65\xf8\xef\x07\xba\x93\x8b\x8b\x46\x98\x3b\xb9\xb8\x38\xbd\x7c\xdd\x08\x7d\xaf\xcf\x2e\xce\xde\x9e\x5c\x9f\xb5\xc7\x7e\xb8\x3e\xb9\x3e\x3f\xe5\xa7\xf5\xa8\x68\x15\x2c\x61\xaf\xe2\x8f\x5a\x8a\x2f\x83\x1d\x21\x29\x4e\x7a\x9c\x4a\x1c\xcc\xaf\xad\xd3\x4c\xc0\x6e\x14\x75\x21\xb4\x2f\x34\x12\x91\x45\x21\xd7\x1a\xae\x50\xed\x06\x77\x9e\x1b\xa5\x2d\x8d\xff\x28\xd0\xb0\x0b\x06\xe4\x48\x23\x20\xda\x08\xbd\x26\x77\x32\x0c\x7b\x30\x86\x22\xa7\xe6\x06\x85\x50\x12\x40\xd9\x4d\x55\x91\x38\xcd\x7d\xb7\x0f\xe3\x3c\x7b\x06\xd2\x54\x0f\xe2\x91\x55\xe3\xc7\xf4\xdb\xa3\xb3\xda\x6e\x73\x64\x61\x8f\x55\x9c\x58\x46\x4f\xdf\x01\xf8\x2b\xcc\x61\x01\x47\x3e\x7b\x3c\x92\x9e\x5e\xc0\x73\x50\x49\xf2\x27\x92\xd4\x0f\x3d\x94\xff\x9d\xa9\xaa\x13\x06\xfe\x3b\x53\x98\x2a\xec\x65\x92\x2c\xa0\xad\xe8\x1f\x3b\x8a\x2e\xc7\x5f\x60\xd6\x1d\xff\x53\x67\xbc\x4f\x77\xc1\x7e\xf7\x58\x63\xe9\xed\xc1\x14\xc9\x08\x98\x47\x8f\xd9\x38\x33\xe1\x96\xc7\x34\x8c\xf1\xf1\x8e\x7f\x6d\xf8\xb5\xb3\x42\xf2\xc4\x93\x38\x06\x63\x65\x8e\x59\x0c\x23\xc6\x94\x34\xeb\xbf\xc2\xd4\xae\x0c\x66\x06\xf0\x0a\xe6\xe3\x40\x76\x7d\xf9\xfa\x72\x41\x2d\x91\x98\x62\x1b\xf9\x2e\xa1\x15\xc8\xf0\xde\x7a\x9f\x27\xff\x35\x22\x71\x10\x34\xcc\xe0\x18\x45\x1b\x91\xad\xd1\x30\x2f\x5a\x7e\xc5\xde\xaf\xd3\xad\x82\xb8\x2e\x61\x25\xd7\xe7\x99\x1d\x95\x4f\x9e\xc3\x8b\x1f\xe6\x73\xbf\x5a\x76\xc8\x07\xc0\xd4\x20\xd4\x14\x59\x77\x63\xf8\xd2\xab\x97\xf9\x81\xf7\xe8\x6f\x8d\x39\x7a\x7b\x2d\xd4\x51\x69\x76\x53\xa8\xea\xb6\x5a\xe2\x2d\x35\x82\xbf\x37\xcc\x93\xda\x69\xea\x8e\x92\xd2\x14\x7e\x23\x94\x3e\x9b\x41\x86\xd4\xce\x51\xa1\xfd\x46\xab\xac\xb7\x9d\xca\x44\xe2\xa2\xa7\x46\xd8\x8a\x1d\x75\x9a\x92\x22\xbb\xd9\x01\x29\x2c\xde\x65\x62\x2b\x23\x52\xf7\x6c\xc6\x74\xa0\x71\x2d\x34\xb3\x2d\x83\x30\x07\x00\x11\xd9\x42\xa4\xe9\x0e\xd6\x92\x5a\xab\x44\x3d\x22\x6d\x87\xfd\x9b\xc0\xcb\x1f\x66\x2f\x7f\x04\x5d\xa4\x48\x9d\x8c\x0a\x98\x94\x4b\xf5\xfa\xa6\x17\xde\xa3\x5e\x63\x6e\x37\xa3\x31\xbc\xda\x83\x70\xc2\x0e\xd5\xa2\x4c\x73\xdc\xa7\x5e\x32\x38\x84\x23\x87\x60\x58\x8a\xca\x62\xfa\xa0\x50\xdd\xa0\xbc\x58\x1c\x1e\xba\x56\xf4\xa5\x6e\xe1\xa3\x1b\xa1\x45\x2a\x56\x38\x5e\xf0\xe1\x01\x71\x81\x3b\xe1\xbb\x9b\xb4\xa5\x90\xa7\x42\x66\x20\xa2\x48\x15\x99\xa5\x6d\x0b\x8d\xca\x74\x07\xb1\xca\xbe\xb7\x81\x1f\xf7\x81\x45\x14\x71\x73\xc6\x95\x3a\xbc\xe7\x24\x94\xd8\x12\x35\xc8\xcc\xc8\x18\x6b\x7b\x4a\x31\x59\x71\xd6\xf5\x23\xa8\x4d\x1e\x18\x6e\x95\xe1\xf6\x0f\xc2\x9d\xa6\x46\x89\x91\xd4\xc2\x90\xd4\xe2\xa3\xbd\x32\xa0\x32\x10\x90\x2a\xd7\x34\xa1\xc8\x0a\x42\xaf\xcd\xd4\xa5\xf2\xb5\xcf\xa8\x99\xba\x9b\x36\x61\x60\x65\xb5\x4b\xdf\xbf\xf0\xf6\xdd\x0f\x6a\xaf\xce\x7e\x3d\xbb\x2a\xe1\xec\x93\x77\x6e\x1a\x6a\xe4\x83\xb2\x61\xeb\xfb\x50\x18\x1f\x94\x79\x8b\xc2\xcc\xe8\x9f\x52\xad\x85\x89\x36\x7a\xec\x22\x0e\x2b\x48\x15\x96\x56\xc4\xbe\xc0\xcc\x09\x22\x48\xcb\x45\xa6\x90\x19\x7b\x83\xaf\xc3\x73\x61\x4c\xe8\x77\xd2\xd3\x90\x99\x20\xc6\x5b\x4c\x55\x8e\xba\xeb\xcb\xfb\xd6\x7a\xfd\xf1\xea\xdd\xc1\x7e\x1b\x5f\x3e\xc1\xc6\x5d\x56\xe9\x46\xf0\x79\x2d\x3f\x1c\xd7\x47\x5f\x60\xf6\x84\x2a\xb6\x8d\xe1\x7b\xe5\x70\xaa\xf7\xba\x5b\xee\x4b\xb3\x4e\xc2\x49\x90\xf4\xb9\x17\x62\x3c\xae\x40\x50\x57\x5b\x4f\xd4\x04\x49\xe0\xb5\x31\x9b\xc1\x7b\x95\x53\x66\xe4\xcd\x4a\x85\xb1\x95\xdd\xaf\xd1\x35\x67\xea\xd6\x41\xfd\x42\x33\x7c\x2c\x54\x4c\x73\x95\x7b\x7d\x90\xf2\xa8\x91\x5f\x65\x59\xe2\x3d\x25\xe8\xd2\xee\x21\xf4\xbd\x78\x11\x76\xd9\x87\xf5\xd2\x29\xc9\xfd\x05\xb8\x41\xb5\x20\xde\x30\x2c\xe1\xf0\x0e\xc7\x54\xaf\xec\x48\xc5\x14\xd1\x07\x03\x92\x89\xda\x60\x21\x20\x1d\x96\xaa\xe3\x88\x04\x87\x55\x2c\x3b\xcf\xe0\xb0\x1c\x48\xc0\xc4\xef\x40\x19\xcd\x3e\x3a\x5e\x3e\xcd\xfb\x4c\x49\x13\x34\x51\xbc\x23\x8a\x31\x45\x8b\x25\xbf\xf3\xec\x18\x5a\x8f\x68\x0a\x8f\x0c\x48\x7b\x1a\x6d\x9f\x95\x56\x31\xf7\x3b\x8d\x76\x8a\xff\x28\x44\x6a\x46\xf3\x12\x2f\x3b\xe9\xac\x22\x4c\x06\xcb\x4e\xa5\x47\x34\x75\xe1\xc2\x9a\x1c\x59\xcb\x34\x5d\xa5\x76\xaa\x62\x7c\x94\x83\x67\x51\x03\x02\xcc\xcc\x87\x98\xde\x84\x40\x0b\x54\xf9\x59\xb3\x51\x47\xc7\x42\xb5\x66\x9d\x5f\x66\x18\xd6\xd7\xb1\xf3\x43\xd8\x0a\xf7\xb6\x9c\xa7\x32\x8b\xf1\xfe\x32\x09\x9c\xc6\xf0\x0a\x0e\x83\x17\xb4\x4a\x8c\xe0\x60\x41\x21\x21\x4c\x7a\x52\x3f\xa6\x91\xac\xca\x1e\x85\x8b\x94\x2e\x50\xde\x61\x38\x80\xd4\x7c\x3a\x40\x12\x3a\x22\x91\xed\xb6\x4a\x63\xdf\x24\x07\x65\x69\x40\xad\xfb\x42\xe3\xc1\x31\xf4\xa4\x42\x53\xe8\x44\x44\x9c\xa8\x0c\x02\xf7\x2b\x0d\x18\xb5\xc5\x8d\xba\x1b\xf6\xac\xe8\x61\x7f\x96\xed\x7a\x56\xe9\x44\x2d\x94\x14\x6a\xc4\xc2\x88\x35\xd6\x3c\xab\x8b\x00\xfa\xf7\xa9\xe5\x77\x1d\xdf\x82\xe7\xe5\xaf\x70\xd8\x03\x12\xfe\x9c\xd3\x3d\xfc\xbf\x75\xbd\x52\x0f\xc1\x8f\xea\xaa\x28\x43\x5d\xed\x25\xad\xa2\xa4\xee\x75\x41\xaf\x89\x2b\xde\xd0\xd7\xc2\x8a\xd1\x78\xdc\xdc\xd7\xff\x59\x5e\x47\x73\xb7\x7b\x06\x21\xf2\xf8\xc8\x36\xe6\x1e\x42\x5d\xc0\x03\xea\xc0\xab\x84\xf0\x76\x4d\x9d\x2d\xe7\xaa\x1f\x7f\x92\x7f\x11\xd0\x21\xf7\x7a\xcf\x71\x83\x6b\x6e\x61\xe5\x2a\x0d\xae\xd9\xf0\x95\x36\x37\x3f\x7b\x4b\xfa\xff\xf6\xb8\x10\x22\x41\xc7\x2b\x1c\xd4\x68\xba\x85\x43\x1d\x15\xe6\xa0\x48\xe4\x63\x05\x6a\x58\xa3\x35\xb0\x22\xe0\x57\xeb\x1e\xb5\x0e\x71\x27\x84\x81\x2d\x63\x0c\xb8\xf3\x37\x4d\xc2\x19\x6d\x40\x05\x7b\x4f\xb8\x9e\x3d\xa3\xdb\x28\x71\xbf\x01\x52\xfa\xd0\xd5\x91\x57\xe9\xc7\x70\xe8\x41\x47\xa9\xc3\x75\xe3\x6c\x0c\xbe\x9f\xdf\x7f\x5f\x85\x8d\x92\x45\x5f\xec\xa8\xc8\xfd\xa9\x72\x9b\xba\x85\x1b\x1e\x63\xf6\x30\x7c\x52\x94\x2c\xdf\xb6\xa7\x0a\x2f\x7a\x59\xfb\xcd\x39\xcf\xe8\x9c\xb4\x8a\xe7\x5c\xf4\xd2\x6f\xb9\xc6\x5b\xa9\x0a\x43\x67\xd0\x4f\xee\x79\xfb\x01\xfc\xcf\x2b\x98\xc3\x5f\x79\x47\x0f\x8f\x60\xc1\x3f\x1c\x37\xf6\xaf\xe4\xc0\x7d\xf1\xb2\xc7\xdd\xb7\xc4\xc7\xc6\x7f\xad\x27\xee\x07\xb6\x1a\x04\xbe\xf6\xbf\xc2\x48\xe9\xb8\x7b\x50\xbf\xda\x35\xee\xaf\x38\x0b\x1d\x5d\x5c\xbe\x9d\x53\x01\x7e\x71\xf9\xf6\xc7\xf1\xb0\x6b\x8a\x7c\x36\xfa\xec\x19\x3c\x11\x56\xfb\xd5\x91\x66\x7d\xfb\x65\x09\x9d\x43\xbd\x52\x67\x7e\xc8\x2b\x3a\xdb\x13\x73\x17\xcc\xf8\xc9\x5f\xf8\xc9\x8f\x41\x57\xc4\xce\xc9\xbb\x84\x27\x97\x17\x55\x92\x62\xd2\x69\xaa\xd6\x7b\xb6\x62\x50\x1f\x10\x74\xef\x63\x04\x4d\x6d\x55\x2e\xa3\xfa\xab\xf2\xa8\x5d\xc2\x12\xe6\xc7\x20\xe1\x2f\x41\xf2\x43\x12\x9c\x1e\x3d\x7f\x5e\xb2\x77\xf4\x6e\xb7\x02\x0c\xfd\x4d\xe9\xb8\xdd\xf4\xa6\x96\xab\x1c\xef\xc1\x94\xa5\x34\xb1\xb0\xe2\xdf\x28\xdc\x68\xb8\x6b\xd7\x06\xc2\xe7\x5f\xaf\xe1\x2a\x85\xd4\x4f\x5d\x06\xbe\x9e\x58\xd4\x52\xf2\x63\xed\x55\xbf\xf0\x05\x78\x0d\xfa\xa7\x24\xc7\x02\xf6\x77\x57\xbd\x9c\x93\x20\x7a\x60\xe7\x4f\x3d\x68\x5f\x1e\xaa\xf3\x75\x0e\xef\xf5\x03\x76\xee\x14\x92\xa5\xbb\x2e\x52\xad\x72\x53\x09\xf5\xbe\xfc\x46\x51\xe6\xa3\x73\x76\xa6\x7f\xe4\xa0\xdd\x23\x43\xab\xf2\xad\x2a\x0b\xc3\x94\xea\xff\x5d\xd9\x28\x98\xb8\x16\x0b\x6c\x44\x16\xfb\xe6\xb6\x88\x63\x49\xfc\x38\x57\x91\x84\x62\x2d\x64\x56\x77\xb0\xa7\xd8\xb0\xcf\x7c\x7d\x31\xb2\xd3\xf3\xab\xd7\xb0\xfe\xe4\x83\x72\x3b\x4b\xec\x4f\xda\xbf\x52\xab\x36\x72\xad\x77\xf8\x12\x10\x79\x16\x5f\x43\x4d\x5f\x83\x4c\xff\x29\x5e\xaa\x81\xa5\x87\x61\x1b\x1f\x78\x0a\x7a\xcb\xb9\x9d\xee\x49\xa8\xcc\x14\x5b\x6e\x69\x82\x08\x2d\x79\xc2\x47\x0c\xdd\xa3\x14\x45\xc6\x8d\x2d\x0a\xab\x8a\xae\xa0\xfa\x35\x94\x19\xa8\x6f\x11\x7f\x26\x3d\xb5\x60\x7b\xf8\x75\x38\xe8\xc6\xdc\x7a\xfa\x0f\xeb\xde\x93\xc3\xe7\xdf\x57\x3e\x51\xc3\x5c\x9c\x06\x7c\xc1\xb2\x16\xed\xae\x6e\xd5\x82\x6a\xdc\xaa\x0b\x37\x3a\xbe\x69\xab\xf7\xdb\xf7\x7a\xf7\x6b\xdf\x5b\x24\x17\x4b\xdd\x1d\x78\x18\xd6\xb7\xd6\x9b\x4c\x0b\x33\x53\x0a\xaa\xd8\x3f\xbe\xf3\x65\xf3\xfe\x61\xd8\xc4\x90\x8f\x55\x56\x4f\x47\x9b\xce\x7c\xdf\xa4\xc2\x5a\x1f\xcf\x6a\xfe\xec\x30\x0d\xdd\xa1\xcb\x05\x65\xf3\xe1\xd3\xc0\x0c\x19\x5a\x00\x32\x6d\x7f\xdc\x73\xea\xde\x9f\x31\x1f\xa7\xf8\x1a\x7c\x39\x3c\xea\x07\x30\xdd\x98\x76\x51\xf6\xe2\xfc\xe2\xf9\x92\x66\x8a\xd4\xc3\xa6\x0b\x6b\x4e\x31\xe1\xf0\xba\x39\x55\x93\x79\x48\x17\x1a\x4d\x5f\xbe\x20\x9d\x12\x2b\x7f\x4e\xec\x6e\x4b\xaf\x90\xaf\x5e\xa2\x16\x84\x9f\x28\x3a\xf8\x4b\xc7\x14\x82\x0c\x5f\x1e\x24\x9a\x44\xd2\x75\x63\xcf\xd8\xdf\x00\x26\xcf\x91\xd9\x7a\x3a\x1c\xb8\xe7\xfb\x2e\xc5\x11\xbc\xf0\x94\xfe\x50\x73\x95\xaa\xe8\x86\xd2\x23\x5d\x33\xe3\x5f\x26\xc3\xfa\x51\x27\x3d\xa6\xe6\xe1\x64\xd8\x3d\xef\xa4\x77\xe4\xdb\x2e\x61\xb6\x4e\x37\xe9\x65\x38\xe1\x2c\x6f\x22\x78\x07\xa2\x77\xdd\xd3\xb9\xc9\xb0\x7e\xae\xd9\xf4\x35\xa2\xe8\x04\xba\x40\x40\x31\x6e\xd1\x4f\xf0\xb1\xd3\xa0\x98\x0c\xbb\x27\xae\xc4\x9d\xef\x11\x39\x71\x5d\x2b\x60\x51\x7f\xeb\x1e\xf9\x85\xca\x6d\x4d\x37\x72\x8b\xf4\xf4\xe1\x78\xf8\xa4\xc8\xea\xb4\xdf\x13\x5a\xbb\xb2\x53\xa7\x66\xdf\x2a\x82\xd1\xd2\x86\x72\xe0\x3c\xb5\xf7\xe1\x9c\x9a\x77\xf1\x67\x61\x36\x8b\x6a\x53\xe9\xd7\x49\xf9\xd2\x5d\x77\xab\xbd\x76\x0f\x78\x40\xed\x46\x74\xc5\xa3\xf5\xb0\x3d\xf0\xbd\x32\x8c\x3e\x3a\x83\xc3\x8b\x52\x43\x14\x9e\xc9\x01\x5a\x47\x2e\xae\x86\x0b\x89\x23\x8b\x21\x91\xda\x50\xdd\x8a\x5b\xf2\xba\xd2\xc9\x08\x78\x89\x0c\x90\x2e\xa5\x82\xe2\x0b\xaa\x8e\x69\xac\x95\xbb\x23\x5a\x11\xd2\x81\x39\x28\xcd\x9f\x95\xa8\x80\x95\x30\x5e\x53\x8c\x34\x18\x6e\xe5\x02\x5d\x7c\x1c\x8d\x21\x55\x2a\xa7\x70\x3f\x9b\x01\xde\x8b\x6d\x5e\x1f\xbb\xa8\xba\x73\x74\xe3\x14\xc8\x67\xe1\x60\x7e\xff\x72\xfe\x93\x78\x39\x9f\xcf\x7f\xfa\xe1\xe5\x7c\x7e\x44\x3f\xd1\xbf\xc9\x3c\x49\xe6\xf3\x83\x09\x18\x14\x3a\xda\xf0\x3c\x68\x2c\xa1\xcb\xba\x89\x54\x8b\x7f\xf6\xac\x3f\x84\xc2\x2b\x38\x2a\x5f\x36\xee\xe3\xb6\x43\xe8\xfc\x73\xe8\x85\xb5\x18\x99\x8d\x4c\xec\xa8\xb4\x96\x72\xf2\x3a\xa9\x0f\xa3\x7d\xc0\xc3\x1b\x6b\x88\xb3\x7b\x48\x1f\xe7\x4e\xa8\xfe\x31\xe6\xbe\x0c\xea\x27\x7c\x9c\xf5\x63\x68\x95\x05\x0f\x40\x6d\x0f\xe9\x71\x05\x63\x68\x02\xb2\xdd\x27\xb3\x2c\x07\xd7\x45\x6c\x8c\x69\x30\xa1\x7d\xec\xbe\xee\x3b\x04\xa4\x76\xa5\x1f\x58\x35\x2c\xb9\x5f\xe9\x05\xf1\xd9\xbb\x31\x26\x08\x11\x6e\x5b\xd3\x7a\x39\x4f\xc8\x7f\xa2\x9f\x76\x52\xc6\x89\x7a\x7e\x0a\x83\x20\x71\x99\xbf\xfa\x74\x01\xac\x46\xac\x9a\x19\xa9\x74\xd7\xd0\x7d\xd7\x8e\xbc\x01\x0d\xbb\x58\x4c\x47\x5f\xce\x61\xfd\xa5\xfb\x18\xf5\x94\x7b\x56\x8e\x85\x81\x3b\x91\x52\xa9\xe4\x72\x9b\xbc\xc5\x74\x17\xbe\x85\x01\xbc\xcf\x53\x19\x49\xeb\xee\xbe\x4e\x40\xe4\x74\x8a\x4b\x51\x91\xa7\x16\xcc\xd2\xc8\x6c\x9d\x86\x25\x3b\x51\x28\x33\x52\xeb\xb0\xb0\x34\xd6\xf5\xfa\x49\xa6\xf2\x10\x4a\x79\x14\x93\xee\x26\x74\x9a\xcc\xf7\x1c\xe9\x1e\xb4\xff\x64\x04\x73\x18\xd1\xb5\x72\x05\x47\xf3\x17\x3f\x8e\x6b\xd7\xd2\x8c\xaf\xae\x32\x6c\x7f\xb8\x24\x35\x98\x62\xc5\x8b\xca\xd1\xb7\x08\xa8\xa4\xf3\x3a\xac\x27\x5d\x6e\xe2\x97\x0a\x6f\xe5\xde\x0a\xb3\x50\xf8\x0e\xeb\x5d\xc2\x27\x12\xe2\x73\x59\x1a\xb1\x8e\x7d\x91\xeb\x29\x86\x83\x81\xff\xbe\xc2\x53\x55\xa1\x22\x20\x2c\x5f\x74\x85\xf7\xd5\xc9\x60\x0b\x8b\xf6\xd9\xb8\x9b\x37\x98\x62\xfd\xca\x87\x3f\xfd\x24\xfd\x7b\x18\xc5\x3e\xe5\xbe\x5f\x6a\x2d\x77\x02\x1d\xf1\xc3\x8d\xba\x50\x15\x9a\x50\x92\x94\x01\xa4\x14\x6f\x4f\xd3\x84\x22\xb0\xcc\xea\xd2\x50\x0d\x5a\x18\x6a\xe5\xd2\xd5\x1a\x99\xc6\x9a\xe0\x93\xf7\x24\xff\x91\x1a\x7d\xd8\xe1\xbf\x41\xca\x15\x7f\x5b\x13\x72\xc2\xb0\xd1\x57\x59\xb2\x1c\x5e\x99\x87\x47\xc7\x20\x5f\x2d\xa9\xab\x72\x78\x18\xe6\x67\xc9\x37\x32\x8d\xa9\x6d\xef\xa5\x37\x9f\xe4\x67\x7f\x7a\x31\x9b\xc1\x6b\x4c\x71\x2d\x2c\x12\x2b\xea\x86\x3a\x57\x62\x98\x03\x84\x92\x2a\xec\xec\xf6\x62\x54\xb2\xab\x0e\x61\x3b\x57\xea\x7a\xc6\x34\x6e\xf9\x50\x7a\xd8\xe5\xa8\x92\x4a\xb8\x70\xdd\x87\xf8\x95\x6a\xac\xda\xf3\x9d\x71\x50\xdd\x11\xaa\xf7\x7e\xaa\x71\x2d\x3b\xec\x6c\x2e\x5d\x23\x8d\x84\x1d\x7d\x92\x9f\xc7\xc7\x2d\xda\x9a\x2d\x35\x6c\xcb\x8d\x2b\x8d\x94\xa1\x73\x20\xaa\xfa\x2e\xe4\xaa\x57\x98\xa2\x30\xae\xcb\x11\xbc\x4f\x25\x8d\xb2\x90\x6f\x12\xb9\x18\x53\x0b\x90\x4d\xfb\xaa\x45\x47\x6f\xc6\xf5\x28\xc8\x66\x4c\xdf\xd8\xf1\xcd\x7c\x3e\x8f\x23\xde\x0e\x61\x40\x61\xfc\x47\x42\x1e\x7b\xc7\x68\xa4\xa6\xab\x15\x12\xd3\xd8\x43\x0c\xb2\xa5\x3f\x0c\xdd\x71\xa7\x78\x85\x5a\x52\x48\xe0\xbb\xb0\x14\x0a\x09\xff\x13\xd7\x4c\x46\x68\x77\x90\xa0\xe0\xaf\x29\xac\xe2\x5b\x1a\xfc\xdd\x94\xcc\xd6\xf4\x39\xe2\xce\xf1\xc3\xb8\x3a\xf8\x27\xdc\xaf\xc8\xa2\x34\x7d\xda\xa6\x7c\x78\xe2\xb3\x89\x9c\x0e\xab\xa5\x9d\xf8\x8b\x57\xd2\xe4\xa9\xd8\xb9\x4f\x85\x9c\x6b\xee\x8d\x49\x13\xa8\x6f\x61\x55\x1d\x10\x4c\x39\x1e\xfe\x27\x37\x07\x88\x43\x99\x73\x59\x9f\x57\xbc\x92\x50\x1b\xf9\x7d\x2d\xf2\x58\x58\x04\x91\x58\xdf\x12\x70\xa3\x28\x56\xf3\x96\x82\x48\x12\x8c\xac\x71\x5f\x7c\xd0\xee\x6b\xa5\x2c\x5b\x7b\x59\x19\xd3\x2f\x50\x89\xd6\xce\xe7\x0d\x29\xfb\x6e\xa1\x37\x98\x7c\xf8\x78\x7e\x7a\xfe\xfa\xec\xe0\xb8\xbd\x08\x53\xc8\x48\xc6\xad\x55\x94\x33\x75\xd7\x5c\xae\xe5\xdb\xae\xb8\x67\x47\xc2\x45\xc9\xee\x9e\x74\x03\xc9\xfe\x18\x52\x0b\xfd\xa5\x42\xe9\x4d\xe9\x86\xdc\xca\x20\xe3\x30\xca\xfb\xf2\x97\xaa\x46\x2c\xe9\xa6\x56\x5d\xa8\x3b\xd4\xa7\xc2\xa0\xbf\x1a\xea\x8a\x80\x05\x77\x56\xa7\xfe\x5b\xd8\x2a\x91\xf8\xe7\x1e\x9e\xd0\x73\x46\x46\x9e\x25\xff\x1c\x0a\x8d\xd2\x50\x17\x0d\xb3\xe5\xd7\x1c\x14\x08\x8d\x2c\x60\xbe\xbf\x30\x09\x76\xbf\xaf\x3a\xa9\x53\xb9\xba\xa7\x8f\x62\x4f\x19\x45\x2a\xe0\x3a\x8a\x36\xa2\xa4\x6b\x57\x56\xb5\xba\xac\x39\xa6\x2a\xa9\xb8\xb2\xe4\xe5\x97\x75\xe5\xb0\x3c\x01\x24\x40\x4c\x10\xcc\xf7\x26\x18\x7e\x50\x39\x14\x57\xf0\x84\x48\x11\x8d\xbf\x68\xee\x42\x6f\x4f\x5d\x1a\x4e\x59\xaa\x3d\xff\xae\xe6\x00\xde\x22\xdc\x66\x07\x7c\xde\x44\x06\xc1\xa0\xba\x87\x1c\xf0\x57\xf8\xf4\x19\xfc\x22\x2a\x08\xef\x85\xf0\x3c\xf7\x23\x10\xd7\x42\x25\xc1\xab\x2f\x9b\x79\x70\xd9\x81\xc2\x7b\xaa\xc3\x1a\xdf\x4d\xf2\x25\x44\xd4\xf4\xf7\x0b\x02\xc8\x70\xc7\xe3\xa1\x77\x6c\xd5\xfb\xea\xf7\x86\x10\x15\x34\xaa\x53\xf4\x62\xa3\x86\xec\xcb\xfa\x0c\x8d\x7c\xf5\xa6\xfd\xf5\x67\x8c\x79\xaa\x76\x90\x29\x57\x37\x5a\x45\x3f\x7a\x94\x3a\xe1\xcf\xfd\x8d\xe5\x3f\x03\x40\x5f\x4e\xee\x69\xd1\xb6\x3f\xfc\xa3\x6a\xa2\x2f\x08\x3f\x16\x9d\xc3\x3a\x7c\x7a\xf4\xcb\xf1\x65\x04\x89\x76\xbc\xff\xb5\x17\x97\x47\xd4\x22\xec\x57\x75\xd6\xc7\xac\x19\x56\x4a\x0c\x76\x83\x3b\x42\x6f\x4e\x2c\x4f\x4f\xfb\xe2\xe1\x8d\x7b\xfe\xe9\x06\x77\x9f\x7d\x1b\x95\x73\x73\x19\xbe\x4a\x3e\x19\xdf\xeb\xfd\x3f\x0d\x76\x4c\x16\x46\xd6\x0c\x91\x9f\x7f\xaa\x28\x3e\xf7\x63\xcf\xd6\x3a\x3a\x54\xc7\xcd\x53\xfe\xa6\x8e\x6a\x34\xb0\xfc\xaa\x8e\x3e\x95\xfc\x4a\x0d\x95\x71\x7f\x7f\x9d\xee\x69\x0f\xca\x50\x78\xf0\x39\xb8\x6c\x1d\xd8\xb6\x70\x90\xa3\x0a\x30\xa8\x9e\xaa\x5b\xa8\xc1\x49\xea\xa9\xbe\xd4\x03\xfb\x97\xe6\x67\x10\xfe\x7f\x9a\x73\x4a\x0f\x27\xe5\x23\xff\x3f\x7d\x51\x83\x99\x87\xdf\x55\x5b\xd0\xff\x57\x01\xd1\x36\xe1\x6c\x06\xbf\xd2\xf3\xf0\xed\x42\x7d\xb6\xb2\xe5\xdd\x99\x8d\x2e\x6b\xbc\x15\xfe\xc2\x3d\x7f\x2a\x5c\xfd\xc7\x44\xdc\xf3\xeb\x99\xeb\x3c\x93\xb6\x02\x70\xd5\x25\xc6\xe0\xd4\xbf\xf0\xf7\x4e\xfb\x73\x1f\x33\x71\xde\x0a\xd7\x3e\x7d\x3f\x34\x32\xdf\x97\xb0\x12\x6a\xed\x2d\x9a\xeb\xa0\x47\x41\x28\xb7\x02\x02\x7f\xb1\x2f\x83\xe2\x9a\xc6\x42\x07\xbf\xbe\x0a\x9a\x39\xc8\xeb\xdd\xb6\xce\xdf\xaa\x72\xa4\x1f\x7d\x62\x8c\x5c\x53\x8b\xc2\x8f\x0e\x92\x95\xfd\x49\xbf\xce\xfa\xa3\x49\x29\x59\xf5\x47\x03\x9c\x7d\x10\x52\xae\xfe\xac\x44\xc5\xcb\xdd\xe5\x58\x00\xb4\xaf\x77\x4c\xaa\x55\xda\x0d\x76\xfe\xa4\x40\x93\x57\xfd\x30\xb6\x42\x50\x7f\xde\x5c\xf7\x5a\x6a\xd3\x50\xcb\xd6\x76\xbf\x12\x03\xc5\x15\x46\x32\x97\xa1\xc6\xab\x59\xf7\x5e\xc3\xa6\xbb\x3d\xfe\xc3\x7b\x8c\xfb\x4d\x3c\xe8\x6b\xf2\xa8\x71\xfb\x76\xf6\x23\x76\xcd\x66\x4d\x77\xc7\x7d\x23\xd2\x21\x3e\xb2\xce\x05\xec\x37\x64\x0f\x3d\xe8\x55\xbd\xea\xfa\x76\x06\x5d\xf6\xda\x1f\x33\xe8\x4b\x1e\x04\xab\x9d\xc5\xff\x6f\xcc\xb3\x51\x35\xfc\x9b\x16\xda\xf4\xdb\x96\x99\x92\xcb\xd6\x3c\x55\x63\x52\x64\xb1\x7f\xb2\x68\x19\x28\xd7\xcf\xf4\xbe\xe1\xdc\xfe\xef\x3e\x2c\x7a\xe3\xee\x6c\x06\xfe\x13\xd1\xee\x26\x67\x05\x6f\xfd\xc3\x70\xf0\x30\x7c\x18\xfe\xdf\x01\x00\x37\xd8\x55\xd3\x6a\x4a\x00\x00")
 
 func call_tracer_parityJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -247,7 +247,7 @@ func prestate_tracerJs() (*asset, error) {
 	return a, nil
 }
 
-var _state_diff_tracerJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x3c\x6b\x73\x1b\x37\x92\x9f\xc9\x5f\x01\xab\xae\x64\x72\x97\xe6\x43\xa2\x24\x8a\x5a\xed\x96\x22\xcb\x89\xee\x6c\xcb\x25\xd1\xce\x65\x5d\xbe\x14\x66\xa6\x87\xc4\x6a\x08\xb0\x00\x8c\x28\x26\xf2\x7f\xbf\xea\x06\xe6\x45\x0e\x25\x79\x93\xdc\xd5\x3d\x3e\x59\xc4\x34\x1a\x8d\x46\xbf\xd1\x70\xaf\xc7\xce\xd5\x62\xa5\xc5\x74\x66\xd9\x5e\x7f\x70\xc4\x26\x33\x60\x53\xf5\x0a\xec\x0c\x34\xa4\x73\x76\x96\xda\x99\xd2\xa6\xd9\xeb\xb1\xc9\x4c\x18\x16\x8b\x04\x98\x30\x6c\xc1\xb5\x65\x2a\x66\x76\x0d\x3e\x11\x81\xe6\x7a\xd5\x6d\xf6\x7a\x6e\x4e\xed\x67\xc4\x10\x6b\x00\x66\x54\x6c\x97\x5c\xc3\x98\xad\x54\xca\x42\x2e\x99\x86\x48\x18\xab\x45\x90\x5a\x60\xc2\x32\x2e\xa3\x9e\xd2\x6c\xae\x22\x11\xaf\x10\xa5\xb0\x2c\x95\x11\x68\x5a\xda\x82\x9e\x9b\x8c\x8e\xef\xdf\x7f\x64\x6f\xc1\x18\xd0\xec\x7b\x90\xa0\x79\xc2\x3e\xa4\x41\x22\x42\xf6\x56\x84\x20\x0d\x30\x6e\xd8\x02\x47\xcc\x0c\x22\x16\x10\x3a\x9c\xf8\x06\x49\xb9\xf1\xa4\xb0\x37\x2a\x95\x11\xb7\x42\xc9\x0e\x03\x81\x94\xb3\x3b\xd0\x46\x28\xc9\xf6\xb3\xa5\x3c\xc2\x0e\x53\x1a\x91\xb4\xb8\xc5\x0d\x68\xa6\x16\x38\xaf\xcd\xb8\x5c\xb1\x84\xdb\x62\xea\x33\x18\x52\xec\x3b\x62\x42\xd2\x32\x33\xb5\x00\x66\x67\xdc\xe2\xae\x97\x22\x49\x58\x00\x2c\x35\x10\xa7\x49\x07\xb1\x05\xa9\x65\x3f\x5e\x4e\x7e\xb8\xfa\x38\x61\x67\xef\x7f\x62\x3f\x9e\x5d\x5f\x9f\xbd\x9f\xfc\x74\xc2\x96\xc2\xce\x54\x6a\x19\xdc\x81\x43\x25\xe6\x8b\x44\x40\xc4\x96\x5c\x6b\x2e\xed\x8a\xa9\x18\x31\xbc\xbb\xb8\x3e\xff\xe1\xec\xfd\xe4\xec\xbb\xcb\xb7\x97\x93\x9f\x98\xd2\xec\xcd\xe5\xe4\xfd\xc5\xcd\x0d\x7b\x73\x75\xcd\xce\xd8\x87\xb3\xeb\xc9\xe5\xf9\xc7\xb7\x67\xd7\xec\xc3\xc7\xeb\x0f\x57\x37\x17\x5d\x76\x03\x48\x15\xe0\xfc\xa7\x79\x1e\xd3\xe9\x69\x60\x11\x58\x2e\x12\x93\x71\xe2\x27\x95\x32\x33\x53\x69\x12\xb1\x19\xbf\x03\xa6\x21\x04\x71\x07\x11\xe3\x2c\x54\x8b\xd5\xb3\x0f\x15\x71\xf1\x44\xc9\x29\xed\x79\xab\x40\xb2\xcb\x98\x49\x65\x3b\xcc\x00\xb0\xbf\xcc\xac\x5d\x8c\x7b\xbd\xe5\x72\xd9\x9d\xca\xb4\xab\xf4\xb4\x97\x38\x74\xa6\xf7\xd7\x6e\x13\x71\x1a\xcb\x2d\xbc\x16\x71\x3c\xd1\x3c\x04\xcd\x54\x6a\x17\xa9\x35\xcc\xa4\x71\x2c\x42\x01\xd2\x32\x21\x63\xa5\xe7\x24\x2a\xcc\x2a\x16\x6a\xe0\x16\x18\x67\x89\x0a\x79\xc2\xe0\x1e\xc2\x94\xbe\x39\x56\x93\xbc\x6a\x2e\x0d\x0f\x69\x34\xd6\x6a\x8e\x9b\x4d\x8d\xc5\x3f\x8c\x81\x79\x90\x40\xc4\xa6\x20\xc1\x08\x33\x61\x41\xa2\xc2\xdb\x6e\xf3\xd7\x66\xa3\x4c\x0e\x8a\x0a\x6d\x32\x03\x23\xf9\x58\xc2\x4b\x0d\x2c\x48\x45\x12\x09\x39\xed\x36\x1b\x39\xfc\x98\xfd\xfa\xb5\xd3\x6c\x36\x66\xdc\x5c\x4a\x61\xcf\x79\x92\x40\x34\x66\x31\x4f\x0c\x74\x9a\x8d\x84\x1b\x7b\x16\x86\xc8\xe1\xe8\x2c\x0c\x55\x2a\xed\x98\xc9\x34\x49\xfc\xb7\x6b\x88\x53\x19\x8d\x59\xbf\x43\x28\x2e\xb4\x56\x3a\x9f\xdd\x6c\x44\x22\x8e\xdf\x71\x7d\x0b\xda\x8c\xd9\xaf\xcd\x46\xe3\x1d\xcc\x95\x5e\x8d\xd9\xce\xcf\x3b\x1d\xa4\xdb\xc2\x7c\xe1\x88\x47\xc1\x8d\xd8\x72\x86\x16\x44\xa7\x52\x0a\x39\xcd\x78\x12\x82\xee\x38\xf9\x96\x70\x07\x1a\xa5\x5c\x83\x4d\xb5\x84\x08\x19\x8b\x50\xa9\x01\xdd\x6c\x34\xbe\x53\x5a\x8e\xd9\xce\x9f\x77\x3a\xcd\x46\xe3\xb5\xc0\x9d\xec\xbc\xa2\x1f\xe7\x33\x2e\xa7\xf4\xfb\x4f\xf4\xfb\x86\xcf\x61\xcc\x76\x4e\xf1\x07\x31\x40\x98\xab\xe0\x1f\x10\xda\x8b\xf9\xc2\xae\xc6\x2c\x4e\x25\x9d\x43\x4b\x05\xff\x68\x13\xe9\x28\xa9\xad\x3b\xae\xd9\x3d\xea\x9f\x1b\xf6\x74\xb8\x0d\x9f\xb0\xaf\xcd\x46\xc3\x8f\x58\x9d\xc2\x89\x47\x6d\xd5\x0f\x70\xff\xaf\xa6\x84\xf4\x8e\x27\x0e\x69\x06\xbd\x5a\x80\x8a\xd9\x1d\x4f\xd8\x8b\xd3\x53\xb6\x83\xaa\x2e\xa7\x3b\xec\xe1\x01\xc7\xba\x42\x46\x70\x7f\x15\xb7\x76\xfa\xf7\x3b\x6d\x82\xe8\xb3\xbf\x31\xfc\xc5\xfe\x4c\x00\x56\xdd\xd0\x8c\xd6\xe0\xb0\xcd\xc6\x38\x94\xad\xcd\xdd\xa1\xe1\xd9\x96\xd6\xe7\x61\xd8\xa1\x45\x1d\x15\x22\x66\x2d\x3b\x13\xa6\x9b\x8b\xc5\x67\x1e\x86\x5f\xd8\xe9\xe9\x29\x59\xd3\x58\x48\x88\x1c\x68\x03\x39\x30\xa7\x53\x74\x07\xcb\x4e\x19\x4d\x2d\x1d\x75\xd7\x9d\xf2\x49\x13\xe1\x6b\xf1\x3a\x54\x8d\x9f\x91\x84\x31\x11\x82\x5b\xdd\xc0\xe3\x0f\xad\xc3\x0a\x49\x51\x9a\x4f\x01\xb5\xdf\x6f\xec\xa5\x61\x42\x0a\x2b\x78\x42\x68\x1c\x5a\x0d\x73\x75\x57\x88\x31\x4e\xf7\x43\x4e\xad\x88\x1c\x07\x0a\x65\x89\x45\x40\x1a\x28\xe4\x2b\x58\x91\x7c\x7d\x7a\xe7\xc0\x63\x21\x79\x52\x06\x37\x56\x2d\x58\xba\x40\x8f\x20\xa7\x5e\x92\x45\x99\x3a\x37\x34\xc7\x1d\x45\xe8\x61\x08\x03\x21\x0b\x78\xc2\x65\x08\x63\xcf\x8c\xc6\xe7\x32\x5b\xbf\xa0\x62\xd2\xf8\xd7\x0e\xfd\x23\xd5\xf3\x61\x43\x15\x3d\x17\xd4\x73\x34\x1b\xfe\x7a\xd2\x6c\x34\xbe\x7a\xd9\xe9\xf5\x58\xa2\xd4\x6d\xba\xf0\x9a\xcf\x84\x44\x0d\x71\x06\xc6\x2c\x20\x14\x31\xfa\x0c\x9e\x7f\xf5\xca\x58\x18\x23\x45\x2a\xd5\x6c\x54\xd0\x94\xe5\x30\x8a\x74\x87\x45\x41\x59\x18\x51\xc0\x78\x18\xa2\x5c\xa1\xde\x10\x4c\x1b\xc9\x22\xf1\xa8\x31\x48\xec\x14\xe1\x49\xda\x7a\x3d\x26\x15\x93\xe0\x0c\x43\x0c\x36\x9c\xb9\xc3\x01\xd3\x41\xe6\x23\x75\x39\xb9\xa6\xe6\x54\x7a\x3d\x34\x41\xe1\x8c\xcd\x81\x4b\xe3\x8c\xa7\x51\x73\x3c\x40\x99\xf2\x84\x85\x3c\x09\xd3\x84\x6c\xba\x71\x8e\x29\x00\x90\x6c\x01\x1a\x6d\x3d\x8a\x6a\x6a\x52\x9e\x24\x2b\xb4\x11\x1a\x4c\x9a\x58\xd3\x6a\x3b\xc4\xef\xaf\x26\x17\x63\x74\xd5\x4e\x12\xb9\x75\xf6\x4d\x2d\x50\x9c\xe3\xd4\x99\x7d\x34\x2d\x1a\xe3\x97\x25\xb0\x48\xc9\x97\x96\x69\xe0\x65\xb1\x8d\x02\xb6\x9c\x81\x44\x6f\x45\x1b\x85\xe8\x11\x05\x7e\x51\x56\x60\xb6\xbb\xcb\x6a\x80\xba\x4e\xa8\xbd\x7a\x3b\xc1\x77\x52\xe0\x0f\xc3\xcb\x29\x3b\x65\x51\xd0\x9d\x82\xfd\xce\xfd\x2e\x0e\x06\x81\x50\xe4\xf2\x23\x73\x70\xe7\x2a\xf2\x40\x39\x14\x09\x71\x8e\xe8\xbd\x2a\xa1\x69\x7e\xb3\x69\xa1\x6f\x25\xfb\x56\xb2\x6a\x39\x3a\xff\xf9\x35\xb7\x3c\xc3\x56\xdd\x7d\x26\x36\xc8\x41\xbf\xd1\x07\xa2\xf2\x01\x77\xd4\xee\x12\xe3\x85\x21\x76\xc7\x02\x9d\x63\x07\x8f\x4d\x12\xae\xcc\xd9\xc6\x42\x1b\xcb\xac\x98\xc3\xba\xc2\x18\x94\x98\xe4\xb1\x33\xea\xfa\x55\xab\x6a\xea\xd6\xad\xb3\xbf\xa5\x1d\x3d\x3a\x35\x3b\xb6\x93\xf5\x49\xb4\xbb\xfa\x29\xf4\x69\x63\x02\x32\xa2\x1e\x1e\xbf\xe4\xa2\xd2\xeb\x61\x30\x17\x82\x33\xe7\x21\x99\xee\x6c\xdb\x38\xf2\xa2\x66\x33\x8e\xf1\x5c\xe6\x4a\x39\xe3\xc6\x69\x14\x7a\x72\x0a\xd9\x84\x67\xb5\x4e\x25\x46\xfb\x8c\x27\x46\x31\x74\xed\x1d\x8f\x80\xbe\x3a\x75\xcd\xc2\xe0\x3c\x4c\x58\x60\x78\x6d\x6c\x11\x27\xe4\x96\x1f\xc9\x2a\x6f\x92\x5c\x11\x31\x7c\x43\xe4\x88\x14\xd4\x9d\xad\x10\x48\x8e\xdf\x51\x85\x73\x99\x23\x42\xd1\x73\xf1\x80\xe7\x55\xa3\x66\x69\xc2\x9f\x73\xb3\x24\x8f\xcf\x3e\x78\xab\xaa\xc7\xfe\xb5\x90\x6d\xe7\x85\x22\x05\x06\x4d\x0a\x19\xae\x92\x2d\xec\xb0\xb9\x32\x96\x2d\xb4\x0a\x78\x90\xac\x58\x00\x21\x4f\x0d\xb9\xd9\x8b\xcb\x0f\xaf\x06\x87\x03\x2f\xf4\x6e\x3f\x4c\xd8\xd2\xc1\xaa\x38\x53\x6b\x8c\x5c\x64\x3a\x0f\x40\xef\x20\xbf\xdc\xe8\x5f\xd8\x73\x84\xcf\xef\x31\x82\x04\x2c\xd4\xeb\x69\xd5\x38\x31\x48\x0c\x79\xda\x16\xa1\xac\xe1\x51\xdd\x52\xc4\xa1\x5c\xca\x73\x46\x93\xaa\x6f\x62\xa8\x11\x7c\x42\x50\x88\xfd\x9a\x9f\xbc\xf1\xe1\x49\xbd\x9f\xcc\x82\x17\x90\x56\xe7\x09\xcc\x54\x60\x0a\x56\x76\xa1\xcd\x86\xcf\x06\xb6\xb9\xd1\x9b\xcc\x65\xaf\xb9\xd1\x5b\x58\x75\x30\xf4\x43\x87\xda\xfe\xad\x9e\xb4\xce\xb8\x7e\xab\x59\xfd\x16\x7b\xee\xa0\x45\x74\x9f\x53\x7b\x0b\x2b\xb7\xe0\xba\xaa\x7a\x3e\x7e\x16\xd1\xfd\xf6\x10\x55\x63\x2c\x5d\xf5\x47\x37\x48\x61\xc1\x2b\xe7\x95\x1a\xdb\x31\x7b\x85\xde\x88\x9f\x5c\x58\xd5\xeb\xb9\xf0\x41\xb3\x5b\x80\x05\x06\x7f\xa4\x66\x5e\x00\xa2\x7b\x36\xe3\x11\x8b\x90\x41\xdc\xb2\x04\xb8\xb1\x0c\xe5\xce\x4d\xfe\x39\xcc\xd2\x91\x17\xad\xde\x7f\xb4\xfa\xf7\xed\xbf\xf5\xff\xf4\x2f\xbd\xae\x05\x63\x5b\x48\x7a\xbb\xed\xc2\xb3\x06\x2a\xc7\x98\x76\xe3\xa2\xb6\x52\x90\xe6\x59\x93\xe7\x12\xb4\x6f\x53\xde\x37\x7e\x7a\x74\x93\x75\xd2\x6d\x28\x7b\xc8\x6c\xe4\x8b\x67\x4e\xcd\x76\x84\x8a\x5f\xb3\x27\x43\x7b\xaa\xb3\x91\xcf\xc1\x59\x31\x9f\xb9\xf2\xff\x7e\x67\x5d\xc7\x06\xfd\x47\xb0\x41\xff\xae\x6c\x28\xcc\x8f\x2b\x34\xdc\x08\x39\x4d\x7c\x22\x8d\xe1\x2f\x0d\xfa\xca\x04\x15\x5a\xc8\x93\xa1\x13\x45\x87\xe5\xc5\xd7\x34\x1b\xe5\xd9\x25\xab\x82\xc2\x5b\x0e\xcc\x4b\x6e\xea\xb1\x6c\xed\x9f\x54\x7d\x77\x86\xb8\xe6\xe6\x69\x3c\x3c\xd4\x7d\x40\xd5\x38\x29\x22\x8f\xca\xa6\x3a\x18\x3f\x2f\xb9\xb4\xc8\x08\xcf\x01\xb4\xa9\x3b\x38\x69\x07\x17\x4b\x2b\xc1\xc9\x53\x6e\x7d\x2b\x75\x88\xaf\xe4\x6a\xb3\xa8\x10\xfd\x69\xb2\x22\xb2\xbc\x43\x36\xc4\x78\xf2\x3d\x48\xdd\x0c\x34\x20\x8d\x21\xcf\x1d\xb2\x84\x29\xb7\xe2\x0e\x1c\x75\xc6\xfb\x6e\xcb\x96\x45\x15\x8c\x42\xa3\x50\x49\x23\x22\xd0\xae\x10\x88\xbf\x40\x9a\x14\x23\xa4\x04\x51\xdb\x99\x4a\xa7\x33\x5a\x9a\x6a\x27\x3f\x63\x04\xea\x53\x1b\x60\x81\x06\x7e\x4b\xac\x48\xd4\x54\x84\x4c\x49\xf6\xe9\xdd\x4b\xc3\xce\xb9\x9c\x68\x2e\x4d\x0c\x9a\xed\xb2\xec\xcf\x2c\x10\x11\xd3\x4b\x69\xbb\xc2\x5c\x4a\x63\x29\xf6\x27\xa3\xb3\xbb\xeb\xaa\x13\xe6\xbd\xa7\xbc\xd5\xae\xb0\xcb\x4f\xfb\x05\xb4\xca\x2d\x96\x2f\x78\x10\xd4\x67\x64\xfd\x97\xb1\x63\xbc\x2f\x92\x10\xe6\x4e\x9d\x74\x7b\xf1\x7a\x44\xbc\x33\x08\x27\x03\x9d\x66\x91\xcd\xcd\xb8\x8c\x12\x70\x0e\xf9\x86\xcf\x7d\x3e\x4e\xfe\x16\xb4\xc4\x7c\x2d\xd3\x82\xbc\x4e\xf4\xdf\xa4\x06\x3e\xb2\xde\x2a\xee\x0e\x8a\x2c\x54\xad\xae\x9c\xfc\x1f\x13\x44\x17\x3c\xee\xee\x12\xe3\xea\x44\x31\xcb\x86\xaa\xb2\xd8\xd8\xc2\x60\x76\x4a\x88\x4e\x58\xaf\xe7\x04\x0d\xa9\x0f\x67\x10\xde\x5e\xc6\x28\x38\x95\xf0\x7c\x83\x18\xab\x88\x14\xab\xea\x08\x71\xd1\xf9\xd3\x64\x10\x9c\x55\xcf\x22\x81\x84\xa9\xf4\xad\x2c\xac\x95\x84\x7e\x53\xec\x10\x7c\x8b\x56\x6e\x91\xe8\x3c\xf6\x71\x31\x49\x45\x6b\xe9\x18\x5c\xc8\x62\xd5\xda\x37\xab\xdc\x97\x35\x9f\x55\x22\xdb\xfd\x8d\x01\x54\xa0\xec\x2c\x33\xd2\x28\xa6\x3b\x56\xed\x14\xe9\x8a\x41\xd8\x4c\x28\x4b\xf3\xff\x67\x6b\xab\x67\x7e\x0b\xc1\x2a\xf5\x9a\x87\x07\x64\xdb\x93\x42\x1f\xaa\xf9\x82\x6b\x27\x7d\x88\xa0\xdf\xf6\x33\xf3\x1a\x82\x55\xed\x93\x82\xf1\x33\x6e\x7c\xbc\xef\x36\x6f\x1c\xfb\x0d\xf2\xbf\x5c\x20\xc3\x5c\x9c\xcb\x95\x4f\xe5\x0d\xcd\xc5\x33\xc1\x71\xab\x30\xbd\xe6\x51\xe4\x6c\x71\x1e\xfc\xbf\x34\xde\x20\xd3\x75\x40\x75\x99\xb5\x53\x2a\xaa\x7d\x78\xac\xdb\x39\xee\x04\xb5\xe0\xd3\x0b\x9a\xdd\xcd\xab\x52\xa7\xa7\x25\x04\x24\x81\xbb\xbb\xc4\xed\x6e\x91\x96\x6e\x01\x70\x45\xab\xda\xef\x44\x46\xe5\x4e\xc0\x2d\xeb\xe3\xb4\x76\xce\x50\xe7\x36\xca\x7b\x0b\xd6\xee\x0b\x30\x05\xf3\x55\x8c\x22\x4b\x2a\x85\xec\x9e\xdf\x67\x51\xa4\xc1\x18\x52\x7e\xff\xb7\x4f\xba\x5c\xb6\xe1\xaa\x99\x09\xc7\x40\x32\xb3\xe5\x79\x81\xbd\x52\x76\x6a\x55\x31\x52\x3e\xe8\x42\xd9\x6f\x93\x70\x57\xd8\x73\x0a\xb2\x1d\xbe\xac\x41\xdf\x90\x1c\x36\xf2\xa2\x7c\x36\xc1\xb8\x2b\x3a\x2a\xc0\x1b\xa6\x74\x56\x9e\x8d\xa9\x1e\x3f\x57\x18\x6b\xb0\xd7\xca\xb9\x36\x67\x31\x30\x87\x65\x2d\xa9\xd0\x93\x33\x2e\x15\x5d\xc7\xce\xc1\xce\x54\xd4\xce\xea\x43\x34\xb8\x14\xa6\x10\xec\x39\x5d\x68\xd3\x2d\xd2\x2b\x27\xc2\x64\x62\xed\x7d\xf7\x33\xaa\xcc\x83\x55\x0f\xa1\x12\x32\xe0\x06\xbe\x38\x15\x2f\x15\xa2\xba\xae\xaa\x4b\x45\xdd\xcd\x2a\x92\xbb\x3d\x78\x78\x60\x75\x65\xa0\x2c\xee\x7f\xa2\xca\xd1\x08\x95\xb4\x42\x56\xea\x45\x99\xbd\x44\x1d\x15\x54\x57\xe4\x4c\xc2\x92\x05\x8a\x6e\x29\xfc\x6a\x1e\x72\xc6\x17\x0b\x90\x06\x5d\xea\x5c\xe9\x48\xe9\x97\x86\xd9\xfb\x31\xeb\xdf\x87\xc3\xc3\xd1\xd1\x41\x3f\x88\x8e\xa2\xa3\xfd\xf8\x60\x3f\x8e\xf7\xe3\x28\x3c\x1a\xee\xf5\x07\x7b\xc3\x83\xe3\x41\x3f\x1a\x0d\xa3\xd1\x30\x88\xc3\x83\x70\xd8\x8f\x8e\x8f\x60\x18\x1e\xf0\xd1\xf1\xde\x28\x3c\xde\x1b\x8c\x8e\x1e\xaf\x9c\x55\x44\xc5\xa5\xaf\xbb\xbb\xec\xb9\x05\xcb\x53\xd6\xaf\x9d\xb3\xb5\xfc\x78\x7a\x4a\x57\x60\xb5\x93\xb6\x17\x39\xc9\x3e\xd6\x94\xea\x32\x47\x51\x57\xfd\x2b\x1d\x04\xd9\x73\x07\xba\x31\xfd\xa4\x54\xc2\x7a\xfe\x47\x2f\x1c\x5b\xbf\x93\x48\x6d\xfd\x4a\xb5\xfb\x22\x4f\xad\x3f\x8b\xba\x0d\x17\x55\x7d\xda\x72\x25\xfa\x6d\xd5\x00\xe6\x95\xf5\x75\x44\x59\x4d\xff\x09\x34\x04\xb6\x15\x49\x76\x7d\xf0\x38\x0e\x84\x2a\xa3\x28\x17\x02\x9e\xde\x9c\x4b\x70\x7f\xeb\xde\x6a\xb0\x7c\xeb\xd6\x6a\x50\x6c\xec\x2c\x3f\xd1\x28\xe8\x02\xb9\x9f\xaa\x57\x68\xff\x36\x63\xa2\x16\x56\xcc\x85\xa1\x8b\xac\x31\x5b\x68\x78\x95\x9b\x97\x25\xb8\x80\xcb\xbb\x7d\x16\x40\xac\x34\xb0\x05\xd7\xc6\x5d\x71\xba\x12\x57\xa5\x92\xfe\x82\x96\xdf\xf0\xf8\xe5\x2d\xfe\x46\x82\x5d\x06\x57\xa9\xa1\x0a\x4a\x4d\x0a\x3f\x2b\x22\xba\x99\xaf\xa9\xaa\x64\x4b\xaf\x7b\x51\x0a\x25\x71\x8e\x87\x73\xf1\x6b\xe1\x4f\x7d\xa5\x75\xc3\x9f\x5a\xf5\xa3\xd2\x51\x4b\x44\xf7\xed\x8e\xeb\x86\x28\x5c\xac\x8b\x68\xac\x58\x33\x10\xe5\xfa\x8e\xdb\x27\xf2\xcd\x58\x51\x35\x52\xd5\x08\xd0\x99\xb6\xc6\xc3\x03\xdb\x00\xec\xae\x47\x8b\x05\xec\x8b\x4d\xe0\xac\x84\x94\x31\xe2\x91\x43\xa8\xa1\xb4\x7a\x24\xfe\x4c\x9e\x61\x6e\x5c\x71\x0e\x4c\xbd\x62\x1b\x2b\x2a\x3a\x43\x08\x09\xba\xae\x16\x83\x51\x60\x8e\xf7\x1b\xc9\xaf\x98\x89\x47\x2b\xbe\x1a\x4c\x36\xa7\xb9\x39\x93\xca\x09\x20\x5d\xa1\x09\x23\x61\x1f\x2d\xa0\x3b\xc6\x08\xb2\x7a\x49\xe2\x4a\xf8\xe5\x92\x3f\x39\xa0\x2c\x3c\x6e\x3c\xe5\xab\xfb\xe1\xc1\x71\x14\xc5\x23\x08\x02\xce\x0f\x87\x83\x61\x3f\x0a\x0e\xf7\x06\xc3\x20\xe0\xd1\xe1\x70\x10\xc7\xf1\x61\x10\xf4\x0f\x0f\x47\xc3\xa3\x28\x80\x78\x7f\xb8\xbf\x1f\x0d\xf7\x87\x41\x34\x88\x83\xc3\xbd\xa3\x7e\xc1\xd4\xc7\x2b\x5c\xf9\xb5\x70\x39\x8f\x2c\x0e\xe7\x8f\x67\xfa\xa6\x65\xdc\x10\x8d\xaf\xa5\xe2\xf7\x5a\xf0\x98\xca\xac\x3c\x9a\x85\x91\xff\x05\x46\xa9\x92\xc8\x0a\x19\x26\x69\x04\x57\x0b\xea\x7e\xca\x92\x29\x34\x4b\xfe\xee\x27\x64\x9f\xde\xb1\xab\x0f\x3e\xb2\x6d\x36\xaa\x13\x4a\xa9\x03\x68\x5d\x69\x0b\x02\x4d\x61\xd4\xee\x2e\xfe\x95\x77\x01\xbd\x44\x62\x34\x0f\x2d\xe6\x5f\x94\x32\x84\x2a\x49\x84\x11\x4a\xbe\x6c\xb3\xbf\xb2\x57\x83\x2c\x65\xd8\xdd\x65\xad\xca\x4c\x21\x4b\xfd\x69\x99\x93\xf4\x05\x1a\xaa\xb3\x78\x04\xe5\xcd\xb9\x70\x53\xc8\x3b\x85\xc1\xb8\xf7\x06\x98\x17\x7e\x7a\x57\x34\xb1\x75\x5d\x4a\x6f\xef\x7d\x76\xf8\x30\x37\xd3\x49\x11\x46\x33\x03\xd6\xdd\xde\x46\x91\x40\x78\x9e\x30\xdc\x05\xdc\x23\x19\x06\x22\x94\x7f\xe0\xe1\xcc\x55\x8a\x7c\x08\xdf\x45\x56\x55\xfa\x96\x42\x7b\x4f\xa6\xd6\xa5\xf6\xfe\x78\x8b\xbe\xb5\xbc\x42\xee\xaa\x4c\x53\x5c\x34\xb4\x29\x4f\x2a\x75\x5f\x77\x36\xeb\x91\x7e\x2e\x3e\xdd\x66\x6d\x4e\x85\xe9\x01\xce\xf1\xa6\x7e\x2b\x4c\x86\xaf\x70\x09\xbd\x1e\x23\x6e\x90\x99\x00\x57\xaa\xa4\x9e\x4b\x64\xad\xdb\x29\xf1\xc6\x31\xda\x65\xe0\x8e\x68\xab\x3c\xc9\xd9\x6d\xe1\xe4\xbe\xeb\x10\xe2\x4a\x56\x21\xd7\x70\xd8\xe5\x3d\x33\x42\x62\x18\x4f\x96\x7c\xe5\x96\xf2\xdd\x4b\x17\x9f\xde\xd1\x0a\xca\x75\x9a\x9d\x9e\x5f\x5f\x9c\x4d\x2e\xf2\xba\x9e\x5f\x55\xc2\x32\x59\xf9\x36\xc5\x88\xad\x0b\x59\x76\x55\x6a\xef\xbb\x6e\x37\x75\xf7\xf9\x5b\x78\x42\x13\x72\xc6\x95\x54\xc7\x58\x58\x94\xa5\x0b\x59\x03\x77\xa0\x57\x4c\x2d\x28\x6e\xa2\x06\x1c\xd7\x80\xe5\x85\x0d\x9d\x3e\x4e\x2b\x49\x45\xa2\xa6\x85\x54\xf4\x7a\xec\x9c\x2f\x6c\xea\x85\xd4\x67\x93\x62\x3e\x87\x48\x70\x0b\xc9\xca\x17\x1d\x5c\xa6\x76\xca\x12\x35\xed\x4e\xc1\x92\x2a\xb6\xf2\x46\x15\xe5\x95\x39\xff\x8e\x02\x56\x82\xa9\xd8\x17\x07\xea\x95\x4d\xe9\xb5\xae\x9b\x87\x87\x1c\x5b\x95\x65\x65\x9e\xe5\x58\x8a\x3b\x9e\xbc\xc8\xb7\x0d\xab\x2b\x51\x54\x6c\x49\xcb\xaf\xd4\x6e\x57\xea\x18\x75\x57\xbd\x88\x0e\x43\x97\x0a\x60\x61\xfa\xb6\xcd\xfb\xb2\xfd\xdc\x9f\x31\x39\x4b\x90\xfd\x2e\x19\xf3\xb7\xa8\x79\x6a\x4e\xe7\x3d\xe3\xa8\x10\xee\x84\x0a\xa9\xaa\xbd\xae\xc6\x1d\xac\xb7\x06\x34\xcb\x37\xdc\xae\x41\xb5\x38\x47\xf7\xbb\x95\x6b\x26\x3a\x74\xea\x16\xc1\x6c\xda\xb7\xef\x19\x24\x87\x16\xea\xa0\xf4\xa3\x8a\xac\x77\xb6\x35\x1b\x0d\xb3\x14\x18\x4a\xa2\xf4\x75\xd5\xa2\x68\xc1\xcc\xce\x35\x44\xa3\xb2\x73\xf1\xef\x93\xf3\xab\xd7\x17\xe7\x57\x1f\x7e\xda\x19\xb3\xca\xd8\xcd\xe5\xdf\x2f\xf2\xb1\xef\xce\xde\x9e\xbd\x3f\xbf\xd8\x19\xaf\xc7\xa0\x99\x1e\x15\xe1\x3f\x2e\x68\x2c\x0f\x6f\xbb\x0b\x80\xdb\x56\xbf\x5d\x69\xff\x6c\xe7\x7a\xd6\x68\x50\x8d\xfd\xa4\x20\xc6\xe9\xbd\x5f\x83\x4a\x35\x79\xcd\x09\x91\x66\x2a\x8f\x7c\xca\x16\xf3\x98\x6a\x09\x3a\xf7\xf0\x2d\x5e\x14\x9a\xaa\x7d\x5d\x60\x8c\x23\xa8\x53\x9f\x58\x3f\x4a\xe7\x9e\x27\xd4\xb5\x37\x87\xb7\x63\x66\x78\x62\x3b\xcc\x88\x5f\xa0\xc3\x54\x1c\x1b\xb0\x1d\x06\x32\x52\xcb\x39\xb8\xe2\x87\xd3\x5d\xfa\xe2\xf7\x54\x62\xd4\xa0\xdd\x25\x63\x7a\x15\xbb\xe2\x8d\x8b\xd4\xc5\x2f\xb0\x09\xba\x57\x07\x0a\x24\x47\x1e\xfb\x9f\x69\xe6\xd3\xbc\xd9\x6b\x6d\xe5\x6c\x67\x7d\xd5\xfd\xea\x49\xba\xef\x2e\x90\xef\x9a\x44\x84\xd0\x2a\x6d\xfa\x9f\xe2\xeb\xd9\xdb\xb7\xb9\xc4\xe1\x0f\x14\xc3\x7c\xe0\xf5\xc5\xdb\x8b\xef\xcf\x26\x17\x15\xa8\x9b\xc9\xd9\xe4\xf2\xdc\x0d\xd5\x88\xcd\x56\xa9\x1c\xac\x49\xa5\x4f\x8e\xaa\x0d\x9a\x3e\xa9\xe3\x72\x65\x67\xd4\x41\x21\x5d\x2a\xaa\xe6\x0b\x91\xb8\xec\xce\xdf\xe1\x21\x54\x62\x14\x9b\x41\xb2\x10\x72\x8a\x29\x18\x9b\x73\x21\x2d\xf7\x6f\x21\xea\xcc\x03\x5d\x2c\xe5\xb9\x8a\x30\x1f\x34\x78\xd4\x51\x21\x9d\x59\x84\x5a\xf0\x2a\x4b\x70\xea\x0a\xab\x95\x8a\x6a\x1d\x8b\x6f\xde\x5e\x9d\xbd\xde\xd4\xe2\x2c\x93\x7c\x44\x18\x7c\x52\xf9\xa4\x76\x97\xf2\xcd\x5a\x02\x6e\x26\x57\xd7\x35\x76\xe4\x77\xa4\xa0\x1e\x6e\xfd\xc4\x1f\xa3\xf1\xe2\xed\x9b\xd7\x17\x37\x93\xeb\x8f\xe7\x93\xed\x16\xef\x11\x4a\x6b\x05\x9f\xae\xeb\xd7\x16\x2c\xdf\x22\xf3\x34\xa9\x84\xb2\xcb\x99\x7f\xfc\xe2\xc3\xad\xf2\x8b\x0c\xf4\x3f\x3e\x04\x89\xb9\x48\x4c\xb3\x41\xd3\xb7\x05\x1d\xff\x1f\x50\xfc\x6f\x0b\x28\x4a\x82\xe3\x0a\xfe\x1b\x92\xc3\x93\xc4\x05\xdf\x24\x26\xe5\x6e\x70\x61\x41\x53\x0c\xad\x30\xb0\xc0\xc0\xdb\x45\x27\x26\x6f\xed\xa3\xaa\x6d\x86\xd8\xc7\xf6\x28\xe7\x42\x4e\x9b\x0d\x37\xbc\x2d\xeb\xf9\xc6\x26\xe9\xb5\x34\x28\xcf\x28\x9e\x48\x82\x7e\x5b\x0e\x64\xd5\xb7\x67\x49\xd9\x75\xaa\x87\xfa\x01\x8a\x4e\xc0\x6c\xd9\x76\x71\xa1\xba\x05\xca\x5d\x74\xfa\x86\x74\xb7\xc2\x3a\x48\x36\x5e\xac\x1a\x2a\x79\x07\xda\x9e\xdb\xfb\x7f\x83\x95\x99\xa8\xef\xe8\xbe\x95\x9d\xb2\xcf\x2f\xa7\xdc\xbc\x15\x73\x61\x5f\x76\x18\xfe\xed\xff\xf9\xa0\x45\x08\xfe\xef\x8f\x06\x22\xfc\x93\x98\xfa\x92\xaa\x05\x45\x41\xd2\xb7\x45\xd4\x60\x2f\x5d\xff\xdd\xc2\x8a\x7a\x4a\xeb\xc0\x3e\x0b\x57\x7f\x08\xed\xfd\xe7\x5b\x58\x7d\xc9\x3b\x08\x5a\xd9\x48\xbb\xd2\xaa\xaf\xb3\x68\x77\xd3\x94\xac\x47\xc4\x7f\xa5\x37\x44\xeb\xa3\x63\xd6\xcf\xf9\xe2\x77\x87\xc4\xd9\xfb\x6e\xc6\x8a\xae\x49\x03\x17\xf5\xf9\xd1\x76\x97\x47\x51\xf6\x03\x27\xb8\x01\x47\x4a\x7e\x1c\x38\x1d\x62\xbb\x15\x59\x36\xf7\xa4\xba\x95\x4f\x94\xfd\x9e\x66\xd3\xbb\xf3\x34\xb1\x62\x91\xac\xb2\xf5\xe8\x28\xf2\x45\x62\x00\x53\x9a\x81\x08\x9f\x9a\x91\x26\xc9\xf7\xdc\x9c\x2b\xb3\x41\xda\xb6\x89\x3e\x6d\x96\x2e\x40\xa2\x9a\x5e\x56\x49\x11\xa6\xe8\x9d\xc9\x7a\xa8\x8b\x37\x6c\x54\x1a\x89\x84\xe1\xf4\x8e\x0f\xc7\xcb\xbd\x2e\x54\x36\xea\x38\xe4\x68\x32\x28\xdd\xcf\x3e\xba\x2a\x61\x51\x7e\x71\x93\x29\xcb\xbd\xb1\x5c\xdb\x7c\x06\x35\x34\x2d\x34\x58\xd0\x85\x3f\xf3\x7b\x9d\x71\xf3\x46\xab\xf9\x4d\x5e\x01\xf2\x0f\x39\xde\x28\x4d\x4c\x3b\x93\x51\x95\x15\xdf\x30\xe1\xe1\xc1\xbf\x81\x7b\x7a\xad\x67\xaf\xb1\x89\x3b\xeb\x12\x36\xe7\x54\xa4\x98\xb8\x7b\x38\x52\x7c\x57\xe2\xcc\x53\x1c\x9c\x54\x33\xbe\xb7\x73\x52\xe0\xe0\x49\x82\x18\xae\xe4\x7b\x25\x2f\xee\x85\xb1\x42\x4e\x0b\x47\x50\x9d\x8d\xf1\x2f\x6a\x11\x8e\x92\xaa\x77\x85\xf9\x3b\x68\xd5\xa2\x36\x8c\x17\x51\xd0\x05\xc4\x60\x32\x2b\x44\xa3\xd5\x88\xd3\x7f\xf1\xf2\xc3\x2b\x8f\x3d\xe9\xe2\x7b\x87\x10\xef\x30\x03\xb6\xb7\x50\x46\xa0\x18\x75\x9a\xa5\x08\x38\xeb\x8b\x29\xf5\x67\x48\x65\x19\x48\xea\xb3\xca\x84\xd0\x2a\xb6\xe0\x2b\xf7\xb4\x37\x2b\x35\x4d\xb9\x61\xa1\x32\x36\x43\x47\x8f\x24\x94\xbb\x03\x4f\x25\xe3\x96\xbc\x19\x0a\x51\xfe\xc9\x55\x55\x2b\x8f\x26\x28\x46\x79\xc6\x81\xe1\xde\xbf\x41\x76\xd6\xd8\xfa\xc1\x6f\xbd\xb5\xd6\xc1\xf4\x6b\xb5\xed\x79\x2d\x42\xa8\xba\x8e\xfa\xa0\xa2\xe4\x62\x36\x1b\x13\xd6\x10\x6c\xf4\x28\x14\xb5\x24\xdf\x96\xe0\x6b\x6f\xee\x9d\x6e\xf6\x8e\x82\xd3\x23\x94\xa2\x43\xc6\x47\x53\x04\x5d\x54\xfe\xeb\xea\xfe\xbe\xea\x3f\x8a\xf7\x0e\xc3\x01\x0f\xe3\x10\xfa\x83\xa3\x11\xdf\x0b\xfa\xfb\x47\xd1\xe8\x20\x06\x00\x7e\x7c\x1c\x04\xc7\x87\x83\x20\x18\x1e\xc6\x07\xc3\x01\x8f\x46\x61\x7f\x10\x1e\x1e\x1e\x8e\x86\x07\x07\xc7\x07\x7b\x7b\x7b\x83\xa6\xef\xfb\x39\x0b\xc3\x22\x36\x2a\xd4\x07\x97\x76\xcf\xca\xdc\xb3\xb8\xd2\xc3\x32\xdc\xe2\x5c\x69\xa0\x4d\x2c\x81\xf1\x85\x7b\x12\xae\x52\xed\x5f\x21\xd3\x63\xa6\xd0\x54\x96\x70\x91\x4c\x4d\x90\xb9\x1e\xc1\xa9\xe7\x9c\x0e\x41\x6d\x9e\x4d\x65\x72\xbe\x8f\xc9\xd5\xeb\xab\x71\xa5\x2d\x02\xf3\xca\x6a\x6f\x52\xc0\xc3\xdb\x66\xfe\xd0\xe8\x51\xe9\x5d\x13\xcb\xe2\x85\xd2\x86\xb9\xcf\x14\xb0\xf2\x9c\xa6\xaa\x85\x9d\xe2\x3d\x37\xda\xef\xa5\x72\x70\x78\xe6\x19\x56\xda\xd4\x63\x2f\x79\x1e\x03\xc8\xda\xcb\x10\x53\x71\x57\x5c\x36\x8c\x59\x62\x4b\x67\x41\x98\x7e\xce\x9f\x61\x3d\xfa\x80\x29\x7b\xd9\x40\x15\xb1\xf5\x6a\xb0\x29\x95\x83\xa9\xd7\xaf\x40\xfc\x58\xef\xc4\xe6\x45\x9a\xef\x35\xa3\x35\xb2\x4a\x73\x66\xd0\xd6\x1f\x6e\x39\xf1\xac\x3e\x40\x2a\x09\xae\x7b\x22\x53\xba\x56\xf3\x54\xd5\x3c\xbe\xf2\x57\x48\x65\x62\x9e\xa1\xe2\x56\xe5\x47\xde\x12\xf4\xff\x04\x64\x2d\x20\xce\xab\xfc\xa9\xfd\x5c\xc5\x7f\x54\xf3\x8f\xe3\xe8\xf8\x20\x3a\x3e\xda\x3f\x1a\x86\x61\x70\x1c\x46\x3c\xdc\x1b\x1e\x87\x47\x43\x88\xa3\xf0\xe0\xe8\xb8\x7f\x14\x1e\xec\x07\x00\x61\xb0\x0f\x87\x10\x84\xd0\xdf\x0f\x86\x21\xdf\x1f\x04\xfd\x28\xde\x8b\x9b\xa5\xad\xaf\x29\x7e\xf9\xda\xec\xf7\xd0\x7e\xbf\xca\x73\x75\xbf\x14\x8a\x6f\x57\xfd\x0c\xa8\xd6\x00\x94\x31\x3c\xdb\x32\xaf\x27\x35\xbf\x83\x75\x0e\xe2\x90\x0f\x07\xd1\x68\xef\x68\x34\x08\xf8\x60\x34\x1a\x85\x83\x7e\x74\x7c\x18\xc1\x68\x18\xc7\x87\xa3\xa3\xe3\x63\xd8\xdf\x1b\x85\x87\x07\xa3\x60\x7f\x78\x7c\x38\x8c\xf6\x47\x7b\x10\xf0\xfe\xe0\x38\xd8\x3f\x3a\xd8\xa3\x58\xbe\xd8\xe8\x1f\x69\xa1\x2b\xcb\xd4\x9e\x54\xe9\xde\xb5\x55\xba\xab\xba\xce\x1a\x86\xa1\xf4\xbf\x4e\xf0\x24\x51\xa1\x7f\xf6\xdc\x2a\x9a\x18\x9b\xd5\xfe\xe2\xfc\xc3\x49\xb3\xf1\xb5\xf9\xb5\xf9\x9f\x01\x00\x00\xff\xff\x46\xc1\xb6\xdb\x41\x46\x00\x00")
+var _state_diff_tracerJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x7c\x6d\x73\x1b\x37\x92\xff\x6b\xf2\x53\xb4\x59\xff\xb2\xc9\x0d\x43\x91\x7a\x16\xb5\xda\x94\x22\xcb\x89\xf6\x2f\x5b\x2e\x8b\xc9\x5e\xd6\xe5\x73\x61\x66\x7a\x48\xac\x86\x00\x0b\xc0\x48\x62\x22\x7f\xf7\xab\xc6\xc3\x3c\x90\x43\x59\xda\x4d\xf6\xea\xea\xee\x95\xc5\x99\x46\xa3\xd1\xfd\x43\x77\xa3\xd1\xe3\xad\x2d\x38\x93\x8b\xa5\xe2\xd3\x99\x81\xed\xe1\xe8\x00\x26\x33\x84\xa9\xfc\x16\xcd\x0c\x15\xe6\x73\x38\xcd\xcd\x4c\x2a\xdd\xde\xda\x82\xc9\x8c\x6b\x48\x79\x86\xc0\x35\x2c\x98\x32\x20\x53\x30\x2b\xf4\x19\x8f\x14\x53\xcb\x41\x7b\x6b\xcb\x8d\x69\x7c\x4d\x1c\x52\x85\x08\x5a\xa6\xe6\x8e\x29\x1c\xc3\x52\xe6\x10\x33\x01\x0a\x13\xae\x8d\xe2\x51\x6e\x10\xb8\x01\x26\x92\x2d\xa9\x60\x2e\x13\x9e\x2e\x89\x25\x37\x90\x8b\x04\x95\x9d\xda\xa0\x9a\xeb\x20\xc7\x0f\xef\x7e\x82\x4b\xd4\x1a\x15\xfc\x80\x02\x15\xcb\xe0\x7d\x1e\x65\x3c\x86\x4b\x1e\xa3\xd0\x08\x4c\xc3\x82\x9e\xe8\x19\x26\x10\x59\x76\x34\xf0\x0d\x89\x72\xed\x45\x81\x37\x32\x17\x09\x33\x5c\x8a\x3e\x20\x27\xc9\xe1\x16\x95\xe6\x52\xc0\x4e\x98\xca\x33\xec\x83\x54\xc4\xa4\xcb\x0c\x2d\x40\x81\x5c\xd0\xb8\x1e\x30\xb1\x84\x8c\x99\x72\xe8\x13\x14\x52\xae\x3b\x01\x2e\xec\xf2\x66\x72\x81\x60\x66\xcc\x90\x26\xee\x78\x96\x41\x84\x90\x6b\x4c\xf3\xac\x4f\xdc\xa2\xdc\xc0\xdf\x2e\x26\x3f\x5e\xfd\x34\x81\xd3\x77\xbf\xc0\xdf\x4e\x3f\x7c\x38\x7d\x37\xf9\xe5\x18\xee\xb8\x99\xc9\xdc\x00\xde\xa2\x63\xc5\xe7\x8b\x8c\x63\x02\x77\x4c\x29\x26\xcc\x12\x64\x4a\x1c\xde\x9e\x7f\x38\xfb\xf1\xf4\xdd\xe4\xf4\xfb\x8b\xcb\x8b\xc9\x2f\x20\x15\xbc\xb9\x98\xbc\x3b\xbf\xbe\x86\x37\x57\x1f\xe0\x14\xde\x9f\x7e\x98\x5c\x9c\xfd\x74\x79\xfa\x01\xde\xff\xf4\xe1\xfd\xd5\xf5\xf9\x00\xae\x91\xa4\x42\x1a\xff\x75\x9d\xa7\xd6\x7a\x0a\x21\x41\xc3\x78\xa6\x83\x26\x7e\x91\x39\xe8\x99\xcc\xb3\x04\x66\xec\x16\x41\x61\x8c\xfc\x16\x13\x60\x10\xcb\xc5\xf2\xc9\x46\x25\x5e\x2c\x93\x62\x6a\xd7\xbc\x11\x90\x70\x91\x82\x90\xa6\x0f\x1a\x11\xfe\x3c\x33\x66\x31\xde\xda\xba\xbb\xbb\x1b\x4c\x45\x3e\x90\x6a\xba\x95\x39\x76\x7a\xeb\x2f\x83\x36\xf1\xd4\x86\x19\x7c\xcd\xd3\x74\xa2\x58\x8c\x0a\x64\x6e\x16\xb9\xd1\xa0\xf3\x34\xe5\x31\x47\x61\x80\x8b\x54\xaa\xb9\x85\x0a\x18\x09\xb1\x42\x66\x10\x18\x64\x32\x66\x19\xe0\x3d\xc6\xb9\x7d\xe7\x54\x4d\x92\x19\xc5\x84\x66\xb1\x7d\x9a\x2a\x39\xa7\xc5\xe6\xda\xd0\x1f\x5a\xe3\x3c\xca\x30\x81\x29\x0a\xd4\x5c\x4f\x20\xca\x64\x7c\x33\x68\xff\xd6\x6e\x55\xc5\xa1\xbd\x43\xac\x0a\x32\x8b\x8f\x3b\x7c\xa5\x10\xa2\x9c\x67\x09\x17\xd3\x41\xbb\x55\xd0\x8f\xe1\xb7\x2f\xfd\x76\xbb\x35\x63\xfa\x42\x70\x73\xc6\xb2\x0c\x93\x31\xa4\x2c\xd3\xd8\x6f\xb7\x32\xa6\xcd\x69\x1c\x93\x86\x93\xd3\x38\x96\xb9\x30\x63\x10\x79\x96\xf9\x77\x67\x76\x51\x2b\xaf\x80\x36\x0e\x53\x53\x2c\x7c\x00\xb1\x81\xb3\x0f\xe7\xa7\x93\xf3\x07\xf7\xcf\x76\xbf\x6a\x16\x6e\x34\x98\xe5\x02\x21\xc2\x54\x2a\xda\xd9\x8e\xfd\x07\x4c\x73\x91\x8c\x61\xd8\xb7\x12\x9e\x2b\x25\x55\x21\x5c\xbb\x95\xf0\x34\x7d\xcb\xd4\x0d\x2a\x3d\x86\xdf\xda\xad\xd6\x5b\x9c\x4b\xb5\x1c\x43\xe7\x73\xa7\x4f\x6a\x31\x38\x5f\x38\xdd\xd0\xbe\x48\xe0\x6e\x46\x0e\x4a\xe5\x42\x70\x31\x0d\x2a\x8f\x51\xf5\xdd\xf6\x11\x78\x8b\x8a\x36\x91\x42\x93\x2b\x81\x09\xd9\x8d\xa8\x72\x8d\xaa\xdd\x6a\x7d\x2f\x95\x18\x43\xe7\x9b\x4e\xbf\xdd\x6a\xbd\xe6\xa4\xa8\xce\xb7\xf6\xc7\xd9\x8c\x89\xa9\xfd\xfd\x27\xfb\xfb\x9a\xcd\x71\x0c\x9d\x13\xfa\x61\xf5\xcb\xf5\x55\xf4\x0f\x8c\xcd\xf9\x7c\x61\x96\x63\x48\x73\x61\xcd\xdc\x95\xd1\x3f\x7a\x56\x74\xda\x08\xdd\x5b\xa6\xe0\x9e\xb6\xb7\x7b\xec\xe5\x70\x0b\x3e\x86\x2f\xed\x56\xcb\x3f\x31\x2a\xc7\x63\xcf\xda\xc8\x1f\xf1\xfe\xaf\xba\xc2\xf4\x96\x65\x8e\x69\xa0\x5e\x2e\x50\xa6\x70\xcb\x32\x78\x71\x72\x02\x1d\xf2\x24\x62\xda\x81\x87\x07\x7a\x36\xe0\x22\xc1\xfb\xab\xb4\xdb\x19\xde\x77\x7a\x96\x62\x08\xdf\x01\xfd\x82\x6f\x2c\x81\x91\xd7\x76\x44\x77\xb4\xdf\x83\x31\x3d\x0a\x73\x33\x67\x78\x82\x4e\x65\x7e\x16\xc7\x7d\x3b\xa9\x93\x82\xa7\xd0\x35\x33\xae\x07\x05\xea\x3e\xb2\x38\xfe\x04\x27\x27\x27\xd6\x59\xa7\x5c\x60\xe2\x48\x5b\xa4\x81\xb9\xb5\xa2\x33\x2c\x9c\x80\x1d\x5a\x31\xf5\xc0\x59\xf9\xb8\xdd\x6e\xb5\x5a\x8d\x7c\x1d\xab\xd6\x67\x12\x61\x6c\x05\xa1\xa5\xae\xf1\xf1\x46\xeb\x43\x89\x14\xa9\xd8\x14\x09\xb5\x7e\x61\xaf\x34\x70\xc1\x0d\x67\x99\x65\xe3\xd8\x2a\x9c\xcb\xdb\x72\x97\xd0\x70\xff\xc8\xed\x5a\xbb\x4c\x47\x8a\x55\xc4\x12\xa1\x7d\x50\xe2\x2b\x5a\x5a\x14\xfe\xfc\xd6\x91\xa7\x5c\xb0\xac\x4a\xae\x8d\x5c\x40\xbe\xa0\x80\x23\xa6\x1e\xc9\xbc\x2a\x9d\x7b\x34\xa7\x15\x25\x14\xc0\x2c\x07\xcb\x2c\x62\x19\x13\x31\x8e\xbd\x32\x5a\x1f\xab\x6a\xfd\x44\xfb\xde\x92\x7d\x21\xb8\xb6\x5a\x42\x3e\x9d\x36\x96\xc9\x53\x49\xbd\x46\xc3\xe3\x2f\xc7\xed\x56\xeb\x8b\xc7\xce\xd6\x16\x64\x52\xde\xe4\x0b\xef\x3d\x80\x0b\xda\x21\xce\x7f\xe9\x05\xc6\x3c\xa5\x90\xe4\xd7\x0a\x5c\xf8\xcd\x58\x58\x1b\xa4\xdd\x52\xed\x56\x8d\x4d\x15\x87\x49\xa2\xfa\x90\x44\x55\x30\x12\xc0\x58\x1c\x13\xae\x68\xdf\x58\x9a\x1e\x89\x65\xe1\xd1\xe0\xef\xe0\x84\xd4\x6d\xd1\xb6\xb5\x05\x42\x82\x40\xe7\x18\x52\x34\xf1\xcc\x19\x07\x75\x9f\x94\x4f\x82\x17\xe2\xea\x06\xab\x6c\x6d\x91\x0b\x8a\x67\x30\x47\x26\x88\x9e\x19\xd0\x72\x4e\x06\x14\x39\xcb\x20\x66\x59\x9c\x67\x36\x64\x68\x17\xf7\x22\x44\x01\x0b\x54\x14\x4a\x30\xe9\x43\xae\x73\x96\x65\x4b\xf2\x11\x0a\x75\x9e\x19\xdd\xed\x39\xc6\xef\xae\x26\xe7\x63\xca\x04\x1c\x12\x99\xb1\xca\x22\xfc\xc8\x14\xd2\xdc\xba\x1b\x1a\x26\x15\xa5\x47\x77\x08\x89\x14\xaf\x0c\x28\x64\x55\xd8\x26\x11\xdc\xcd\x50\x50\x30\xb4\x0b\xc5\xe4\x91\x0d\xfc\xa2\xba\x81\xe1\xe5\x4b\x68\x20\x1a\x7c\xb6\x90\xf4\xdb\xdb\x01\x9f\xd4\xfd\xa5\xed\x8d\xe1\x71\x0a\x27\x90\x44\x83\x29\x9a\xef\xdd\xef\xd2\x30\x44\x44\x90\x2b\x4c\xe6\xe8\xce\x64\xe2\x89\x0a\x2a\x21\xab\x8c\xde\xc9\x0a\x1b\x4f\xf1\x0c\xd7\x62\xd7\xe2\xad\x49\xfe\xad\xe2\xd5\x0a\x76\xfe\xf5\x6b\x66\x18\x9c\x34\xad\x3e\xc0\x86\x5c\xa0\x5f\xe8\x83\x95\xf2\x81\x56\xd4\x1b\x58\xc5\x73\x6d\xd5\x9d\x72\x8a\xbd\x7d\x32\x1b\x25\x65\x5c\x87\x58\x9e\x72\xa5\x0d\x18\x3e\xc7\xd5\x0d\xa3\x09\x31\xd9\x63\x36\x1a\xf8\x59\xeb\xdb\xd4\xcd\xdb\xe4\x7f\x2b\x2b\x7a\x74\x68\x30\xdb\xf1\xea\x20\x21\x37\x0e\x11\xb2\x69\x00\x29\xa2\x99\x9e\xde\x14\x50\xd9\xda\x82\x54\xaa\x18\xad\x05\x20\xb6\xae\x3b\x2c\x9b\x9e\xbc\x68\x58\x0c\x9d\x07\x52\x60\xa2\xd8\x94\x33\xa6\xdd\x8e\xa2\x48\x6e\x53\x0f\xee\x55\xad\x72\x41\x87\x09\x60\x99\x96\x40\xa1\xbd\xef\x19\x58\x43\xb8\xed\xca\xcd\x4a\x9a\xb0\xa0\xec\x5d\x9b\x32\x4f\x28\x3c\x3f\xd9\xbb\xba\x48\x1b\x8a\x6c\xc0\x5b\x83\x9c\x15\x85\xf6\xce\x46\x8a\xd7\xbc\x58\x51\x4d\x73\x21\x10\x11\xf4\x5c\x3e\xe0\x75\x55\xa7\x72\x53\x5b\xfe\x85\x36\x2b\x78\xf4\x9c\xab\x43\xfc\x9b\x15\xab\x18\x59\x37\x7b\x30\x0b\x4f\x7d\x14\x4a\x24\x6a\x72\x29\xd6\x71\x55\x7c\x61\x1f\xe6\x52\x1b\x58\x28\x19\xb1\x28\x5b\x42\x84\x31\xcb\xb5\x0d\xb3\xe7\x17\xef\xbf\x1d\xed\x8f\x3c\xe8\xdd\x7a\x6c\xf6\x57\x18\x56\x52\x62\x6e\xb7\x35\x65\x2e\x22\x9f\x47\xa8\x3a\xe4\x6b\xdc\xd3\x3f\xc3\x53\xc0\xe7\xd7\x98\x60\x86\x06\x9b\xf7\x69\xdd\x39\x01\x66\x1a\x09\x3c\x5d\x21\x9b\x75\xd4\x34\x95\x91\x55\x94\x17\x8a\x26\x1c\x37\x70\x68\x00\xbe\x91\x35\xd8\xaf\xc4\xc9\x6b\x17\x4c\x37\xc4\xc9\x90\xbc\xa0\x30\xaa\x38\x1f\x4d\x39\x9d\xf0\xfc\xac\x36\x84\x5a\x7e\x8f\x85\x51\x3f\xcb\x5a\x18\xbd\xc1\x65\x9f\x52\x3f\x0a\xa8\xbd\x7f\x35\x92\x36\x39\xd7\xe7\xba\xd5\xe7\xf8\x73\x47\xcd\x93\xfb\x42\xda\x1b\x5c\xba\x09\x57\xb7\xaa\xd7\xe3\x47\x9e\xdc\x6f\x4e\x51\x15\xe5\xd2\x81\x95\x0b\x37\xd7\x24\x61\xa9\xab\x5e\x6f\xcd\xd3\xd5\x39\xfb\x0d\x5d\x47\x40\x91\x56\x6d\x6d\xb9\xf4\x41\xc1\x0d\xe2\x82\x4e\x2b\x76\x9b\x79\x00\x24\xf7\x30\x63\x09\x24\xa4\x20\x66\x20\x43\x3a\x5f\x11\xee\x2c\xcb\xd6\xe7\x38\x1c\x47\x5e\x74\xb7\xfe\xb3\x3b\xbc\xef\x7d\x37\xfc\xd3\xff\xdb\x1a\x18\xd4\xa6\x4b\xa2\xf7\x7a\xd6\xbd\xb5\x5a\xe4\x99\xc7\x40\x8f\xec\xef\x6a\x92\xe6\x55\x53\x9c\x25\xac\x06\x75\x75\xdd\xf4\xea\xd1\x45\x36\xa1\x9b\x38\x58\xbd\x5b\xee\x2f\x9e\x38\x34\xac\x88\x36\x7e\xc3\x9a\xb4\x5d\x93\x97\xb3\xf5\x5c\x9e\x35\xf7\x59\x6c\xfe\xdf\xcf\xd6\x2b\xf3\xda\x4d\xae\xfe\x08\x35\xa8\xdf\x55\x0d\x65\x9a\x4e\xc9\x27\x33\xd7\x5c\x4c\x33\x7f\x90\x36\xd2\x3f\xf4\x85\x0f\xfa\x05\x36\x92\x51\x10\xa5\x80\xe5\xe1\xab\xdb\xad\xea\xe8\x8a\x57\x21\xf0\x56\x13\xf3\x4a\x98\x7a\xec\xb4\xf6\x4f\x6e\x7d\x67\x43\x9a\x73\x65\xf9\x46\xd2\x6c\x0d\x2f\x68\x6b\x84\xf4\x8d\x56\x57\x5d\x54\x1f\xee\x10\xee\x98\x30\x74\x0e\xf0\x1a\x20\x9f\xda\xa1\x41\x1d\xf2\x92\x39\xfa\xfd\xf3\xa4\xb0\xbe\x51\x3a\xe2\x17\x76\x63\x48\x46\x28\x1f\x91\xda\x64\x4b\xab\x74\x1f\x90\xb5\xcd\x5e\x6c\x50\xd2\x7d\x4a\xe1\x15\x92\x8c\x31\x2b\x02\xb2\xc0\x29\x33\xfc\x16\x9d\x74\xda\xc7\x6e\x03\x77\x65\x91\xcd\xa6\x46\xb1\x14\x9a\x27\xa8\x5c\x9d\x91\x7e\xa1\xd0\x39\x65\x48\x19\x1d\x73\xa8\x70\x38\x9d\xd9\xa9\x6d\xed\xe4\x33\x65\xa0\xfe\x68\x83\x10\x29\x64\x37\x36\xf2\x64\x72\xca\x63\x90\x02\x7e\x7e\xfb\x4a\xc3\x19\x13\x13\x2a\x6e\xa5\xa8\xe0\x25\x84\x3f\xbd\x8a\x22\x3e\xbd\x10\x66\xc0\xf5\x85\xd0\x86\xb2\x8b\x2e\xe1\x98\x30\x4e\xc5\x07\xae\xdf\x79\xc9\xbb\x01\xda\x4e\x5d\x7e\xd8\xaf\xa8\x64\xe1\xb1\x7c\xc1\xc3\x2a\xf5\x23\xa9\xfe\xd3\xd8\x2a\x6d\xe0\x8b\x24\x96\x73\xbf\x09\xdd\x1e\x5e\x8f\xc0\x3b\x50\x38\x60\xf7\xed\x58\x77\x9a\x9b\x31\x91\x64\xe8\x92\x75\xaa\xfc\x38\x98\x50\x05\xd0\xa0\x12\x74\x5e\x6b\xb7\x6a\xb3\xfc\xb7\x6d\x03\x9f\x59\x6f\x84\x3b\xe9\x56\x11\xa8\x1b\x69\x8c\x3c\xfe\x5f\x06\x44\xd2\x97\x45\x22\xfd\xd1\x04\x45\xaf\xd0\x15\x2c\xb6\x36\x28\x18\x4e\xec\x51\xfb\x98\x4a\x3b\x16\x68\x24\x7d\x3c\xc3\xf8\xe6\x22\x25\xe0\x04\x18\x37\x0b\x63\xa4\x15\xc5\xc8\x26\x41\x8c\x7c\x9a\x18\x96\xce\xc8\x27\x89\x40\x56\x1e\x54\xde\x55\xc1\xea\xa7\x0d\x15\xc6\x55\xd8\xd1\x6a\x36\xec\xca\x0d\x88\x2e\x72\x1f\xd2\xd0\xca\xae\xa5\x47\x3e\x65\x31\x72\xe5\x9d\x91\xbd\x7e\x43\xcc\xaa\x88\xed\xfe\xa6\x6c\x3e\x92\x66\x16\x9c\x34\xc1\xb4\x63\x64\xa7\x3c\xae\x68\x36\x2f\x41\x59\x19\xff\x3f\x7b\xb7\x7a\xe5\x77\x8d\xac\x67\xb3\xf0\xf0\x40\x6a\xfb\x2a\xe8\x63\x39\x5f\x30\xe5\xd0\x47\xe9\xf0\xb0\xe7\x47\x16\x35\x04\x23\x7b\xc7\xa5\xe2\x67\x4c\xfb\x7c\xdf\x19\x56\x3b\xf5\x6b\xd2\x7f\xb5\x40\x46\x67\x71\xba\x08\x73\xe9\x87\xb6\x46\x63\x82\x1c\x80\xa6\x25\x45\x08\x2c\x49\x5c\xaa\x51\x24\xff\xaf\xb4\x77\xc8\xed\xd6\xda\x34\x2b\x56\x2a\xab\x7d\x64\xd6\xcd\x1a\x77\x40\x2d\xf5\xf4\xa2\x9b\x54\x8e\xc0\x76\x7d\x25\x03\x52\x58\xeb\xe5\x4b\xeb\x1b\x07\x42\x7e\x85\x80\x0e\x78\x1b\xde\x5b\xc3\xd7\xee\x04\xba\x49\x25\x4f\xeb\x15\x0a\x75\x61\xa3\xba\xb6\x88\xee\x1d\x17\xa3\xfd\xd1\xca\xbd\x01\x1d\xc5\xb8\x58\x39\x2d\xf9\x5d\x5a\x39\x54\x9d\x26\x89\x42\xad\x49\x15\xd2\xff\xed\x0f\x5f\xee\xd4\xe1\xaa\x9a\x74\x3b\xa9\x4d\xf0\xe9\x45\xa1\xbd\x56\x7e\xea\xd6\x39\xda\x73\xa1\xd5\xe4\x33\x91\x6e\xc9\x7d\x12\xba\x99\xbe\xba\x93\x9e\x71\x48\x6c\x15\xc5\xf9\x30\x40\xdb\xba\x8f\x2b\xc4\x6b\xba\xd1\xf4\x65\x5a\x52\xa4\xa5\x64\xd9\x00\x5e\x53\xa9\x99\x7b\xe8\x02\x9d\x65\xa1\x4b\x35\x3a\x4e\x55\x22\x49\xd7\xb3\x30\x47\x33\x93\x49\x2f\xd4\x89\xec\xc3\x3b\xae\x4b\x80\xcf\xed\xbd\xb9\xbd\x4d\xfa\xd6\x41\x99\xa6\x88\xcd\xfd\xe0\x23\x6d\x9d\x07\x23\x1f\x62\xc9\x45\xc4\x34\x7e\x72\x5b\xbd\x52\x90\x1a\xb8\xea\xae\x2d\xee\xae\x1d\x51\x3f\x5b\xe1\xc9\xe1\x34\x95\x83\xbc\xcd\xbf\x56\xed\x68\xc5\x52\x18\x2e\x8a\x8c\xdf\xaf\xa3\xf0\x95\xdc\x50\x80\x67\x20\xf0\x0e\x22\xa9\x44\x59\x8a\xf7\x94\x33\xb6\x58\xa0\xd0\x14\x5a\xe7\x52\x25\x52\xbd\xd2\x60\xee\xc7\x30\xbc\x8f\x77\xf7\x0f\x0f\xf6\x86\x51\x72\x90\x1c\xec\xa4\x7b\x3b\x69\xba\x93\x26\xf1\xc1\xee\xf6\x70\xb4\xbd\xbb\x77\x34\x1a\x26\x87\xbb\xc9\xe1\x6e\x94\xc6\x7b\xf1\xee\x30\x39\x3a\xc0\xdd\x78\x8f\x1d\x1e\x6d\x1f\xc6\x47\xdb\xa3\xc3\x83\xc6\x35\x17\x89\x74\x0d\x2a\x44\x6a\x77\x54\x95\xd6\xc3\xb6\xc1\x67\x02\x39\xb0\xc6\x31\x1b\xcb\x90\x54\x76\x1a\xde\x77\x1a\x07\x6d\xaa\x37\xd9\x69\x82\x1d\x1a\x8b\x71\xab\xf0\xa6\xb3\x53\xc5\x10\x04\x70\x4f\xba\x36\xfc\xb8\x52\xca\x7a\xfa\x4b\x0f\x8e\x8d\xef\x2d\xa4\x36\xbe\xb5\x35\xfc\xf2\xbc\xda\x6c\x8b\xa6\x05\x7b\x53\x84\x25\xd7\xb2\xe0\x6e\x03\xa1\x0f\xae\xc7\x6b\x8c\x84\x7c\x12\x1b\x21\x1f\x63\x12\xae\x11\x1e\xe7\x41\x54\x55\x16\xd5\x82\x40\xab\x41\xe6\x3a\x47\x77\xd0\xed\x36\xd0\x3d\x67\x6d\x0d\x5c\x9e\xbb\xb4\x06\x16\x6b\x2b\x0b\x2e\x12\xe9\x5a\x3a\x98\x5c\x03\x35\xba\x38\x10\xd1\x9d\x39\xf5\x88\xc8\x3c\xa6\xae\x18\xaa\x85\xa9\xb2\x6a\xab\xc9\x4d\xe2\xf2\x95\x42\xcf\x47\xf8\x96\x18\x72\x18\x20\xa4\x82\x84\x63\x32\x80\xef\xc3\xb5\x7e\x3f\xd0\x83\x14\xd9\x12\x14\x2e\xa4\x2a\x26\x71\x1d\x12\xc9\x20\xa0\x2c\x89\x06\x56\xae\x6e\x3d\x52\xd9\x24\xb8\xeb\x62\x20\xf9\xc0\xa2\xfc\xbf\x16\x33\x68\x53\x85\x44\xf5\x9f\x74\x87\xd4\xb2\x33\xe7\xda\x5e\xc9\x8d\x61\xa1\xf0\xdb\xc2\x41\xde\xa1\x4b\x1d\x7d\x02\x13\x7a\x17\x16\x4c\x69\x2e\xa6\x45\xb1\xae\x76\x27\xf0\xc2\x4e\xbf\x96\xbb\x54\x8d\xf4\x2f\x0a\xec\xce\xa2\xc5\xec\x54\x0d\xe6\x94\xcf\x56\x32\x05\x2a\x83\x72\x01\x0d\xf5\xa1\x30\xf5\x6a\x1e\x60\x93\x62\x3a\x85\x79\xba\x76\xab\x9e\x11\xf8\x9a\xf1\x5a\x46\x60\xe4\xdf\xa4\x4a\xba\x3c\xb9\xef\xf5\x7d\x6f\x48\x91\x24\xd8\x20\xae\x0d\x87\x93\x26\x49\x6c\xf5\xcb\xa1\x9c\xa0\xa0\x0d\xaf\xbb\xd9\x7a\x2e\x6b\xe9\x5a\xad\x87\x07\x58\x23\x1c\x18\xb9\x89\xf6\xc5\x3a\x71\x28\x86\x05\x45\x3c\x62\x84\x06\x49\xeb\x26\xf1\x36\x79\x82\xc3\xb4\xaa\x50\xa8\x9b\x5d\x93\x36\xbc\xb6\xeb\x2d\x43\x4b\xdd\x84\x79\xca\x67\x0b\xbe\xcf\x14\xbf\xe6\xe8\xea\xee\xa5\x4a\x4d\xe5\x4b\xd4\x61\x4c\x7b\x7d\xa4\x2d\x8c\xa0\xb0\xf7\x68\xb6\x2e\xe5\xf3\x1d\x4a\x28\x28\x99\xae\x5f\xf7\xd0\xa1\xa0\x7e\x79\x41\xe4\x45\xa2\xdf\xfa\x5a\xb6\x31\x8c\xf7\x8e\x92\x24\x3d\xc4\x28\x62\x6c\x7f\x77\xb4\x3b\x4c\xa2\xfd\xed\xd1\x6e\x14\xb1\x64\x7f\x77\x94\xa6\xe9\x7e\x14\x0d\xf7\xf7\x0f\x77\x0f\x92\x08\xd3\x9d\xdd\x9d\x9d\x64\x77\x67\x37\x4a\x46\x69\xb4\xbf\x7d\xe0\xd3\x81\x9a\x95\xd6\xb4\xfa\x9a\x57\x2e\xb8\x2b\x47\xc3\x8a\x71\xfe\x78\xa5\xaf\xfb\xf6\x35\x68\x78\x6b\xd4\x3c\x82\x57\x73\x2e\x3c\xf6\xc2\x76\xd3\xff\x06\xa7\xe4\xc5\xa0\xc6\x97\x39\x37\xc5\xcc\x16\x14\x7e\x75\xa0\x33\x69\xa8\xcd\x06\x18\x35\x01\xa2\x9a\x73\xc1\xb5\xe1\x71\x1f\xb4\x0b\x0e\xb6\x63\x21\x5c\x1a\x15\x93\x06\x85\x10\xd1\xff\xc7\xa5\x5e\xb9\xfd\xee\x1d\x3f\xf5\x70\x14\x2c\x2f\xd3\x95\xf7\x35\x93\x59\x5c\x74\xdc\x55\x59\x27\x68\xe3\x51\xfa\x47\xc5\xab\xd1\x86\x60\x5c\x2b\x60\x84\x81\xbe\x59\x48\xaf\x74\x3e\xba\x9b\x3d\x27\x4f\xd9\x44\x77\x83\x4b\x52\xa5\x46\xe5\xfb\x44\x89\x0b\xf9\x47\x0a\x65\xa4\x46\x1f\xb3\x6d\xcf\xa8\x86\xbf\x5e\x5f\xbd\x03\x8d\x8a\xb3\x8c\xff\x6a\x63\x1c\x6d\xd1\x9a\x15\xa8\x59\xd0\x4b\xd2\xd4\xb6\x46\xca\xf5\x66\x3a\x81\xdf\xbe\x84\x9a\x84\x95\xe3\x04\xdc\xf9\x76\x40\xbf\xec\x90\x01\x91\x76\xeb\xa6\xa1\x00\x30\x3c\x06\x0e\x7f\xa6\x6b\x33\x3d\xc8\x50\x4c\xcd\xec\x18\xf8\x37\xdf\x78\x35\xd3\x20\x4c\x3e\xd2\xdb\x8f\xfc\x13\xed\x04\x19\xfd\xa3\xf8\xe9\xaf\x4a\xc3\xf9\xdd\x11\x57\xea\x11\x69\xc6\xa6\xb6\x71\xd0\x56\x65\x75\xd9\x8f\xc8\xfc\xed\x64\x40\x25\x75\xf0\xcc\xd8\x2d\xc5\x6e\xba\x5c\x63\xc2\x9d\x11\xfb\xce\x4f\x71\x31\x6d\xfb\x42\xa5\x75\x4f\xc4\xc6\xb9\xa8\x01\x9c\xba\xe6\x4e\x52\x5f\x2c\xb3\x8c\x53\x93\xa5\xb3\x49\xa5\xf7\x80\x65\xd4\x6a\x13\xea\x1d\xd4\xfa\xd5\x6e\x35\xf4\x7d\x76\x71\x30\x1d\x00\xf3\xdd\x92\xdb\xa0\xd0\xf1\x16\x53\x0b\x00\x41\xb5\x4e\xe3\xbb\x88\x34\x66\xe9\xb7\x09\x6a\xa3\xf2\x38\x58\x19\x99\xca\x38\xaa\xd0\x23\x4c\xee\xb6\xca\xbf\x6f\xb7\x1e\xe5\x68\x2e\x5b\xd1\x86\x3a\x1a\xf0\x9e\x6b\xa3\x21\x17\x86\x67\x56\x22\x14\x09\xb5\x00\x13\x43\x6e\x7a\x74\xc1\x78\x8b\xbe\x5f\x29\xac\x27\x17\x3e\x29\xb4\x90\x22\xf0\x2d\x14\xde\x72\x99\x6b\xbf\x44\x97\x46\xde\xe0\x82\x8e\xcd\xda\x20\xa3\xcc\xae\xb0\x46\x15\x4c\x0b\xfb\xa4\x07\x2b\xb7\xca\x1b\xae\x91\x03\xc6\x7c\xba\x58\xa5\xac\xb7\xa3\x7e\xe5\x2e\x9a\xf2\x91\xe2\x06\xd8\xd2\x71\x11\x67\x79\x82\x57\x4e\x9e\x52\xae\x97\x2f\x8b\xc9\xa8\xcd\x84\x06\x56\x9e\xd1\x3d\xb6\xf5\x0e\xf4\x6f\xe5\xf1\xc6\xb6\x94\x26\x4f\x10\x0e\x86\xd5\xc1\xab\x4d\x09\xed\x0d\x43\x2d\x4c\xc3\xf5\x1d\x80\xbf\x3a\x2e\x0c\x65\xbb\xcc\xaa\x88\x2e\xf7\x86\xcb\xde\xea\x5a\xab\xb5\x17\x94\x6d\xbc\x6c\xa5\x7f\xb7\xa1\x1f\xcf\xe2\xd9\x7b\x24\xa6\xed\x75\xa0\xdd\x3c\x48\x3d\x1b\xb4\x25\x6a\x20\xa1\x15\x12\x4a\x63\xa6\x29\x9e\xe0\xea\x1e\x42\x3d\x68\xb7\x9a\x04\x6c\x68\xf0\x5b\x83\xce\x86\x86\x84\x95\x95\xfa\x3b\x78\x16\xc7\x63\xd2\x96\x2d\xe5\xf8\x9e\xd1\x75\x3d\xd7\x4d\x09\xdf\xc1\x66\x33\x8e\x4b\x3a\xe2\xf9\xe5\xb8\xdd\x5c\x3f\x2b\xdb\x13\x57\xf3\x0d\xd2\x5d\xb5\xa8\x5a\x47\x66\xa8\xa8\x52\x46\xef\x1b\x40\x62\xf8\xf9\x2d\x5c\xbd\xf7\x65\xad\x76\xab\x3e\xa0\xa2\x32\x54\x3e\xed\xf4\xfe\x12\x95\x2d\x4a\xbe\x7c\x49\x43\x8b\x56\xe0\x57\x85\xa7\x61\xbe\x5e\x68\x8d\x42\xdf\x2a\xbc\xea\xc1\x5f\xe0\xdb\x51\x4d\x38\x57\x2b\xe2\xe2\x56\x52\xc3\xa3\x3f\x08\x51\x71\xf7\xe7\xb7\x65\xa3\xfb\xc0\x12\xc7\xe6\xde\x97\x78\x1f\xe6\x7a\x3a\x29\x6b\x60\xa0\xd1\x7e\xcf\x41\x65\x5f\x4e\xf4\xd4\x0f\x29\x85\xc1\x7b\xaa\x43\x92\x9f\x96\x02\x90\xc5\x33\x72\x67\x31\xfa\xfa\xdb\x80\x96\x5a\x6b\x3e\x8e\xcd\x7d\x09\x89\x90\xd9\x94\xbd\xed\xc5\x35\xb7\x4b\x4b\xa8\x49\x9d\xc5\x86\x9a\x2f\xab\x97\xb7\x4e\xb7\xab\x65\xba\xb0\xa5\xf4\xa0\xd9\xa0\x54\xdb\xa3\x31\xfe\x94\xb3\x91\x26\xf0\x2b\x4f\x43\xb4\x67\x49\x1b\xa4\x46\xd2\x03\xcd\x4e\x7b\x82\xd6\xe6\x57\x6a\x75\xe3\x14\x4d\x6f\x82\xd0\x46\x7a\x91\x43\x62\x30\xb9\x27\xe9\x9c\xa6\xe9\xf0\x23\x5d\x20\xb0\xf5\x49\x7b\x3a\x24\x0e\x2c\xbb\x63\x4b\x37\x95\x6f\x41\x3e\xff\xf9\xad\x9d\x81\xc8\x97\x0b\x3c\x71\xbb\xbd\xb8\x9c\xf3\xb3\x0a\xbc\xcb\x96\xc1\x49\xc1\x2a\x48\xbc\x2f\x25\x35\x58\xdb\x6e\xf6\x7e\xeb\x3a\xb1\x03\x0a\xc5\x55\xf3\x20\x83\x8b\x2a\xba\x48\x35\xd4\xa7\xbf\x04\xb9\xa0\x7a\x86\x8b\x4a\xb4\xc4\x02\x6c\x74\xde\xd5\x06\x17\x15\x54\x64\x72\x5a\xa2\x82\x3e\x6c\x62\x0b\x93\x7b\x90\xfa\x52\x30\x9f\xcf\x31\xe1\xcc\x20\x5d\xd7\xda\x2c\x26\x38\xd6\x4c\x4e\xa9\xeb\xc3\x45\x85\xa2\xdb\xd4\x87\x87\xf2\x3d\x01\xac\x42\x53\x4b\xad\xed\x73\x0a\x1a\x5d\xc7\xb4\xee\x4c\x1e\x1e\x0a\x6e\x75\x95\x55\x75\x56\x70\x09\x08\xf6\xc9\x35\xcd\xb3\x89\xeb\x63\x61\xad\x57\xbb\x8c\x68\x8a\x91\x21\xd8\xd5\x08\x4b\x8f\xb7\x69\xdc\xa7\xcd\x76\x2f\xc2\x7f\x21\xc5\x5a\x90\x2b\xb6\x56\xf1\xfd\x47\xa9\x61\xf7\x3d\x48\xb7\xd8\x33\x54\x36\x22\x2c\xd8\x22\xb5\xef\x8e\xd7\x45\x62\xd7\x27\x57\x42\x09\xdb\x7a\xa0\x6a\xe9\x3b\x4e\xf7\x1c\x5d\x32\xad\x5c\x94\x5f\x38\x04\x8d\xdb\xa8\xd4\x39\xff\x8f\xc9\xd9\xd5\xeb\xf3\xb3\xab\xf7\xbf\x74\xc6\x50\x7b\x76\x7d\xf1\xf7\xf3\xe2\xd9\xf7\xa7\x97\xa7\xef\xce\xce\x3b\xe3\xd5\xc2\x88\xd7\x48\xa5\x82\x45\x13\x6a\xc3\xe2\x9b\xc1\x02\xf1\xa6\x3b\xec\x95\x73\x8f\xf6\x7b\xbd\x62\x07\xb4\x5a\xb6\x97\xe2\xb8\x14\xc6\xed\x48\x3f\x07\xc1\x33\xb8\x66\xa7\x9e\xb0\x19\x49\x4f\x61\xb2\xde\xf1\xaa\x40\xf5\x08\xd8\x35\xf2\xcc\x0f\xeb\x7a\x6e\xfd\xb5\xee\x69\xd4\xfa\x09\x72\x6d\x7b\xc1\xec\x8e\x65\xf1\xcd\x18\x34\xcb\xe8\x83\x2a\xfe\x2b\xf6\x41\xa6\xa9\x46\xd3\x07\x14\x89\xbc\x9b\xa3\x30\xc5\x22\xdc\x1b\xbf\x86\x8a\x62\x46\xbd\x81\x75\x6b\x57\xa9\xbb\x03\xb1\x5b\x4e\xf3\x5f\x71\x9d\x74\xbb\x89\x94\x32\xd8\x93\xc0\xfd\x1b\x2b\xc6\x93\x75\xb1\xdd\xdd\xa8\xd0\xfe\xea\xe4\x3b\x75\x03\xba\xf7\xae\xa8\x34\xd0\xf4\xd5\x58\xb7\xb2\xf6\xc7\xf5\x78\x7a\x79\x59\x20\xea\xec\xf4\xf2\x92\xa0\x57\x3c\x78\x7d\x7e\x79\xfe\xc3\xe9\xe4\xbc\x46\x75\x3d\x39\x9d\x5c\x9c\xb9\x47\xc5\xca\x59\xc3\x0d\xdf\x8a\xd0\xa3\x15\xd4\xf9\x8a\x5c\xfd\xfb\x06\x5f\x49\x64\x62\x49\xb7\x52\x53\xca\xd5\x6c\xfd\x53\xce\x17\x3c\x23\x17\x1b\x2a\x3d\xf4\x4d\x95\xed\x9b\x9e\x61\x46\xad\x8a\x7d\x1a\x3d\x67\x5c\x18\xe6\x4f\x21\x4d\xce\xc5\x36\x08\x15\x05\x32\xae\xdf\x2b\xa4\xab\x66\x9e\x61\x52\xc2\xce\x9f\xb3\x2b\xba\x0a\x55\xb5\x8a\x1d\x83\x01\xfd\xa8\x47\x54\x7c\x7d\x79\x75\xfa\xba\x33\x5e\x65\x10\xca\x97\x8f\x58\xdd\x57\x32\xbf\xba\x7b\x2b\x45\xce\x46\x01\xae\x27\x57\x1f\xce\xff\x50\x09\x9a\xe9\x56\x2d\xfe\x98\x8c\xe7\x97\x6f\x5e\x9f\x5f\x4f\x3e\xfc\x74\x36\xe9\x8c\x37\x29\xfb\x11\x49\x1b\x33\x5a\xaa\xa0\xad\x4e\x58\x89\xf2\x29\xcb\xb3\x5a\x12\x49\xae\xbd\x9a\xe8\x14\x69\x24\xe5\x39\x4c\x84\xe0\x9f\xd2\x47\xa3\xed\x96\x1d\xbe\x29\xdc\xff\x5f\x28\xff\xf7\x85\xf2\x8a\x49\xdd\x0d\xf6\x9a\x4d\x59\xe6\x4a\x0c\xce\x80\xd5\xcf\x9c\xb8\x41\x65\xf3\x4a\x49\x21\x9d\x92\x51\x5f\xfa\x2a\x0a\x25\xf6\x1a\xd2\x7f\xf8\x14\xf2\x5d\xda\x2b\xb6\x38\xe3\x1e\x6f\x3a\x09\x3c\xa7\x27\x61\xfd\x68\x60\xe4\xd3\x0e\x06\xff\xda\xb9\xc0\xc8\xe7\x9f\x1c\x42\x9f\x90\xa7\xfa\x11\xcb\x16\xf7\x30\x6d\x01\x73\x23\x37\x50\x19\x59\xf9\xd2\xca\xcd\xb0\x4a\x12\x9e\x97\xb3\xc6\x52\xdc\xa2\x32\x67\xe6\x9e\xca\x94\x13\xf9\xbd\x6d\x24\x82\x13\xf8\xf8\x6a\xca\xf4\x25\x9f\x73\xf3\xaa\x0f\xf4\xb7\xff\xe7\xbd\xe2\x31\xfa\xbf\x7f\xd2\x98\xd0\x9f\x56\xa9\xaf\x3e\xad\x54\x04\xb9\xd8\xc0\xdd\x03\xd0\x97\x18\xe1\x64\x03\xd9\x47\x6e\x39\xb6\x62\x73\x4f\x75\xc2\x4f\x45\x6b\x5c\x37\x3c\xf1\x60\xf5\x4b\x51\x21\xcf\x5c\xdf\xe4\xab\xb9\xe8\x5f\x60\x08\xdf\xad\x3d\x1d\xc3\xb0\xd0\x8b\x5f\x1d\x09\x67\xee\x07\x41\x15\x03\x9d\x47\x04\x55\x67\xc7\x29\xd3\xbd\x01\x4b\x92\xf0\x83\xd4\xe1\x1e\x38\x51\x0a\x73\xd0\x70\x4c\xcd\x46\x66\x61\x6c\x31\xbb\x1b\xff\x33\xa9\x15\x4e\xc2\xf0\xc1\x3c\xcf\x0c\x5f\x64\xcb\x30\x9f\x35\x45\x31\x49\x8a\xa8\x2b\x23\x48\x98\xaf\x8d\xc8\xb3\xec\x07\xa6\xcf\xa4\x5e\x13\x6d\xd3\x40\x7f\x94\xf4\x05\x1f\xc2\x65\x68\x2f\x22\x17\x51\x34\x85\x86\x8f\x83\xc2\xd6\x46\x65\xcb\x05\x09\xd7\xcc\x7e\xff\x4e\xcf\xab\x4d\x9c\xb6\x14\x42\x55\x16\xdf\x3e\x46\xef\x8b\x97\xae\x45\xa5\x2c\x49\xd0\x4b\x7f\xf2\xbb\x36\xf4\x7f\x54\x84\x11\xb6\x53\x77\xa1\xa8\xf0\x5d\x46\x1a\x7f\x8e\x7e\x4b\x9e\xaf\x5a\x43\xd5\xc5\xa7\x93\x74\x7b\x1d\x6e\xb5\xb9\x02\x8d\xf6\x7a\x3c\xb6\x1d\xd5\xfe\x88\xbd\x60\xcb\x70\x9a\x9f\x7b\xe5\xcd\x98\x7e\xa3\xe4\xfc\xba\xf8\xaf\x02\xfc\x27\x8f\x6f\xa4\xb2\x56\x38\x15\x49\x5d\xb7\xcf\x18\x40\x7e\xde\x7e\x3e\x7c\xfc\xf5\xc9\x9e\x3c\x49\x03\x73\xcf\x9d\x6b\x97\x44\x4f\x7c\xf5\x92\x7c\x89\xfd\xf3\xa4\x38\xaf\x50\xec\x6a\x78\xbe\xdd\x09\x12\x72\x4d\x07\x67\xe2\x70\x25\xde\x49\x71\x4e\xb5\x68\x2e\xa6\xde\x55\xad\x71\xa5\x64\x97\x36\x26\xf1\xb4\xde\x63\xc0\xf5\xdf\x51\xc9\xae\xad\xd3\xbe\xa0\xa6\x01\xe2\xa0\x83\x63\xb3\x4f\xeb\xe9\xa5\x7f\xe3\x21\xc9\xaa\xb6\x75\x65\xfb\x8e\x65\xdc\xa1\xfa\xc8\xd6\x42\x6a\x4e\xc8\xf4\x18\x73\xe9\x6e\xe8\x21\xf5\x32\x12\x42\xa9\x3d\x0c\x85\x6d\x8e\x0f\xb8\x36\xd2\x9a\xdf\xfe\x2f\x1b\xa1\xa2\x33\x65\x54\x4b\xd3\x26\xb0\xa3\xf2\x3b\x0d\xa5\x3e\x31\xfa\x06\xd1\xd8\x00\x49\xb8\x2c\x5e\xb9\x72\x7a\x79\x84\x0d\x09\xc9\x13\x2c\x46\x6b\x7f\x06\x7a\x56\xd4\xfa\xde\x2f\xbd\x68\x32\xf6\x35\xc3\xdf\xea\x9f\x08\xad\xe4\x12\xf5\x68\xd4\x9c\x41\x54\xa2\x16\x9c\xac\x26\x23\x2b\x0c\xd6\xfa\xf8\xca\x92\x8d\x6f\xdd\xf3\x25\x2e\x1a\x17\x4c\x62\xbf\xc7\xe6\xa6\xd2\x4d\xea\x53\x27\x5b\x46\x2c\xef\x96\x9b\x6e\x96\xfd\xbd\xf2\x61\xba\xbd\x1f\x8f\x58\x9c\xc6\x38\x1c\x1d\x1c\xb2\xed\x68\xb8\x73\x90\x1c\xee\xa5\x88\xc8\x8e\x8e\xa2\xe8\x68\x7f\x14\x45\xbb\xfb\xe9\xde\xee\x88\x25\x87\xf1\x70\x14\xef\xd3\x2d\xf3\xde\xde\xd1\xde\xf6\xf6\xf6\x28\xf4\xc8\x9e\xc6\x71\x59\xa3\x2f\xb7\x0f\x79\x43\xe7\x47\xdc\x27\xe4\x95\x8f\xb0\xa9\x2a\x35\x97\x0a\xed\x22\xee\x10\xd8\xc2\xfd\xef\x2c\xf4\x7f\xc8\xb8\xff\x10\xc4\x7e\xf8\x1b\xeb\xda\x14\x2e\x39\x6a\xc8\x28\x57\xd4\x6b\xe4\x53\xac\x63\x64\xa3\x6d\x6a\x83\xcb\x86\xb0\xaa\x1f\xf0\x4c\xdc\xd4\x44\x3e\xf8\xbc\xb9\x45\xa7\xfa\x6d\x6b\x2b\xdc\x5c\x50\x35\x67\xb5\xc6\xa8\x2b\x45\x46\x4a\x41\x2b\x8c\x1f\x6b\xa7\x5b\xef\x4c\xf0\x6d\xc8\x76\x0e\xcf\xbb\xd8\xbf\xab\xdf\xf4\x3a\x6b\xd4\xbf\x4d\xad\xd8\x89\x34\x52\xeb\x53\xf0\x52\xf9\x5e\xbb\x8a\x2d\xc2\x9d\x7c\x55\x98\x27\x20\xda\xc8\xc2\xc5\x74\x79\x6a\x5d\x8c\x5f\xb1\x73\xae\x7f\xea\x3d\x15\xe7\x8f\x02\xfd\x28\x4d\x8e\xf6\x92\xa3\x83\x9d\x83\xdd\x38\x8e\x8e\xe2\x84\xc5\xdb\xbb\x47\xf1\xc1\x2e\xa6\x49\xbc\x77\x70\x34\x3c\x88\xf7\x76\x22\xc4\x38\xda\xc1\x7d\x8c\x62\x1c\xee\x44\xbb\x31\xdb\x19\x45\xc3\x24\xdd\x4e\xdb\x95\xa5\xaf\xe0\xbc\xda\x87\xf0\x7b\x80\xdd\xc8\x67\x41\xbd\x92\xcc\x6e\x46\x7a\x20\x6a\xc4\x7b\x95\xc3\x93\x1d\x51\x18\xf4\x3b\x3a\xa3\x28\x8d\xd9\xee\x28\x39\xdc\x3e\x38\x1c\x45\x6c\x74\x78\x78\x18\x8f\x86\xc9\xd1\x7e\x82\x87\xbb\x69\xba\x7f\x78\x70\x74\x84\x3b\xdb\x87\xf1\xfe\xde\x61\xb4\xb3\x7b\xb4\xbf\x9b\xec\x1c\x6e\x63\xc4\x86\xa3\xa3\x68\xe7\x60\x6f\x9b\xd8\x07\xb1\xfe\x60\x87\x54\x9b\xa6\xd1\x52\xf4\xe9\x89\xc8\xa8\x46\x65\x64\x96\x94\xad\xd3\xc4\x5e\xe7\x73\x2c\xda\x0d\x1f\x69\x4b\xec\xfa\x26\xc4\x5e\x38\x2c\xb9\xa6\x47\xdb\x1f\x4f\xf1\xdd\xf7\x07\x16\x29\x4b\x91\x81\x7e\x08\x5f\xac\x60\xe5\x7f\x55\x62\x59\x26\x63\xff\xff\x6e\x74\x0b\xeb\xf7\xca\x6b\xb2\x3a\x2c\x8e\xdb\xad\x2f\xed\x2f\xed\xff\x1a\x00\xd6\x13\x6b\xdf\x21\x4d\x00\x00")
 
 func state_diff_tracerJsBytes() ([]byte, error) {
 	return bindataRead(
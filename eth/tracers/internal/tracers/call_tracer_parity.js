@@ -28,22 +28,21 @@
 	// an inner call.
 	descended: false,
 
-	parityErrorMapping: {
-		"contract creation code storage out of gas": "Out of gas",
-		"out of gas": "Out of gas",
-		"gas uint64 overflow": "Out of gas",
-		"max code size exceeded": "Out of gas",
-		"invalid jump destination": "Bad jump destination",
-		"execution reverted": "Reverted",
-		"return data out of bounds": "Out of bounds",
-		"stack limit reached 1024 (1023)": "Out of stack",
-		"precompiled failed": "Built-in failed",
-	},
+	// includePrecompiles reports the calls to precompiled contracts too, which are
+	// skipped by default like OpenEthereum does.
+	includePrecompiles: false,
 
-	parityErrorMappingStartingWith: {
-		"invalid opcode:": "Bad instruction",
-		"stack underflow": "Stack underflow",
-	},
+	// includeGasRemaining reports the gas each frame had left when it returned,
+	// along with the gas its caller retained meanwhile (EIP-150's 1/64).
+	includeGasRemaining: false,
+
+	// includeLogs reports the logs emitted directly by each frame, unless the frame
+	// or one of its callers failed, reverting them.
+	includeLogs: false,
+
+	// includeFailedCreateGas keeps the gas burnt by failed creations in their
+	// result, which OpenEthereum drops along with the rest of it.
+	includeFailedCreateGas: false,
 
 	paritySkipTracesForErrors: [
 		"insufficient balance for transfer"
@@ -54,6 +53,14 @@
 		return true;
 	},
 
+	// init is invoked before the EVM starts, picking up the tracer options.
+	init: function(ctx, db) {
+		this.includePrecompiles = ctx.includePrecompiles === true;
+		this.includeGasRemaining = ctx.includeGasRemaining === true;
+		this.includeLogs = ctx.includeLogs === true;
+		this.includeFailedCreateGas = ctx.includeFailedCreateGas === true;
+	},
+
 	// step is invoked for every opcode that the VM executes.
 	step: function(log, db) {
 		// Capture any errors immediately
@@ -107,8 +114,9 @@
 		if (syscall && (op == "CALL" || op == "CALLCODE" || op == "DELEGATECALL" || op == "STATICCALL")) {
 			var to = toAddress(log.stack.peek(1).toString(16));
 
-			// Skip any pre-compile invocations, those are just fancy opcodes
-			if (isPrecompiled(to) && (op == "CALL" || op == "STATICCALL")) {
+			// Skip any pre-compile invocations, those are just fancy opcodes. If they
+			// are requested, the gas they charged is picked up as for any other call.
+			if (!this.includePrecompiles && isPrecompiled(to) && (op == "CALL" || op == "STATICCALL")) {
 				return;
 			}
 			var off = (op == "DELEGATECALL" || op == "STATICCALL" ? 0 : 1);
@@ -179,10 +187,12 @@
 		if (log.getDepth() == this.callstack.length - 1) {
 			// Pop off the last call and get the execution results
 			var call = this.callstack.pop();
+			var used;
 
 			if (call.type == "CREATE" || call.type == "CREATE2") {
 				// If the call was a CREATE, retrieve the contract address and output code
-				call.gasUsed = "0x" + bigInt(call.gas - (log.getGas() - (call.gasIn - call.gasCost))).toString(16);
+				used = call.gas - (log.getGas() - (call.gasIn - call.gasCost));
+				call.gasUsed = "0x" + bigInt(used).toString(16);
 				delete call.gasIn; delete call.gasCost;
 
 				var ret = log.stack.peek(0);
@@ -205,7 +215,8 @@
 			} else {
 				// If the call was a contract call, retrieve the gas usage and output
 				if (call.gas !== undefined) {
-					call.gasUsed = "0x" + bigInt(call.gasIn - call.gasCost + call.gas - log.getGas()).toString(16);
+					used = call.gasIn - call.gasCost + call.gas - log.getGas();
+					call.gasUsed = "0x" + bigInt(used).toString(16);
 				}
 				delete call.gasIn; delete call.gasCost;
 
@@ -233,6 +244,12 @@
 
 				delete call.outOff; delete call.outLen;
 			}
+			// The caller gets back the gas the frame had left, on top of what it retained
+			if (this.includeGasRemaining && used !== undefined) {
+				var remaining = call.gas - used;
+				call.gasRemaining = '0x' + bigInt(remaining).toString(16);
+				call.gasRetained = '0x' + bigInt(log.getGas() - remaining).toString(16);
+			}
 			if (call.gas !== undefined) {
 				call.gas = '0x' + bigInt(call.gas).toString(16);
 			}
@@ -245,6 +262,27 @@
 			}
 			this.callstack[left].calls.push(call);
 		}
+		// Record the logs emitted by the current frame (LOG0 to LOG4)
+		if (this.includeLogs && log.getDepth() == this.callstack.length) {
+			var opcode = log.op.toNumber();
+			if (opcode >= 0xa0 && opcode <= 0xa4) {
+				var frame = this.callstack[this.callstack.length - 1];
+				if (frame.logs === undefined) {
+					frame.logs = [];
+				}
+				var topics = [];
+				for (var i = 0; i < opcode - 0xa0; i++) {
+					topics.push(toHex(toWord(log.stack.peek(2 + i).toString(16))));
+				}
+				var dataOff = log.stack.peek(0).valueOf();
+				var dataEnd = dataOff + log.stack.peek(1).valueOf();
+				frame.logs.push({
+					address: toHex(log.contract.getAddress()),
+					topics:  topics,
+					data:    toHex(log.memory.slice(dataOff, dataEnd)),
+				});
+			}
+		}
 	},
 
 	// fault is invoked when the actual execution of an opcode fails.
@@ -269,6 +307,9 @@
 		if (call.gas !== undefined) {
 			call.gas = '0x' + bigInt(call.gas).toString(16);
 			call.gasUsed = call.gas
+			if (this.includeGasRemaining) {
+				call.gasRemaining = '0x0';
+			}
 		} else {
 			// Retrieve gas true allowance from the inner call.
 			// We need to extract if from within the call as there may be funky gas dynamics
@@ -310,6 +351,9 @@
 			output:  toHex(ctx.output),
 			time:    ctx.time,
 		};
+		if (this.includeGasRemaining) {
+			result.gasRemaining = '0x' + bigInt(ctx.gas - ctx.gasUsed).toString(16);
+		}
 		var extraCtx = {
 			blockHash: ctx.blockHash,
 			blockNumber: ctx.blockNumber,
@@ -325,6 +369,9 @@
 		if (this.callstack[0].calls !== undefined) {
 			result.calls = this.callstack[0].calls;
 		}
+		if (this.callstack[0].logs !== undefined) {
+			result.logs = this.callstack[0].logs;
+		}
 		if (this.callstack[0].error !== undefined) {
 			result.error = this.callstack[0].error;
 		} else if (ctx.error !== undefined) {
@@ -336,10 +383,48 @@
 		return this.finalize(result, extraCtx);
 	},
 
-	// finalize recreates a call object using the final desired field order for json
+	// finalize flattens the call tree into the list of Parity traces, in depth first
+	// order. The tree is walked iteratively with an explicit stack, appending into a
+	// single result list and computing each trace address on the fly, so that very
+	// deep (up to 1024) call stacks don't need a copy of their subtree per frame.
+	finalize: function(call, extraCtx) {
+		var results = [];
+		var pending = [call];
+		call.traceAddress = [];
+
+		while (pending.length > 0) {
+			call = pending.pop();
+			if (call.error !== undefined) {
+				call.reverted = true;
+			}
+			results.push(this.format(call, extraCtx, call.traceAddress));
+
+			var calls = call.calls;
+			if (calls === undefined) {
+				continue;
+			}
+			// Push the children in reverse for them to be popped in order
+			for (var i=calls.length-1; i>=0; i--) {
+				var childCall = calls[i];
+
+				// Delegatecall uses the value from parent
+				if ((childCall.type == "DELEGATECALL" || childCall.type == "STATICCALL") && typeof childCall.value === "undefined") {
+					childCall.value = call.value;
+				}
+				childCall.traceAddress = call.traceAddress.concat([i]);
+				childCall.reverted = call.reverted;
+				pending.push(childCall);
+			}
+			// Release the subtree of the call as it's walked
+			delete call.calls;
+		}
+		return results;
+	},
+
+	// format recreates a call object using the final desired field order for json
 	// serialization. This is a nicety feature to pass meaningfully ordered results
 	// to users who don't interpret it, just display it.
-	finalize: function(call, extraCtx, traceAddress) {
+	format: function(call, extraCtx, traceAddress) {
 		var data;
 		if (call.type == "CREATE" || call.type == "CREATE2") {
 			data = this.createResult(call);
@@ -358,7 +443,6 @@
 			}
 		}
 
-		traceAddress = traceAddress || [];
 		var sorted = {
 			type: call.type.toLowerCase(),
 			action: data.action,
@@ -372,18 +456,23 @@
 			blockHash: extraCtx.blockHash,
 			time: call.time,
 		}
+		// The logs of failed frames and of their callees are reverted
+		if (this.includeLogs && call.type != "SUICIDE") {
+			sorted.logs = call.reverted || call.logs === undefined ? [] : call.logs;
+		}
 
 		if (sorted.error !== undefined) {
-			if (this.parityErrorMapping.hasOwnProperty(sorted.error)) {
-				sorted.error = this.parityErrorMapping[sorted.error];
+			// Convert the EVM error into its exact OpenEthereum counterpart
+			var parityError = toParityError(sorted.error);
+			if (parityError !== undefined) {
+				sorted.error = parityError;
+			}
+			// Failed creations deploy no code to no address, but still burn gas
+			if (this.includeFailedCreateGas && (call.type == "CREATE" || call.type == "CREATE2")) {
+				delete sorted.result.code;
+				delete sorted.result.address;
+			} else if (parityError !== undefined) {
 				delete sorted.result;
-			} else {
-				for (var searchKey in this.parityErrorMappingStartingWith) {
-					if (this.parityErrorMappingStartingWith.hasOwnProperty(searchKey) && sorted.error.indexOf(searchKey) > -1) {
-						sorted.error = this.parityErrorMappingStartingWith[searchKey];
-						delete sorted.result;
-					}
-				}
 			}
 		}
 
@@ -399,26 +488,10 @@
 			}
 		}
 
-		var calls = call.calls;
-		if (calls !== undefined) {
-			sorted["subtraces"] = calls.length;
-		}
-
-		var results = [sorted];
-
-		if (calls !== undefined) {
-			for (var i=0; i<calls.length; i++) {
-				var childCall = calls[i];
-
-				// Delegatecall uses the value from parent
-				if ((childCall.type == "DELEGATECALL" || childCall.type == "STATICCALL") && typeof childCall.value === "undefined") {
-					childCall.value = call.value;
-				}
-
-				results = results.concat(this.finalize(childCall, extraCtx, traceAddress.concat([i])));
-			}
+		if (call.calls !== undefined) {
+			sorted["subtraces"] = call.calls.length;
 		}
-		return results;
+		return sorted;
 	},
 
 	createResult: function(call) {
@@ -431,9 +504,11 @@
 				creationMethod: call.type.toLowerCase(),  // Create Type
 			},
 			result: {
-				gasUsed:  call.gasUsed,  // Gas used
-				code:     call.output,   // Code
-				address:  call.to,       // Assigned address
+				gasUsed:      call.gasUsed,       // Gas used
+				code:         call.output,        // Code
+				address:      call.to,            // Assigned address
+				gasRemaining: call.gasRemaining,  // Gas left when returning (optional)
+				gasRetained:  call.gasRetained,   // Gas the caller retained (optional)
 			}
 		}
 	},
@@ -449,8 +524,10 @@
 				callType:  call.type.toLowerCase(), // The type of the call
 			},
 			result: {
-				gasUsed: call.gasUsed,  // Gas used
-				output:  call.output,   // Output bytes
+				gasUsed:      call.gasUsed,       // Gas used
+				output:       call.output,        // Output bytes
+				gasRemaining: call.gasRemaining,  // Gas left when returning (optional)
+				gasRetained:  call.gasRetained,   // Gas the caller retained (optional)
 			}
 		}
 	},
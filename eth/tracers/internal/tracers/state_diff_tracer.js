@@ -22,6 +22,7 @@
 
 	hasInitCalled: false,
 	lastAccessedAccount: null,
+	lastCreatedAccount: null, // target of the last CREATE|CREATE2, along with its type before it
 	lastRefund: 0,
 	hasError: false,
 
@@ -245,7 +246,7 @@
 				&& this.isObjectEmpty(data.storage));
 	},
 
-	format: function(db) {
+	format: function(db, eip161) {
 		for (var acc in this.stateDiff) {
 			var accountAddress = toAddress(acc);
 			// fetch latest balance
@@ -288,7 +289,9 @@
 				accountData.code = this.formatSingle(accountData.code, type);
 			}
 
-			if (db.empty(toAddress(acc))) {
+			// empty accounts are deleted when touched under EIP-161, so they're
+			// neither born nor died. Before it, they're only reported when created.
+			if (db.empty(toAddress(acc)) && (eip161 || type !== this.diffMarkers.Born)) {
 				delete this.stateDiff[acc];
 				continue;
 			}
@@ -336,6 +339,53 @@
 				continue;
 			}
 		}
+
+		// emit accounts and storage slots in a deterministic, sorted order
+		this.stateDiff = this.sortKeys(this.stateDiff);
+		for (var acc in this.stateDiff) {
+			if (typeof this.stateDiff[acc].storage === "object") {
+				this.stateDiff[acc].storage = this.sortKeys(this.stateDiff[acc].storage);
+			}
+		}
+	},
+
+	// sortKeys returns a copy of the given object with its keys inserted in sorted
+	// order, so that its JSON serialization is deterministic.
+	sortKeys: function(obj) {
+		var sorted = {};
+		var keys = Object.keys(obj).sort();
+		for (var i = 0; i < keys.length; i++) {
+			sorted[keys[i]] = obj[keys[i]];
+		}
+		return sorted;
+	},
+
+	// flagError marks the last accessed account as having had an error, removing
+	// it from the output. A creation colliding with an account already changed by
+	// the transaction (e.g. a CREATE2 recreating a contract which self-destructed
+	// earlier in the same transaction, and therefore still exists until the end of
+	// it) leaves that account untouched, so its previous changes are kept instead.
+	flagError: function(opError) {
+		var acc = this.lastAccessedAccount;
+		var created = this.lastCreatedAccount;
+		this.lastAccessedAccount = null;
+
+		if (this.includeOpError(opError) && created !== null && created.acc === acc && created.type !== undefined) {
+			this.stateDiff[acc]._type = created.type;
+			return;
+		}
+		this.stateDiff[acc]._error = true;  // mark account that had an error
+	},
+
+	// lookupCreatedAccount injects the target of a CREATE|CREATE2 into the stateDiff
+	// object as Born, remembering its previous type in case the creation collides.
+	lookupCreatedAccount: function(addr, db) {
+		var acc = toHex(addr);
+		this.lastCreatedAccount = {
+			acc: acc,
+			type: this.stateDiff[acc] !== undefined ? this.stateDiff[acc]._type : undefined,
+		};
+		this.lookupAccount(addr, db, this.diffMarkers.Born);
 	},
 
 	// includeOpError checks for specific VM OP errors
@@ -373,8 +423,7 @@
 		if ((error !== undefined || this.includeOpError(opError))
 				&& this.lastAccessedAccount !== null
 				&& this.stateDiff[this.lastAccessedAccount] !== undefined) {
-			this.stateDiff[this.lastAccessedAccount]._error = true;  // mark account that had an error
-			this.lastAccessedAccount = null;
+			this.flagError(opError);
 			return;
 		}
 
@@ -387,14 +436,14 @@
 				break;
 			case "CREATE":
 				var address = log.contract.getAddress();
-				this.lookupAccount(toContract(address, db.getNonce(address)), db, this.diffMarkers.Born);
+				this.lookupCreatedAccount(toContract(address, db.getNonce(address)), db);
 				break;
 			case "CREATE2":
 				// stack: salt, size, offset, endowment
 				var offset = log.stack.peek(1).valueOf()
 				var size = log.stack.peek(2).valueOf()
 				var end = offset + size
-				this.lookupAccount(toContract2(log.contract.getAddress(), log.stack.peek(3).toString(16), log.memory.slice(offset, end)), db, this.diffMarkers.Born);
+				this.lookupCreatedAccount(toContract2(log.contract.getAddress(), log.stack.peek(3).toString(16), log.memory.slice(offset, end)), db);
 				break;
 			case "CALL": case "CALLCODE": case "DELEGATECALL": case "STATICCALL":
 				var address = toAddress(log.stack.peek(1).toString(16));
@@ -430,8 +479,7 @@
 		if ((error !== undefined || this.includeOpError(opError))
 				&& this.lastAccessedAccount !== null
 				&& this.stateDiff[this.lastAccessedAccount] !== undefined) {
-			this.stateDiff[this.lastAccessedAccount]._error = true;  // mark account that had an error
-			this.lastAccessedAccount = null;
+			this.flagError(opError);
 		}
 	},
 
@@ -465,9 +513,10 @@
 		var fullGasCost = ctx.gasLimit.multiply(ctx.gasPrice);
 
 		// in case from balance is negative because the tracer has disabled the CanTransfer check,
-		// and the Transfer happened before the CaptureStart and the interpreter execution
-		var hasFromSufficientBalanceForValueAndGasCost = ctx.hasFromSufficientBalanceForValueAndGasCost || false;
-		var hasFromSufficientBalanceForGasCost = ctx.hasFromSufficientBalanceForGasCost || false;
+		// and the Transfer happened before the CaptureStart and the interpreter execution.
+		// Mined transactions don't report it, their sender could always pay for them
+		var hasFromSufficientBalanceForValueAndGasCost = ctx.hasFromSufficientBalanceForValueAndGasCost !== false;
+		var hasFromSufficientBalanceForGasCost = ctx.hasFromSufficientBalanceForGasCost !== false;
 
 		var isCreateType = ctx.type == "CREATE" || ctx.type == "CREATE2";
 		var isCallTypeOnNonExistingAccount = ctx.type == "CALL" && ctx.value.isZero() && !db.exists(ctx.to) && !isPrecompiled(ctx.to)
@@ -522,7 +571,8 @@
 			coinbaseAcc._final = true;
 		}
 
-		this.format(db);
+		// unless told otherwise, assume touched empty accounts are deleted (EIP-161)
+		this.format(db, ctx.eip161 !== false);
 
 		// Return the assembled allocations (stateDiff)
 		return this.stateDiff;
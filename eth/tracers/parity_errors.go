@@ -0,0 +1,70 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// errPrecompileFailed is the error the Parity call tracer reports for failed
+// calls into precompiled contracts.
+const errPrecompileFailed = "precompiled failed"
+
+// parityErrors maps the messages of the EVM errors to the exact error strings
+// reported by OpenEthereum traces.
+var parityErrors = map[string]string{
+	vm.ErrOutOfGas.Error():               "Out of gas",
+	vm.ErrCodeStoreOutOfGas.Error():      "Out of gas",
+	vm.ErrGasUintOverflow.Error():        "Out of gas",
+	vm.ErrMaxCodeSizeExceeded.Error():    "Out of gas",
+	vm.ErrInvalidJump.Error():            "Bad jump destination",
+	vm.ErrExecutionReverted.Error():      "Reverted",
+	vm.ErrReturnDataOutOfBounds.Error():  "Out of bounds",
+	vm.ErrWriteProtection.Error():        "Mutable Call In Static Context",
+	vm.ErrInvalidRetsub.Error():          "Subroutine stack underflow",
+	vm.ErrReturnStackExceeded.Error():    "Subroutine stack overflow",
+	vm.ErrInvalidSubroutineEntry.Error(): "Invalid subroutine entry",
+	errPrecompileFailed:                  "Built-in failed",
+}
+
+// parityErrorPrefixes maps the message prefixes of the parameterised EVM errors
+// (vm.ErrInvalidOpCode, vm.ErrStackUnderflow and vm.ErrStackOverflow) to the
+// exact error strings reported by OpenEthereum traces.
+var parityErrorPrefixes = []struct {
+	prefix string
+	parity string
+}{
+	{"invalid opcode", "Bad instruction"},
+	{"stack underflow", "Stack underflow"},
+	{"stack limit reached", "Out of stack"},
+}
+
+// parityError converts an EVM error message into its OpenEthereum counterpart.
+// The second return value reports whether a mapping exists.
+func parityError(msg string) (string, bool) {
+	if parity, ok := parityErrors[msg]; ok {
+		return parity, true
+	}
+	for _, mapping := range parityErrorPrefixes {
+		if strings.HasPrefix(msg, mapping.prefix) {
+			return mapping.parity, true
+		}
+	}
+	return msg, false
+}
@@ -320,12 +320,15 @@ type Tracer struct {
 	ctx map[string]interface{} // Transaction context gathered throughout execution
 	err error                  // Error, if one has occurred
 
+	precompiles map[common.Address]vm.PrecompiledContract // Precompiled contracts of the traced chain and block, nil until known
+
 	interrupt uint32 // Atomic flag to signal execution interruption
 	reason    error  // Textual reason for the interruption
 
 	supportsStepPerfOptimisations bool  // Checks wether tracer supports `getCallstackLength` method in order to achieve optimal performance for call_tracer*
 	handleNextOpCode              bool  // Flag for step prechecker, instructing that next VM opcode has to be proccessed in `step` method
 	callTracerCallstackLength     *uint // Holds the current callstack length for call tracers, which can be compared with VM depth
+	stepLogOpCodes                bool  // Flag for step prechecker, instructing that LOG opcodes have to be processed too (call tracers reporting logs)
 }
 
 // New instantiates a new tracer instance. code specifies a Javascript snippet,
@@ -417,10 +420,25 @@ func New(code string) (*Tracer, error) {
 		return 1
 	})
 	tracer.vm.PushGlobalGoFunction("isPrecompiled", func(ctx *duktape.Context) int {
-		_, ok := vm.PrecompiledContractsForConfig(params.AllEthashProtocolChanges, big.NewInt(0))[common.BytesToAddress(popSlice(ctx))]
+		// Without an EVM to take the precompiles from, assume all known ones are active
+		precompiles := tracer.precompiles
+		if precompiles == nil {
+			precompiles = vm.PrecompiledContractsForConfig(params.AllEthashProtocolChanges, big.NewInt(0))
+		}
+		_, ok := precompiles[common.BytesToAddress(popSlice(ctx))]
 		ctx.PushBoolean(ok)
 		return 1
 	})
+	tracer.vm.PushGlobalGoFunction("toParityError", func(ctx *duktape.Context) int {
+		msg, ok := parityError(ctx.GetString(-1))
+		ctx.Pop()
+		if !ok {
+			ctx.PushUndefined()
+			return 1
+		}
+		ctx.PushString(msg)
+		return 1
+	})
 	tracer.vm.PushGlobalGoFunction("slice", func(ctx *duktape.Context) int {
 		start, end := ctx.GetInt(-2), ctx.GetInt(-1)
 		ctx.Pop2()
@@ -573,6 +591,13 @@ func wrapError(context string, err error) error {
 func (jst *Tracer) CapturePreEVM(env *vm.EVM, inputs map[string]interface{}) error {
 	jst.dbWrapper.db = env.StateDB
 
+	// Use the precompiled contracts of the traced chain, which may differ from
+	// the mainnet ones (e.g. on custom networks) and change at forks
+	jst.precompiles = vm.PrecompiledContractsForConfig(env.ChainConfig(), env.BlockNumber)
+
+	// Expose whether touched empty accounts get deleted (EIP-161) in this block
+	jst.ctx["eip161"] = env.ChainConfig().IsEnabled(env.ChainConfig().GetEIP161dTransition, env.BlockNumber)
+
 	for key, val := range inputs {
 		jst.ctx[key] = val
 	}
@@ -585,6 +610,10 @@ func (jst *Tracer) CapturePreEVM(env *vm.EVM, inputs map[string]interface{}) err
 			return nil
 		}
 	}
+	// Call tracers reporting the logs of their frames need to step through the
+	// LOG opcodes, skipped by the step prechecker otherwise
+	jst.stepLogOpCodes = jst.vm.GetPropString(jst.tracerObject, "includeLogs") && jst.vm.GetBoolean(-1)
+	jst.vm.Pop()
 
 	return nil
 }
@@ -673,6 +702,8 @@ func (jst *Tracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost
 			} else if op&0xf0 == 0xf0 {
 				jst.handleNextOpCode = true
 				run = true
+			} else if jst.stepLogOpCodes && op >= vm.LOG0 && op <= vm.LOG4 {
+				run = true
 			}
 
 			if !run {
@@ -25,9 +25,11 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
 )
 
 type account struct{}
@@ -178,3 +180,94 @@ func TestHaltBetweenSteps(t *testing.T) {
 		t.Errorf("Expected timeout error, got %v", err)
 	}
 }
+
+// TestSstoreCostPerFork checks that the opcode cost handed to the tracers is the
+// dynamic, fork dependent gas cost and not a static table entry. Step based
+// tracers (e.g. vmTrace) rely on it to report the gas used by each instruction.
+func TestSstoreCostPerFork(t *testing.T) {
+	tests := []struct {
+		name   string
+		config ctypes.ChainConfigurator
+		want   string
+	}{
+		// EIP-2200: fresh slot set costs 20000, rewriting a dirty slot costs SLOAD (800)
+		{"istanbul", params.TestChainConfig, "[20000,800]"},
+		// EIP-2929: a cold slot adds 2100 on first access, later accesses are warm (100)
+		{"berlin", params.YoloV2ChainConfig, "[22100,100]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				origin   = common.HexToAddress("0x1000")
+				contract = common.HexToAddress("0x2000")
+			)
+			statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+			statedb.SetCode(contract, []byte{
+				byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x0, byte(vm.SSTORE),
+				byte(vm.PUSH1), 0x2, byte(vm.PUSH1), 0x0, byte(vm.SSTORE),
+				byte(vm.STOP),
+			})
+			// Mirror the state transition, which warms up the sender and recipient
+			statedb.AddAddressToAccessList(origin)
+			statedb.AddAddressToAccessList(contract)
+			tracer, err := New("{costs: [], step: function(log) { if (log.op.toString() == 'SSTORE') this.costs.push(log.getCost()); }, fault: function() {}, result: function() { return this.costs; }}")
+			if err != nil {
+				t.Fatal(err)
+			}
+			context := vm.Context{
+				CanTransfer: func(vm.StateDB, common.Address, *big.Int) bool { return true },
+				Transfer:    func(vm.StateDB, common.Address, common.Address, *big.Int) {},
+				Origin:      origin,
+				BlockNumber: big.NewInt(1),
+			}
+			env := vm.NewEVM(context, statedb, tt.config, vm.Config{Debug: true, Tracer: tracer})
+			if _, _, err := env.Call(vm.AccountRef(origin), contract, nil, 100000, new(big.Int)); err != nil {
+				t.Fatal(err)
+			}
+			ret, err := tracer.GetResult()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(ret) != tt.want {
+				t.Errorf("SSTORE cost mismatch: have %s, want %s", ret, tt.want)
+			}
+		})
+	}
+}
+
+// Tests that EVM errors are converted into the exact error strings reported by
+// OpenEthereum traces.
+func TestParityErrorMapping(t *testing.T) {
+	tests := []struct {
+		err    string
+		parity string
+		mapped bool
+	}{
+		{vm.ErrOutOfGas.Error(), "Out of gas", true},
+		{vm.ErrCodeStoreOutOfGas.Error(), "Out of gas", true},
+		{vm.ErrGasUintOverflow.Error(), "Out of gas", true},
+		{vm.ErrMaxCodeSizeExceeded.Error(), "Out of gas", true},
+		{vm.ErrInvalidJump.Error(), "Bad jump destination", true},
+		{vm.ErrExecutionReverted.Error(), "Reverted", true},
+		{vm.ErrReturnDataOutOfBounds.Error(), "Out of bounds", true},
+		{vm.ErrWriteProtection.Error(), "Mutable Call In Static Context", true},
+		{vm.ErrInvalidRetsub.Error(), "Subroutine stack underflow", true},
+		{vm.ErrReturnStackExceeded.Error(), "Subroutine stack overflow", true},
+		{vm.ErrInvalidSubroutineEntry.Error(), "Invalid subroutine entry", true},
+		{(&vm.ErrInvalidOpCode{}).Error(), "Bad instruction", true},
+		{"invalid opcode: opcode 0xfe not defined", "Bad instruction", true},
+		{(&vm.ErrStackUnderflow{}).Error(), "Stack underflow", true},
+		{"stack underflow (0 <=> 2)", "Stack underflow", true},
+		{(&vm.ErrStackOverflow{}).Error(), "Out of stack", true},
+		{"stack limit reached 1024 (1023)", "Out of stack", true},
+		{"precompiled failed", "Built-in failed", true},
+		{vm.ErrDepth.Error(), vm.ErrDepth.Error(), false},
+		{vm.ErrInsufficientBalance.Error(), vm.ErrInsufficientBalance.Error(), false},
+	}
+	for i, tt := range tests {
+		parity, mapped := parityError(tt.err)
+		if parity != tt.parity || mapped != tt.mapped {
+			t.Errorf("test %d (%q): mapping mismatch: have (%q, %v), want (%q, %v)", i, tt.err, parity, mapped, tt.parity, tt.mapped)
+		}
+	}
+}
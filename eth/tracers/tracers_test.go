@@ -17,6 +17,7 @@
 package tracers
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"crypto/rand"
 	"encoding/json"
@@ -25,6 +26,7 @@ import (
 	"math/big"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
@@ -39,6 +41,7 @@ import (
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/vars"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/tests"
 )
@@ -248,6 +251,110 @@ func TestPrestateTracerCreate2(t *testing.T) {
 	}
 }
 
+// TestCallTracerParityFactoryCreates checks that contract initiated creates
+// (including creates nested inside a constructor) report the creating frame's
+// address as action.from.
+func TestCallTracerParityFactoryCreates(t *testing.T) {
+	var (
+		factory = common.HexToAddress("0x00000000000000000000000000000000000fac70")
+		child1  = crypto.CreateAddress(factory, 1)
+		child2  = crypto.CreateAddress(factory, 2)
+		grand1  = crypto.CreateAddress(child1, 1)
+		grand2  = crypto.CreateAddress(child2, 1)
+	)
+	unsignedTx := types.NewTransaction(0, factory, new(big.Int), 1000000, big.NewInt(1), nil)
+
+	privateKeyECDSA, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("err %v", err)
+	}
+	signer := types.NewEIP155Signer(big.NewInt(1))
+	tx, err := types.SignTx(unsignedTx, signer, privateKeyECDSA)
+	if err != nil {
+		t.Fatalf("err %v", err)
+	}
+	origin, _ := signer.Sender(tx)
+	context := vm.Context{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		Origin:      origin,
+		Coinbase:    common.Address{},
+		BlockNumber: new(big.Int).SetUint64(8000000),
+		Time:        new(big.Int).SetUint64(5),
+		Difficulty:  big.NewInt(0x30000),
+		GasLimit:    uint64(6000000),
+		GasPrice:    big.NewInt(1),
+	}
+	alloc := genesisT.GenesisAlloc{}
+
+	// The factory stores the child init code 0x600160006000f000 in memory and
+	// CREATEs it twice. Each child constructor in turn CREATEs a grandchild
+	// from the single byte init code 0x00.
+	alloc[factory] = genesisT.GenesisAccount{
+		Nonce:   1,
+		Code:    hexutil.MustDecode("0x67600160006000f000600052600860186000f050600860186000f05000"),
+		Balance: big.NewInt(0),
+	}
+	alloc[origin] = genesisT.GenesisAccount{
+		Nonce:   0,
+		Code:    []byte{},
+		Balance: big.NewInt(500000000000000),
+	}
+	_, statedb := tests.MakePreState(rawdb.NewMemoryDatabase(), alloc, false)
+
+	tracer, err := New("callTracerParity")
+	if err != nil {
+		t.Fatalf("failed to create call tracer: %v", err)
+	}
+	evm := vm.NewEVM(context, statedb, params.MainnetChainConfig, vm.Config{Debug: true, Tracer: tracer})
+
+	msg, err := tx.AsMessage(signer)
+	if err != nil {
+		t.Fatalf("failed to prepare transaction for tracing: %v", err)
+	}
+	st := core.NewStateTransition(evm, msg, new(core.GasPool).AddGas(tx.Gas()))
+	if _, err = st.TransitionDb(); err != nil {
+		t.Fatalf("failed to execute transaction: %v", err)
+	}
+	res, err := tracer.GetResult()
+	if err != nil {
+		t.Fatalf("failed to retrieve trace result: %v", err)
+	}
+	traces := new([]callTraceParity)
+	if err := json.Unmarshal(res, traces); err != nil {
+		t.Fatalf("failed to unmarshal trace result: %v", err)
+	}
+	want := []struct {
+		typ          string
+		from         common.Address
+		address      common.Address
+		traceAddress []int
+	}{
+		{"call", origin, common.Address{}, []int{}},
+		{"create", factory, child1, []int{0}},
+		{"create", child1, grand1, []int{0, 0}},
+		{"create", factory, child2, []int{1}},
+		{"create", child2, grand2, []int{1, 0}},
+	}
+	if len(*traces) != len(want) {
+		t.Fatalf("trace count mismatch: have %d, want %d", len(*traces), len(want))
+	}
+	for i, trace := range *traces {
+		if trace.Type != want[i].typ {
+			t.Errorf("trace %d: type mismatch: have %s, want %s", i, trace.Type, want[i].typ)
+		}
+		if trace.Action.From != want[i].from {
+			t.Errorf("trace %d: action.from mismatch: have %x, want %x", i, trace.Action.From, want[i].from)
+		}
+		if !reflect.DeepEqual(trace.TraceAddress, want[i].traceAddress) {
+			t.Errorf("trace %d: traceAddress mismatch: have %v, want %v", i, trace.TraceAddress, want[i].traceAddress)
+		}
+		if trace.Type == "create" && (trace.Result.Address == nil || *trace.Result.Address != want[i].address) {
+			t.Errorf("trace %d: created address mismatch: have %v, want %x", i, trace.Result.Address, want[i].address)
+		}
+	}
+}
+
 // Iterates over all the input-output datasets in the tracer test harness and
 // runs the JavaScript tracers against them.
 func TestCallTracer(t *testing.T) {
@@ -404,6 +511,18 @@ func callTracerParityTestRunner(filename string) error {
 	if err := json.Unmarshal(res, ret); err != nil {
 		return fmt.Errorf("failed to unmarshal trace result: %v", err)
 	}
+	// Like OpenEthereum's, create actions have no recipient at all, the created
+	// address is only reported in the result (which the typed traces can't tell)
+	var raw []map[string]interface{}
+	if err := json.Unmarshal(res, &raw); err != nil {
+		return fmt.Errorf("failed to unmarshal raw trace result: %v", err)
+	}
+	for i, trace := range raw {
+		action, _ := trace["action"].(map[string]interface{})
+		if to, ok := action["to"]; ok && trace["type"] == "create" {
+			return fmt.Errorf("create trace %d has a recipient: %v", i, to)
+		}
+	}
 
 	if !jsonEqualParity(ret, test.Result) {
 		// uncomment this for easier debugging
@@ -475,6 +594,82 @@ func BenchmarkCallTracerParity(b *testing.B) {
 	}
 }
 
+// runDeepRecursionTrace traces a transaction calling a contract which recursively
+// calls itself with all its gas, up to the maximum call depth, returning the raw
+// callTracerParity result.
+func runDeepRecursionTrace() (json.RawMessage, error) {
+	var (
+		origin    = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+		recursive = common.HexToAddress("0x00000000000000000000000000000000000deeb")
+	)
+	context := vm.Context{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		Origin:      origin,
+		Coinbase:    common.Address{},
+		BlockNumber: new(big.Int).SetUint64(8000000),
+		Time:        new(big.Int).SetUint64(5),
+		Difficulty:  big.NewInt(0x30000),
+		GasLimit:    math.MaxUint64,
+		GasPrice:    big.NewInt(1),
+	}
+	// The contract CALLs itself forwarding all its gas: PUSH1 0, DUP1 x4, ADDRESS, GAS, CALL, STOP
+	alloc := genesisT.GenesisAlloc{
+		recursive: {Code: hexutil.MustDecode("0x600080808080305af100"), Balance: new(big.Int)},
+		origin:    {Balance: new(big.Int).SetUint64(math.MaxUint64)},
+	}
+	_, statedb := tests.MakePreState(rawdb.NewMemoryDatabase(), alloc, false)
+
+	tracer, err := New("callTracerParity")
+	if err != nil {
+		return nil, err
+	}
+	evm := vm.NewEVM(context, statedb, params.MainnetChainConfig, vm.Config{Debug: true, Tracer: tracer})
+
+	// Enough gas to reach the maximum depth despite retaining 1/64th per call
+	gas := uint64(1000000000000)
+	msg := types.NewMessage(origin, &recursive, 0, new(big.Int), gas, big.NewInt(1), nil, false)
+	if _, err := core.NewStateTransition(evm, msg, new(core.GasPool).AddGas(gas)).TransitionDb(); err != nil {
+		return nil, err
+	}
+	return tracer.GetResult()
+}
+
+// Tests that callTracerParity flattens a maximally deep call stack, assigning the
+// correct trace address to each frame.
+func TestCallTracerParityDeepRecursion(t *testing.T) {
+	res, err := runDeepRecursionTrace()
+	if err != nil {
+		t.Fatalf("failed to trace deep recursion: %v", err)
+	}
+	traces := new([]callTraceParity)
+	if err := json.Unmarshal(res, traces); err != nil {
+		t.Fatalf("failed to unmarshal trace result: %v", err)
+	}
+	if len(*traces) <= int(vars.CallCreateDepth) {
+		t.Fatalf("trace count mismatch: have %d, want more than %d", len(*traces), vars.CallCreateDepth)
+	}
+	for i, trace := range *traces {
+		if len(trace.TraceAddress) != i {
+			t.Fatalf("trace %d: traceAddress depth mismatch: have %d, want %d", i, len(trace.TraceAddress), i)
+		}
+		for j, index := range trace.TraceAddress {
+			if index != 0 {
+				t.Fatalf("trace %d: traceAddress[%d] mismatch: have %d, want 0", i, j, index)
+			}
+		}
+	}
+}
+
+func BenchmarkCallTracerParityDeepRecursion(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		if _, err := runDeepRecursionTrace(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 type stateDiffAccount struct {
 	Balance interface{}                            `json:"balance"` // Can be either string "=" or mapping "*" => {"from": "hex", "to": "hex"}
 	Code    interface{}                            `json:"code"`
@@ -489,17 +684,9 @@ type stateDiffTest struct {
 	Result  map[common.Address]*stateDiffAccount `json:"result"`
 }
 
-func stateDiffTracerTestRunner(filename string) error {
-	// Call tracer test found, read if from disk
-	blob, err := ioutil.ReadFile(filepath.Join("testdata", filename))
-	if err != nil {
-		return fmt.Errorf("failed to read testcase: %v", err)
-	}
-	test := new(stateDiffTest)
-	if err := json.Unmarshal(blob, test); err != nil {
-		return fmt.Errorf("failed to parse testcase: %v", err)
-	}
-
+// runStateDiffTracer executes the transaction of a state diff test case with the
+// state diff tracer attached, returning the raw trace result.
+func runStateDiffTracer(test *stateDiffTest) (json.RawMessage, error) {
 	// Configure a blockchain with the given prestate
 	msg := test.Input.ToMessage(uint64(test.Context.GasLimit))
 
@@ -561,7 +748,7 @@ func stateDiffTracerTestRunner(filename string) error {
 	// Create the tracer, the EVM environment and run it
 	tracer, err := New("stateDiffTracer")
 	if err != nil {
-		return fmt.Errorf("failed to create state diff tracer: %v", err)
+		return nil, fmt.Errorf("failed to create state diff tracer: %v", err)
 	}
 	evm := vm.NewEVM(context, statedb, test.Genesis.Config, vm.Config{Debug: true, Tracer: tracer})
 
@@ -569,13 +756,39 @@ func stateDiffTracerTestRunner(filename string) error {
 
 	st := core.NewStateTransition(evm, msg, new(core.GasPool).AddGas(msg.Gas()))
 	if _, err = st.TransitionDb(); err != nil {
-		return fmt.Errorf("failed to execute transaction: %v", err)
+		return nil, fmt.Errorf("failed to execute transaction: %v", err)
 	}
-
-	// Retrieve the trace result and compare against the etalon
+	// Retrieve the trace result
 	res, err := tracer.GetResult()
 	if err != nil {
-		return fmt.Errorf("failed to retrieve trace result: %v", err)
+		return nil, fmt.Errorf("failed to retrieve trace result: %v", err)
+	}
+	return res, nil
+}
+
+// readStateDiffTest loads a state diff test case from the testdata folder.
+func readStateDiffTest(filename string) (*stateDiffTest, error) {
+	blob, err := ioutil.ReadFile(filepath.Join("testdata", filename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read testcase: %v", err)
+	}
+	test := new(stateDiffTest)
+	if err := json.Unmarshal(blob, test); err != nil {
+		return nil, fmt.Errorf("failed to parse testcase: %v", err)
+	}
+	return test, nil
+}
+
+func stateDiffTracerTestRunner(filename string) error {
+	// Call tracer test found, read if from disk
+	test, err := readStateDiffTest(filename)
+	if err != nil {
+		return err
+	}
+	// Run the transaction and compare the trace result against the etalon
+	res, err := runStateDiffTracer(test)
+	if err != nil {
+		return err
 	}
 	ret := new(map[common.Address]*stateDiffAccount)
 	if err := json.Unmarshal(res, ret); err != nil {
@@ -632,3 +845,138 @@ func jsonEqualStateDiff(x, y interface{}) bool {
 	}
 	return reflect.DeepEqual(xTrace, yTrace)
 }
+
+// jsonObjectKeys returns the keys of a JSON object in their serialized order.
+func jsonObjectKeys(blob []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(blob))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("not a JSON object: %v", err)
+	}
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, tok.(string))
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// Tests that the state diff tracer output is byte-identical across runs of the
+// same transaction, with accounts and storage slots emitted in sorted order.
+func TestStateDiffTracerDeterministic(t *testing.T) {
+	files, err := ioutil.ReadDir("testdata")
+	if err != nil {
+		t.Fatalf("failed to retrieve tracer test suite: %v", err)
+	}
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name(), "state_diff_tracer_") {
+			continue
+		}
+		test, err := readStateDiffTest(file.Name())
+		if err != nil {
+			t.Fatalf("%s: %v", file.Name(), err)
+		}
+		first, err := runStateDiffTracer(test)
+		if err != nil {
+			t.Fatalf("%s: %v", file.Name(), err)
+		}
+		for i := 0; i < 3; i++ {
+			again, err := runStateDiffTracer(test)
+			if err != nil {
+				t.Fatalf("%s: %v", file.Name(), err)
+			}
+			if !bytes.Equal(first, again) {
+				t.Fatalf("%s: output mismatch on run %d:\nhave %s\nwant %s", file.Name(), i+2, again, first)
+			}
+		}
+		// Ensure both accounts and their storage slots are sorted
+		keys, err := jsonObjectKeys(first)
+		if err != nil {
+			t.Fatalf("%s: failed to decode output: %v", file.Name(), err)
+		}
+		if !sort.StringsAreSorted(keys) {
+			t.Errorf("%s: accounts not sorted: %v", file.Name(), keys)
+		}
+		var accounts map[string]struct {
+			Storage json.RawMessage `json:"storage"`
+		}
+		if err := json.Unmarshal(first, &accounts); err != nil {
+			t.Fatalf("%s: failed to decode output: %v", file.Name(), err)
+		}
+		for addr, account := range accounts {
+			slots, err := jsonObjectKeys(account.Storage)
+			if err != nil {
+				t.Fatalf("%s: failed to decode storage of %s: %v", file.Name(), addr, err)
+			}
+			if !sort.StringsAreSorted(slots) {
+				t.Errorf("%s: storage of %s not sorted: %v", file.Name(), addr, slots)
+			}
+		}
+	}
+}
+
+// Tests that the state diff tracer follows the EIP-161 semantics of the active
+// fork: touched empty accounts are created before Spurious Dragon, but deleted
+// (and thus not reported at all) after it.
+func TestStateDiffTracerEIP161(t *testing.T) {
+	var (
+		sender  = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+		touched = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+		miner   = common.HexToAddress("0x00000000000000000000000000000000000000cc")
+		gas     = hexutil.Uint64(vars.TxGas)
+	)
+	spurious := params.MainnetChainConfig.GetEIP161dTransition()
+
+	tests := []struct {
+		number uint64
+		born   bool
+	}{
+		{*spurious - 1, true},
+		{*spurious, false},
+	}
+	for _, tt := range tests {
+		test := &stateDiffTest{
+			Genesis: &genesisT.Genesis{
+				Config: params.MainnetChainConfig,
+				Alloc: genesisT.GenesisAlloc{
+					sender: {Balance: big.NewInt(vars.Ether), Nonce: 1},
+					miner:  {Balance: big.NewInt(vars.Ether)},
+				},
+			},
+			Context: &callContext{
+				Number:     math.HexOrDecimal64(tt.number),
+				Difficulty: (*math.HexOrDecimal256)(big.NewInt(1)),
+				GasLimit:   math.HexOrDecimal64(vars.GenesisGasLimit),
+				Miner:      miner,
+			},
+			Input: ethapi.CallArgs{From: &sender, To: &touched, Gas: &gas},
+		}
+		res, err := runStateDiffTracer(test)
+		if err != nil {
+			t.Fatalf("block %d: %v", tt.number, err)
+		}
+		var diff map[common.Address]*stateDiffAccount
+		if err := json.Unmarshal(res, &diff); err != nil {
+			t.Fatalf("block %d: failed to decode state diff: %v", tt.number, err)
+		}
+		account, ok := diff[touched]
+		if ok != tt.born {
+			t.Fatalf("block %d: touched account presence mismatch: have %v, want %v: %s", tt.number, ok, tt.born, res)
+		}
+		if ok {
+			if want := map[string]interface{}{"+": "0x0"}; !reflect.DeepEqual(account.Balance, want) {
+				t.Errorf("block %d: touched account balance mismatch: have %v, want %v", tt.number, account.Balance, want)
+			}
+		}
+		if _, ok := diff[sender]; !ok {
+			t.Errorf("block %d: sender missing from state diff: %s", tt.number, res)
+		}
+	}
+}
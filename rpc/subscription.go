@@ -147,6 +147,12 @@ func (n *Notifier) Closed() <-chan interface{} {
 	return n.h.conn.closed()
 }
 
+// Conn returns a value identifying the RPC connection the notifier is tied to,
+// shared by the notifiers of all the subscriptions made over that connection.
+func (n *Notifier) Conn() interface{} {
+	return n.h
+}
+
 // takeSubscription returns the subscription (if one has been created). No subscription can
 // be created after this call.
 func (n *Notifier) takeSubscription() *Subscription {
@@ -0,0 +1,47 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracefilter
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/eth"
+)
+
+// Fuzz throws arbitrary JSON at the trace_filter argument decoder. Decoding
+// must never panic, and any arguments it accepts must survive an encode-decode
+// round trip unchanged.
+func Fuzz(input []byte) int {
+	var args eth.TraceFilterArgs
+	if err := json.Unmarshal(input, &args); err != nil {
+		return 0
+	}
+	output, err := json.Marshal(&args)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode decoded args %+v: %v", args, err))
+	}
+	var decoded eth.TraceFilterArgs
+	if err := json.Unmarshal(output, &decoded); err != nil {
+		panic(fmt.Sprintf("failed to decode encoded args %s: %v", output, err))
+	}
+	if !reflect.DeepEqual(args, decoded) {
+		panic(fmt.Sprintf("encode-decode is not equal, \ninput : %s\noutput: %s", input, output))
+	}
+	return 1
+}
@@ -0,0 +1,58 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracefilter
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// TestCorpus runs the fuzzer over the seed corpus and a set of adversarial
+// inputs, which must all be handled without panicking.
+func TestCorpus(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("corpus", "*"))
+	if err != nil {
+		t.Fatalf("failed to list corpus: %v", err)
+	}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read corpus file %s: %v", file, err)
+		}
+		Fuzz(data)
+	}
+	for _, input := range []string{
+		``,
+		`null`,
+		`{}`,
+		`[]`,
+		`"0x1"`,
+		`{"fromBlock":null,"toAddress":null}`,
+		`{"fromBlock":"0x","toBlock":"0xffffffffffffffffff"}`,
+		`{"fromBlock":"latest","toBlock":"pending"}`,
+		`{"fromBlock":1,"toBlock":true}`,
+		`{"fromAddress":"0x01","toAddress":["0x0000000000000000000000000000000000000001"]}`,
+		`{"fromAddress":"0xzz00000000000000000000000000000000000001"}`,
+		`{"after":-1,"count":18446744073709551616}`,
+		`{"fromBlock":"0x1","fromBlock":"0x2"}`,
+		`{"fromBlock":{"fromBlock":{"fromBlock":"0x1"}}}`,
+		"{\"fromBlock\":\"0x1\u0000\"}",
+	} {
+		Fuzz([]byte(input))
+	}
+}